@@ -18,17 +18,25 @@ import (
 
 func init() {
 	backupCmd.PersistentFlags().String("address", "127.0.0.1:8445", "Regatta maintenance API address.")
-	backupCmd.PersistentFlags().String("dir", "", "Target directory (current directory if empty).")
+	backupCmd.PersistentFlags().String("dir", "", "Target directory or S3 location in the s3://bucket/prefix form (current directory if empty).")
+	backupCmd.PersistentFlags().String("since", "", "Location of the previous backup, only the changes since the previous backup are backed up if set.")
 	backupCmd.PersistentFlags().String("ca", "", "Path to the client CA certificate.")
 	backupCmd.PersistentFlags().String("token", "", "The access token to use for the authentication.")
 	backupCmd.PersistentFlags().Bool("json", false, "Enables JSON logging.")
+	backupCmd.PersistentFlags().String("key-file", "", "Path to the file with the 256-bit key (raw, hex or base64 encoded) the backup files are encrypted with.")
+	backupCmd.PersistentFlags().String("passphrase-file", "", "Path to the file with the passphrase the key the backup files are encrypted with is derived from.")
+	backupCmd.PersistentFlags().AddFlagSet(s3FlagSet)
 }
 
 var backupCmd = &cobra.Command{
 	Use:   "backup",
-	Short: "Backup Regatta to local files.",
-	Long: `Command backs up Regatta into a directory of choice. All tables present in the target server are backed up.
-Backup consists of file per a table in a binary compressed form and a human-readable manifest file. Use restore command to load backup into the server.`,
+	Short: "Backup Regatta to local files or S3-compatible object storage.",
+	Long: `Command backs up Regatta into a directory or an S3 bucket of choice. All tables present in the target server are backed up.
+Backup consists of file per a table in a binary zstd compressed form and a human-readable manifest file. Use restore command to load backup into the server.
+Incremental backup holding only the changes since the previous backup is created if the previous backup directory is provided,
+the changes are read from the Raft log of the tables so the previous backup must not be older than the retained log.
+Backup files are uploaded to the S3 bucket as they are streamed from the server, the manifest file is written once all the tables are backed up.
+Backup files are encrypted by AES-256-GCM with a random data key per file if a key file or a passphrase is provided, the data key is encrypted by the provided key.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var cp *x509.CertPool
 		ca := viper.GetString("ca")
@@ -50,9 +58,17 @@ Backup consists of file per a table in a binary compressed form and a human-read
 			return err
 		}
 
+		key, err := loadBackupKey()
+		if err != nil {
+			return err
+		}
+
 		b := backup.Backup{
-			Conn: conn,
-			Dir:  viper.GetString("dir"),
+			Conn:  conn,
+			Dir:   viper.GetString("dir"),
+			Since: viper.GetString("since"),
+			Open:  openBackupStorage,
+			Key:   key,
 		}
 		if viper.GetBool("json") {
 			l := rl.NewLogger(false, zap.InfoLevel.String())
@@ -3,8 +3,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"errors"
+	"os"
 	"runtime"
 	"strconv"
 	"sync"
@@ -15,6 +18,7 @@ import (
 	"github.com/jamf/regatta/cert"
 	rl "github.com/jamf/regatta/log"
 	"github.com/jamf/regatta/regattaserver"
+	"github.com/jamf/regatta/replication/backup"
 	"github.com/jamf/regatta/storage/table"
 	dbl "github.com/lni/dragonboat/v4/logger"
 	"github.com/spf13/viper"
@@ -103,6 +107,36 @@ func (tokenCredentials) RequireTransportSecurity() bool {
 	return true
 }
 
+// openBackupStorage opens the backup location, the S3 locations are configured by the s3 flags.
+func openBackupStorage(location string) (backup.Storage, error) {
+	return backup.OpenStorage(location, backup.S3Config{
+		Endpoint:  viper.GetString("s3.endpoint"),
+		Region:    viper.GetString("s3.region"),
+		AccessKey: viper.GetString("s3.access-key"),
+		SecretKey: viper.GetString("s3.secret-key"),
+		Insecure:  viper.GetBool("s3.insecure"),
+		PartSize:  viper.GetUint64("s3.part-size"),
+	})
+}
+
+// loadBackupKey loads the key the backup files are encrypted with, the files are not encrypted if no key is configured.
+func loadBackupKey() (*backup.Key, error) {
+	keyFile, passphraseFile := viper.GetString("key-file"), viper.GetString("passphrase-file")
+	switch {
+	case keyFile != "" && passphraseFile != "":
+		return nil, errors.New("only one of key-file and passphrase-file could be set")
+	case keyFile != "":
+		return backup.LoadKeyFile(keyFile)
+	case passphraseFile != "":
+		passphrase, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, err
+		}
+		return backup.NewPassphraseKey(bytes.TrimRight(passphrase, "\r\n"))
+	}
+	return nil, nil
+}
+
 func parseInitialMembers(members map[string]string) (map[uint64]string, error) {
 	initialMembers := make(map[uint64]string)
 	for kStr, v := range members {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jamf/regatta/storage/watch"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -20,6 +21,8 @@ var (
 	storageFlagSet      = pflag.NewFlagSet("storage", pflag.ContinueOnError)
 	maintenanceFlagSet  = pflag.NewFlagSet("maintenance", pflag.ContinueOnError)
 	experimentalFlagSet = pflag.NewFlagSet("experimental", pflag.ContinueOnError)
+	// s3FlagSet is initialized eagerly as it is shared by the commands initialized before this file.
+	s3FlagSet = newS3FlagSet()
 )
 
 func init() {
@@ -99,6 +102,7 @@ At least one reachable Regatta instance is required to successfully bootstrap th
 	// Storage flags
 	storageFlagSet.Int64("storage.block-cache-size", 16*1024*1024, "Shared block cache size in bytes, the cache is used to hold uncompressed blocks of data in memory.")
 	storageFlagSet.Int("storage.table-cache-size", 1024, "Shared table cache size, the cache is used to hold handles to open SSTs.")
+	storageFlagSet.Int("storage.watch-history-size", watch.DefaultHistorySize, "Number of the most recent events retained per table, watches could be resumed only from the retained revisions.")
 
 	// Maintenance flags
 	maintenanceFlagSet.Bool("maintenance.enabled", true, "Whether maintenance API is enabled.")
@@ -126,3 +130,14 @@ func initConfig(set *pflag.FlagSet) {
 		panic(fmt.Errorf("error reading config %v", err))
 	}
 }
+
+func newS3FlagSet() *pflag.FlagSet {
+	set := pflag.NewFlagSet("s3", pflag.ContinueOnError)
+	set.String("s3.endpoint", "s3.amazonaws.com", "S3-compatible object storage endpoint, used for the backup locations in the s3://bucket/prefix form.")
+	set.String("s3.region", "", "S3 bucket region, looked up if empty.")
+	set.String("s3.access-key", "", "S3 access key, if left empty (default) the credentials are read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY env variables or the instance metadata.")
+	set.String("s3.secret-key", "", "S3 secret key.")
+	set.Bool("s3.insecure", false, "Whether to connect to the S3 endpoint without TLS.")
+	set.Uint64("s3.part-size", 16*1024*1024, "Size of the parts the backup files are uploaded in, a file could have at most 10000 parts.")
+	return set
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -55,6 +56,28 @@ func init() {
 	followerCmd.PersistentFlags().Uint64("replication.max-recv-message-size-bytes", 8*1024*1024, "The maximum size of single replication message allowed to receive.")
 	followerCmd.PersistentFlags().Uint64("replication.max-recovery-in-flight", 1, "The maximum number of recovery goroutines allowed to run in this instance.")
 	followerCmd.PersistentFlags().Uint64("replication.max-snapshot-recv-bytes-per-second", 0, "Maximum bytes per second received by the snapshot API client, default value 0 means unlimited.")
+	followerCmd.PersistentFlags().Bool("replication.streaming", false, "Whether the replication stream should be kept open and the leader should push the new commands as they are applied instead of being polled. Falls back to polling against the leaders not supporting the streaming.")
+	followerCmd.PersistentFlags().Duration("replication.heartbeat-interval", 1*time.Second, "The maximum interval between the messages of the open replication stream, the stream is reopened if no message arrives within 3 intervals.")
+	followerCmd.PersistentFlags().String("replication.table-deletion", "disabled", "How the tables deleted in the leader cluster are handled. Allowed values are disabled (tables are kept), dry-run (tables are only reported in the log) and enabled (tables are deleted). Tables not replicated from the leader or excluded by replication.include-tables and replication.exclude-tables are never deleted.")
+	followerCmd.PersistentFlags().Duration("replication.table-deletion-grace-period", 1*time.Hour, "How long the table must be missing in the leader cluster before it is deleted.")
+	followerCmd.PersistentFlags().StringSlice("replication.include-tables", nil, "Glob patterns of the tables to replicate, all the tables are replicated if empty.")
+	followerCmd.PersistentFlags().StringSlice("replication.exclude-tables", nil, "Glob patterns of the tables not to replicate, takes precedence over replication.include-tables.")
+	followerCmd.PersistentFlags().StringSlice("replication.key-prefixes", nil, "Prefixes of the keys to replicate in the form table=prefix, the flag could be repeated to replicate multiple prefixes of a single table. All the keys are replicated in tables without prefixes. Transactions comparing the keys without the prefixes could not be replicated, the table is recovered from the leader snapshot instead.")
+	followerCmd.PersistentFlags().Bool("replication.server.enabled", false, "Whether replication API is enabled, other followers could replicate from this follower instead of the leader cluster.")
+	followerCmd.PersistentFlags().Uint64("replication.server.max-send-message-size-bytes", regattaserver.DefaultMaxGRPCSize, `The target maximum size of single replication message allowed to send.
+Under some circumstances, a larger message could be sent. Followers should be able to accept slightly larger messages.`)
+	followerCmd.PersistentFlags().String("replication.server.address", ":8444", "Replication API server address.")
+	followerCmd.PersistentFlags().String("replication.server.cert-filename", "hack/replication/server.crt", "Path to the API server certificate.")
+	followerCmd.PersistentFlags().String("replication.server.key-filename", "hack/replication/server.key", "Path to the API server private key file.")
+	followerCmd.PersistentFlags().String("replication.server.ca-filename", "hack/replication/ca.crt", "Path to the API server CA cert file.")
+	followerCmd.PersistentFlags().Duration("replication.server.snapshot-retention", 5*time.Minute, "How long the snapshot of the interrupted snapshot stream is held for the follower to resume the download. Value 0 means the snapshot streams are not resumable.")
+
+	// Forwarding flags
+	followerCmd.PersistentFlags().Bool("forwarding.enabled", false, "Whether write requests should be forwarded to the leader cluster instead of being rejected.")
+	followerCmd.PersistentFlags().String("forwarding.leader-address", "localhost:8443", "Address of the leader KV API to forward the write requests to.")
+	followerCmd.PersistentFlags().String("forwarding.ca-filename", "hack/server.crt", "Path to the CA cert file used to verify the leader KV API certificate.")
+	followerCmd.PersistentFlags().Bool("forwarding.wait-for-replication", false, "Whether the forwarded write responses should be returned only after the written revision has been replicated to this cluster (read-your-writes).")
+	followerCmd.PersistentFlags().Duration("forwarding.wait-for-replication-timeout", regattaserver.DefaultWaitTimeout, "Maximum time to wait for the forwarded write to be replicated to this cluster.")
 }
 
 var followerCmd = &cobra.Command{
@@ -110,6 +133,7 @@ func follower(_ *cobra.Command, _ []string) {
 		EnableMetrics:       true,
 		MaxReceiveQueueSize: viper.GetUint64("raft.max-recv-queue-size"),
 		MaxSendQueueSize:    viper.GetUint64("raft.max-send-queue-size"),
+		WatchHistorySize:    viper.GetInt("storage.watch-history-size"),
 		Gossip: storage.GossipConfig{
 			BindAddress:      viper.GetString("memberlist.address"),
 			AdvertiseAddress: viper.GetString("memberlist.advertise-address"),
@@ -155,6 +179,7 @@ func follower(_ *cobra.Command, _ []string) {
 	defer engine.Close()
 
 	// Replication
+	var replicator *replication.Manager
 	{
 		c, err := cert.New(viper.GetString("replication.cert-filename"), viper.GetString("replication.key-filename"))
 		if err != nil {
@@ -176,8 +201,39 @@ func follower(_ *cobra.Command, _ []string) {
 			log.Panicf("cannot create replication conn: %v", err)
 		}
 
+		filter := replication.Filter{
+			IncludeTables: viper.GetStringSlice("replication.include-tables"),
+			ExcludeTables: viper.GetStringSlice("replication.exclude-tables"),
+			KeyPrefixes:   make(map[string][][]byte),
+		}
+		for _, prefix := range viper.GetStringSlice("replication.key-prefixes") {
+			name, p, ok := strings.Cut(prefix, "=")
+			if !ok || name == "" || p == "" {
+				log.Panicf("invalid key prefix '%s', expected table=prefix", prefix)
+			}
+			filter.KeyPrefixes[name] = append(filter.KeyPrefixes[name], []byte(p))
+		}
+		if err := filter.Validate(); err != nil {
+			log.Panicf("invalid replication filter: %v", err)
+		}
+
 		d := replication.NewManager(engine.Manager, engine.NodeHost, conn, replication.Config{
 			ReconcileInterval: viper.GetDuration("replication.reconcile-interval"),
+			TableDeletion: func() replication.TableDeletion {
+				switch viper.GetString("replication.table-deletion") {
+				case "disabled":
+					return replication.TableDeletionDisabled
+				case "dry-run":
+					return replication.TableDeletionDryRun
+				case "enabled":
+					return replication.TableDeletionEnabled
+				default:
+					log.Panicf("unknown table deletion mode: %s", viper.GetString("replication.table-deletion"))
+				}
+				return replication.TableDeletionDisabled
+			}(),
+			TableDeletionGracePeriod: viper.GetDuration("replication.table-deletion-grace-period"),
+			Filter:                   filter,
 			Workers: replication.WorkerConfig{
 				PollInterval:        viper.GetDuration("replication.poll-interval"),
 				LeaseInterval:       viper.GetDuration("replication.lease-interval"),
@@ -185,11 +241,14 @@ func follower(_ *cobra.Command, _ []string) {
 				SnapshotRPCTimeout:  viper.GetDuration("replication.snapshot-rpc-timeout"),
 				MaxRecoveryInFlight: int64(viper.GetUint64("replication.max-recovery-in-flight")),
 				MaxSnapshotRecv:     viper.GetUint64("replication.max-snapshot-recv-bytes-per-second"),
+				Streaming:           viper.GetBool("replication.streaming"),
+				HeartbeatInterval:   viper.GetDuration("replication.heartbeat-interval"),
 			},
 		})
 		prometheus.MustRegister(d)
 		d.Start()
 		defer d.Close()
+		replicator = d
 	}
 
 	// Start servers
@@ -204,10 +263,37 @@ func follower(_ *cobra.Command, _ []string) {
 			}
 			// Create server
 			regatta := createAPIServer(c)
-			regattapb.RegisterKVServer(regatta, &regattaserver.ReadonlyKVServer{
+			kv := &regattaserver.ReadonlyKVServer{
 				KVServer: regattaserver.KVServer{
 					Storage: engine,
 				},
+				Tables: engine,
+			}
+			if viper.GetBool("forwarding.enabled") {
+				conn, err := createForwardingConn()
+				if err != nil {
+					log.Panicf("cannot create forwarding conn: %v", err)
+				}
+				defer func() {
+					_ = conn.Close()
+				}()
+				fkv := &regattaserver.ForwardingKVServer{
+					ReadonlyKVServer: *kv,
+					Leader:           regattapb.NewKVClient(conn),
+				}
+				if viper.GetBool("forwarding.wait-for-replication") {
+					fkv.Revisions = engine
+					fkv.WaitTimeout = viper.GetDuration("forwarding.wait-for-replication-timeout")
+				}
+				regattapb.RegisterKVServer(regatta, fkv)
+			} else {
+				regattapb.RegisterKVServer(regatta, kv)
+			}
+			regattapb.RegisterWatchServer(regatta, &regattaserver.WatchServer{Storage: engine})
+			regattapb.RegisterLeaseServer(regatta, &regattaserver.ReadonlyLeaseServer{
+				LeaseServer: regattaserver.LeaseServer{
+					Storage: engine,
+				},
 			})
 			// Start server
 			go func() {
@@ -219,6 +305,41 @@ func follower(_ *cobra.Command, _ []string) {
 			defer regatta.Shutdown()
 		}
 
+		if viper.GetBool("replication.server.enabled") {
+			// Load replication API certificate
+			c, err := cert.New(viper.GetString("replication.server.cert-filename"), viper.GetString("replication.server.key-filename"))
+			if err != nil {
+				log.Panicf("cannot load replication certificate: %v", err)
+			}
+			caBytes, err := os.ReadFile(viper.GetString("replication.server.ca-filename"))
+			if err != nil {
+				log.Panicf("cannot load clients CA: %v", err)
+			}
+
+			replication := createReplicationServer(viper.GetString("replication.server.address"), c, caBytes, logger.Named("server.replication"))
+			ls := regattaserver.NewLogServer(
+				engine.Manager,
+				engine.LogReader,
+				logger,
+				viper.GetUint64("replication.server.max-send-message-size-bytes"),
+			)
+			ls.Follower = true
+			ls.Replication = replicator
+			regattapb.RegisterMetadataServer(replication, &regattaserver.MetadataServer{Tables: engine})
+			ss := &regattaserver.SnapshotServer{Tables: engine, Follower: true, Retention: viper.GetDuration("replication.server.snapshot-retention")}
+			defer ss.Close()
+			regattapb.RegisterSnapshotServer(replication, ss)
+			regattapb.RegisterLogServer(replication, ls)
+			// Start server
+			go func() {
+				log.Infof("regatta replication listening at %s", replication.Addr)
+				if err := replication.ListenAndServe(); err != nil {
+					log.Panicf("grpc listenAndServe failed: %v", err)
+				}
+			}()
+			defer replication.Shutdown()
+		}
+
 		if viper.GetBool("maintenance.enabled") {
 			// Load maintenance API certificate
 			c, err := cert.New(viper.GetString("maintenance.cert-filename"), viper.GetString("maintenance.key-filename"))
@@ -227,7 +348,7 @@ func follower(_ *cobra.Command, _ []string) {
 			}
 
 			maintenance := createMaintenanceServer(c)
-			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.ResetServer{Tables: engine})
+			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.ResetServer{Tables: engine, Replication: replicator})
 			// Start server
 			go func() {
 				log.Infof("regatta maintenance listening at %s", maintenance.Addr)
@@ -276,3 +397,17 @@ func createReplicationConn(cp *x509.CertPool, cer *cert.Reloadable) (*grpc.Clien
 	}
 	return replConn, nil
 }
+
+func createForwardingConn() (*grpc.ClientConn, error) {
+	caBytes, err := os.ReadFile(viper.GetString("forwarding.ca-filename"))
+	if err != nil {
+		return nil, err
+	}
+	cp := x509.NewCertPool()
+	cp.AppendCertsFromPEM(caBytes)
+	creds := credentials.NewTLS(&tls.Config{
+		RootCAs:    cp,
+		MinVersion: tls.VersionTLS12,
+	})
+	return grpc.Dial(viper.GetString("forwarding.leader-address"), grpc.WithTransportCredentials(creds))
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/cockroachdb/pebble/vfs"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -43,6 +44,9 @@ func init() {
 	// Tables flags
 	leaderCmd.PersistentFlags().StringSlice("tables.names", nil, "Create Regatta tables with given names.")
 	leaderCmd.PersistentFlags().StringSlice("tables.delete", nil, "Delete Regatta tables with given names.")
+	leaderCmd.PersistentFlags().Duration("tables.lease-expiry-interval", time.Second, "How often the expired leases are revoked. Value 0 turns the lease expiry off.")
+	leaderCmd.PersistentFlags().Uint64("tables.history-retention", 100000, "Number of the most recent revisions the history of the keys is retained for, tables could override it. Value 0 retains the whole history.")
+	leaderCmd.PersistentFlags().Duration("tables.history-compaction-interval", time.Minute, "How often the history of the keys is compacted to the retention. Value 0 turns the automatic compaction off.")
 
 	// Replication flags
 	leaderCmd.PersistentFlags().Bool("replication.enabled", true, "Whether replication API is enabled.")
@@ -53,6 +57,7 @@ Under some circumstances, a larger message could be sent. Followers should be ab
 	leaderCmd.PersistentFlags().String("replication.key-filename", "hack/replication/server.key", "Path to the API server private key file.")
 	leaderCmd.PersistentFlags().String("replication.ca-filename", "hack/replication/ca.crt", "Path to the API server CA cert file.")
 	leaderCmd.PersistentFlags().Int("replication.log-cache-size", 0, "Size of the replication cache. Size 0 means cache is turned off.")
+	leaderCmd.PersistentFlags().Duration("replication.snapshot-retention", 5*time.Minute, "How long the snapshot of the interrupted snapshot stream is held for the follower to resume the download. Value 0 means the snapshot streams are not resumable.")
 }
 
 var leaderCmd = &cobra.Command{
@@ -106,22 +111,26 @@ func leader(_ *cobra.Command, _ []string) {
 		MaxReceiveQueueSize: viper.GetUint64("raft.max-recv-queue-size"),
 		MaxSendQueueSize:    viper.GetUint64("raft.max-send-queue-size"),
 		LogCacheSize:        viper.GetInt("replication.log-cache-size"),
+		WatchHistorySize:    viper.GetInt("storage.watch-history-size"),
 		Gossip: storage.GossipConfig{
 			BindAddress:      viper.GetString("memberlist.address"),
 			AdvertiseAddress: viper.GetString("memberlist.advertise-address"),
 			InitialMembers:   viper.GetStringSlice("memberlist.members"),
 		},
 		Table: storage.TableConfig{
-			FS:                 vfs.Default,
-			ElectionRTT:        viper.GetUint64("raft.election-rtt"),
-			HeartbeatRTT:       viper.GetUint64("raft.heartbeat-rtt"),
-			SnapshotEntries:    viper.GetUint64("raft.snapshot-entries"),
-			CompactionOverhead: viper.GetUint64("raft.compaction-overhead"),
-			MaxInMemLogSize:    viper.GetUint64("raft.max-in-mem-log-size"),
-			DataDir:            viper.GetString("raft.state-machine-dir"),
-			RecoveryType:       toRecoveryType(viper.GetString("raft.snapshot-recovery-type")),
-			BlockCacheSize:     viper.GetInt64("storage.block-cache-size"),
-			TableCacheSize:     viper.GetInt("storage.table-cache-size"),
+			FS:                        vfs.Default,
+			ElectionRTT:               viper.GetUint64("raft.election-rtt"),
+			HeartbeatRTT:              viper.GetUint64("raft.heartbeat-rtt"),
+			SnapshotEntries:           viper.GetUint64("raft.snapshot-entries"),
+			CompactionOverhead:        viper.GetUint64("raft.compaction-overhead"),
+			MaxInMemLogSize:           viper.GetUint64("raft.max-in-mem-log-size"),
+			DataDir:                   viper.GetString("raft.state-machine-dir"),
+			RecoveryType:              toRecoveryType(viper.GetString("raft.snapshot-recovery-type")),
+			BlockCacheSize:            viper.GetInt64("storage.block-cache-size"),
+			TableCacheSize:            viper.GetInt("storage.table-cache-size"),
+			LeaseExpiryInterval:       viper.GetDuration("tables.lease-expiry-interval"),
+			HistoryRetention:          viper.GetUint64("tables.history-retention"),
+			HistoryCompactionInterval: viper.GetDuration("tables.history-compaction-interval"),
 		},
 		Meta: storage.MetaConfig{
 			ElectionRTT:        viper.GetUint64("raft.election-rtt"),
@@ -193,6 +202,8 @@ func leader(_ *cobra.Command, _ []string) {
 			regattapb.RegisterKVServer(regatta, &regattaserver.KVServer{
 				Storage: engine,
 			})
+			regattapb.RegisterWatchServer(regatta, &regattaserver.WatchServer{Storage: engine})
+			regattapb.RegisterLeaseServer(regatta, &regattaserver.LeaseServer{Storage: engine})
 			// Start server
 			go func() {
 				log.Infof("regatta listening at %s", regatta.Addr)
@@ -214,7 +225,7 @@ func leader(_ *cobra.Command, _ []string) {
 				log.Panicf("cannot load clients CA: %v", err)
 			}
 
-			replication := createReplicationServer(c, caBytes, logger.Named("server.replication"))
+			replication := createReplicationServer(viper.GetString("replication.address"), c, caBytes, logger.Named("server.replication"))
 			ls := regattaserver.NewLogServer(
 				engine.Manager,
 				engine.LogReader,
@@ -222,7 +233,9 @@ func leader(_ *cobra.Command, _ []string) {
 				viper.GetUint64("replication.max-send-message-size-bytes"),
 			)
 			regattapb.RegisterMetadataServer(replication, &regattaserver.MetadataServer{Tables: engine})
-			regattapb.RegisterSnapshotServer(replication, &regattaserver.SnapshotServer{Tables: engine})
+			ss := &regattaserver.SnapshotServer{Tables: engine, Retention: viper.GetDuration("replication.snapshot-retention")}
+			defer ss.Close()
+			regattapb.RegisterSnapshotServer(replication, ss)
 			regattapb.RegisterLogServer(replication, ls)
 			// Start server
 			go func() {
@@ -243,7 +256,7 @@ func leader(_ *cobra.Command, _ []string) {
 
 			maintenance := createMaintenanceServer(c)
 			regattapb.RegisterMetadataServer(maintenance, &regattaserver.MetadataServer{Tables: engine})
-			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.BackupServer{Tables: engine})
+			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.BackupServer{Tables: engine, LogReader: engine.LogReader})
 			// Start server
 			go func() {
 				log.Infof("regatta maintenance listening at %s", maintenance.Addr)
@@ -269,13 +282,13 @@ func leader(_ *cobra.Command, _ []string) {
 	log.Info("shutting down...")
 }
 
-func createReplicationServer(cer *cert.Reloadable, ca []byte, log *zap.Logger) *regattaserver.RegattaServer {
+func createReplicationServer(addr string, cer *cert.Reloadable, ca []byte, log *zap.Logger) *regattaserver.RegattaServer {
 	cp := x509.NewCertPool()
 	cp.AppendCertsFromPEM(ca)
 
 	// Create regatta replication server
 	return regattaserver.NewServer(
-		viper.GetString("replication.address"),
+		addr,
 		viper.GetBool("api.reflection-api"),
 		grpc.Creds(credentials.NewTLS(&tls.Config{
 			ClientAuth:     tls.RequireAndVerifyClientCert,
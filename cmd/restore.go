@@ -5,7 +5,9 @@ package cmd
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"os"
+	"time"
 
 	rl "github.com/jamf/regatta/log"
 	"github.com/jamf/regatta/replication/backup"
@@ -18,21 +20,41 @@ import (
 
 func init() {
 	restoreCmd.PersistentFlags().String("address", "127.0.0.1:8445", "Maintenance API address.")
-	restoreCmd.PersistentFlags().String("dir", "", "Directory containing the backups (current directory if empty)")
+	restoreCmd.PersistentFlags().String("dir", "", "Directory or S3 location in the s3://bucket/prefix form containing the backups (current directory if empty)")
+	restoreCmd.PersistentFlags().StringSlice("incremental", nil, "Locations of the incremental backups restored on top of the backup in dir, in the order they were taken.")
+	restoreCmd.PersistentFlags().String("table", "", "Name of the only table to restore (all the tables in the backup if empty).")
+	restoreCmd.PersistentFlags().String("target-table", "", "Name the table is restored as (the name of the table in the backup if empty).")
+	restoreCmd.PersistentFlags().Int64("revision", 0, "Revision the tables are restored to (the latest backed up state if 0).")
+	restoreCmd.PersistentFlags().String("timestamp", "", "Time in RFC 3339 format the tables are restored to (the latest backed up state if empty).")
 	restoreCmd.PersistentFlags().String("ca", "", "Path to the client CA cert file.")
 	restoreCmd.PersistentFlags().String("token", "", "The access token to use for the authentication.")
 	restoreCmd.PersistentFlags().Bool("json", false, "Enables JSON logging.")
+	restoreCmd.PersistentFlags().String("key-file", "", "Path to the file with the 256-bit key (raw, hex or base64 encoded) the backup files are decrypted with.")
+	restoreCmd.PersistentFlags().String("passphrase-file", "", "Path to the file with the passphrase the key the backup files are decrypted with is derived from.")
+	restoreCmd.PersistentFlags().AddFlagSet(s3FlagSet)
 }
 
 var restoreCmd = &cobra.Command{
 	Use:   "restore",
-	Short: "Restore Regatta from local files.",
+	Short: "Restore Regatta from local files or S3-compatible object storage.",
 	Long: `WARNING: Restoring from backup is a destructive operation and should be used only as part of break glass procedure.
 
-Restore Regatta cluster from a directory of choice. All tables present in the manifest.json will be restored.
+Restore Regatta cluster from a directory or an S3 bucket of choice. All tables present in the manifest.json will be restored.
 Restoring is done sequentially, for the fine-grained control of what to restore use backup manifest file.
+Incremental backups are restored on top of the full backup in the order they were taken, the chain of the backups must not have any gaps.
+The tables could be restored to a past revision or time covered by the incremental backups and under a different name to inspect the data before swapping.
+The format of the backup files is detected, the key file or the passphrase the backup was encrypted with must be provided for encrypted backups.
 It is almost certain that after restore the cold-start of all the followers watching the restored leader cluster is going to be necessary.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var ts time.Time
+		if t := viper.GetString("timestamp"); t != "" {
+			var err error
+			ts, err = time.Parse(time.RFC3339, t)
+			if err != nil {
+				return fmt.Errorf("invalid timestamp: %w", err)
+			}
+		}
+
 		var cp *x509.CertPool
 		ca := viper.GetString("ca")
 		if ca != "" {
@@ -53,9 +75,21 @@ It is almost certain that after restore the cold-start of all the followers watc
 			return err
 		}
 
+		key, err := loadBackupKey()
+		if err != nil {
+			return err
+		}
+
 		b := backup.Backup{
-			Conn: conn,
-			Dir:  viper.GetString("dir"),
+			Conn:         conn,
+			Dir:          viper.GetString("dir"),
+			Incrementals: viper.GetStringSlice("incremental"),
+			Table:        viper.GetString("table"),
+			TargetTable:  viper.GetString("target-table"),
+			Revision:     viper.GetInt64("revision"),
+			Timestamp:    ts,
+			Open:         openBackupStorage,
+			Key:          key,
 		}
 		if viper.GetBool("json") {
 			l := rl.NewLogger(false, zap.InfoLevel.String())
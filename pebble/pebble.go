@@ -141,6 +141,34 @@ func WithEventListener(listener pebble.EventListener) Option {
 	}}
 }
 
+// WithCompression sets the block compression of all the levels.
+func WithCompression(compression pebble.Compression) Option {
+	return &funcOption{func(options *pebble.Options) {
+		for i := range options.Levels {
+			options.Levels[i].Compression = compression
+		}
+	}}
+}
+
+// WithBloomFilter sets the bits per key of the bloom filters of all but the last level, bloom filters are not created if 0.
+func WithBloomFilter(bitsPerKey int) Option {
+	return &funcOption{func(options *pebble.Options) {
+		for i := range options.Levels {
+			options.Levels[i].FilterPolicy = nil
+			if bitsPerKey > 0 && i < len(options.Levels)-1 {
+				options.Levels[i].FilterPolicy = bloom.FilterPolicy(bitsPerKey)
+			}
+		}
+	}}
+}
+
+// WithMemTableSize sets the size of a single memtable.
+func WithMemTableSize(size int) Option {
+	return &funcOption{func(options *pebble.Options) {
+		options.MemTableSize = size
+	}}
+}
+
 // OpenDB opens DB on paths given (using sane defaults).
 func OpenDB(dbdir string, options ...Option) (*pebble.DB, error) {
 	opts := DefaultOptions()
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -43,6 +44,21 @@ func Test_split(t *testing.T) {
 	}
 }
 
+func TestWithBloomFilter(t *testing.T) {
+	r := require.New(t)
+	opts := DefaultOptions()
+	WithBloomFilter(5).apply(opts)
+	for _, lvl := range opts.Levels[:len(opts.Levels)-1] {
+		r.Equal(bloom.FilterPolicy(5), lvl.FilterPolicy)
+	}
+	r.Nil(opts.Levels[len(opts.Levels)-1].FilterPolicy)
+
+	WithBloomFilter(0).apply(opts)
+	for _, lvl := range opts.Levels {
+		r.Nil(lvl.FilterPolicy)
+	}
+}
+
 func TestOpenDB(t *testing.T) {
 	type args struct {
 		dbdir   string
@@ -105,6 +121,14 @@ func TestOpenDB(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "table tuning",
+			args: args{
+				dbdir:   "/tmp",
+				options: []Option{WithFS(vfs.NewMem()), WithCompression(pebble.ZstdCompression), WithBloomFilter(0), WithMemTableSize(1024 * 1024)},
+			},
+			wantErr: require.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -21,9 +21,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Maintenance_Backup_FullMethodName  = "/maintenance.v1.Maintenance/Backup"
-	Maintenance_Restore_FullMethodName = "/maintenance.v1.Maintenance/Restore"
-	Maintenance_Reset_FullMethodName   = "/maintenance.v1.Maintenance/Reset"
+	Maintenance_Backup_FullMethodName      = "/maintenance.v1.Maintenance/Backup"
+	Maintenance_Restore_FullMethodName     = "/maintenance.v1.Maintenance/Restore"
+	Maintenance_Reset_FullMethodName       = "/maintenance.v1.Maintenance/Reset"
+	Maintenance_Compact_FullMethodName     = "/maintenance.v1.Maintenance/Compact"
+	Maintenance_CreateTable_FullMethodName = "/maintenance.v1.Maintenance/CreateTable"
+	Maintenance_DeleteTable_FullMethodName = "/maintenance.v1.Maintenance/DeleteTable"
+	Maintenance_ListTables_FullMethodName  = "/maintenance.v1.Maintenance/ListTables"
 )
 
 // MaintenanceClient is the client API for Maintenance service.
@@ -33,6 +37,10 @@ type MaintenanceClient interface {
 	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (Maintenance_BackupClient, error)
 	Restore(ctx context.Context, opts ...grpc.CallOption) (Maintenance_RestoreClient, error)
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
+	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
+	CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error)
+	DeleteTable(ctx context.Context, in *DeleteTableRequest, opts ...grpc.CallOption) (*DeleteTableResponse, error)
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
 }
 
 type maintenanceClient struct {
@@ -118,6 +126,42 @@ func (c *maintenanceClient) Reset(ctx context.Context, in *ResetRequest, opts ..
 	return out, nil
 }
 
+func (c *maintenanceClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
+	out := new(CompactResponse)
+	err := c.cc.Invoke(ctx, Maintenance_Compact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenanceClient) CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error) {
+	out := new(CreateTableResponse)
+	err := c.cc.Invoke(ctx, Maintenance_CreateTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenanceClient) DeleteTable(ctx context.Context, in *DeleteTableRequest, opts ...grpc.CallOption) (*DeleteTableResponse, error) {
+	out := new(DeleteTableResponse)
+	err := c.cc.Invoke(ctx, Maintenance_DeleteTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenanceClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, Maintenance_ListTables_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MaintenanceServer is the server API for Maintenance service.
 // All implementations must embed UnimplementedMaintenanceServer
 // for forward compatibility
@@ -125,6 +169,10 @@ type MaintenanceServer interface {
 	Backup(*BackupRequest, Maintenance_BackupServer) error
 	Restore(Maintenance_RestoreServer) error
 	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
+	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
+	CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error)
+	DeleteTable(context.Context, *DeleteTableRequest) (*DeleteTableResponse, error)
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
 	mustEmbedUnimplementedMaintenanceServer()
 }
 
@@ -141,6 +189,18 @@ func (UnimplementedMaintenanceServer) Restore(Maintenance_RestoreServer) error {
 func (UnimplementedMaintenanceServer) Reset(context.Context, *ResetRequest) (*ResetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
 }
+func (UnimplementedMaintenanceServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (UnimplementedMaintenanceServer) CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTable not implemented")
+}
+func (UnimplementedMaintenanceServer) DeleteTable(context.Context, *DeleteTableRequest) (*DeleteTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTable not implemented")
+}
+func (UnimplementedMaintenanceServer) ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
 func (UnimplementedMaintenanceServer) mustEmbedUnimplementedMaintenanceServer() {}
 
 // UnsafeMaintenanceServer may be embedded to opt out of forward compatibility for this service.
@@ -219,6 +279,78 @@ func _Maintenance_Reset_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Maintenance_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Maintenance_Compact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServer).Compact(ctx, req.(*CompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Maintenance_CreateTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServer).CreateTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Maintenance_CreateTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServer).CreateTable(ctx, req.(*CreateTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Maintenance_DeleteTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServer).DeleteTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Maintenance_DeleteTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServer).DeleteTable(ctx, req.(*DeleteTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Maintenance_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Maintenance_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Maintenance_ServiceDesc is the grpc.ServiceDesc for Maintenance service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -230,6 +362,22 @@ var Maintenance_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Reset",
 			Handler:    _Maintenance_Reset_Handler,
 		},
+		{
+			MethodName: "Compact",
+			Handler:    _Maintenance_Compact_Handler,
+		},
+		{
+			MethodName: "CreateTable",
+			Handler:    _Maintenance_CreateTable_Handler,
+		},
+		{
+			MethodName: "DeleteTable",
+			Handler:    _Maintenance_DeleteTable_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _Maintenance_ListTables_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -29,34 +29,52 @@ const (
 type Command_CommandType int32
 
 const (
-	Command_PUT          Command_CommandType = 0
-	Command_DELETE       Command_CommandType = 1
-	Command_DUMMY        Command_CommandType = 2
-	Command_PUT_BATCH    Command_CommandType = 3
-	Command_DELETE_BATCH Command_CommandType = 4
-	Command_TXN          Command_CommandType = 5
-	Command_SEQUENCE     Command_CommandType = 6
+	Command_PUT             Command_CommandType = 0
+	Command_DELETE          Command_CommandType = 1
+	Command_DUMMY           Command_CommandType = 2
+	Command_PUT_BATCH       Command_CommandType = 3
+	Command_DELETE_BATCH    Command_CommandType = 4
+	Command_TXN             Command_CommandType = 5
+	Command_SEQUENCE        Command_CommandType = 6
+	Command_LEASE_GRANT     Command_CommandType = 7
+	Command_LEASE_REVOKE    Command_CommandType = 8
+	Command_LEASE_KEEPALIVE Command_CommandType = 9
+	Command_COMPACT         Command_CommandType = 10
+	Command_INCREMENT       Command_CommandType = 11
+	Command_INGEST          Command_CommandType = 12
 )
 
 // Enum value maps for Command_CommandType.
 var (
 	Command_CommandType_name = map[int32]string{
-		0: "PUT",
-		1: "DELETE",
-		2: "DUMMY",
-		3: "PUT_BATCH",
-		4: "DELETE_BATCH",
-		5: "TXN",
-		6: "SEQUENCE",
+		0:  "PUT",
+		1:  "DELETE",
+		2:  "DUMMY",
+		3:  "PUT_BATCH",
+		4:  "DELETE_BATCH",
+		5:  "TXN",
+		6:  "SEQUENCE",
+		7:  "LEASE_GRANT",
+		8:  "LEASE_REVOKE",
+		9:  "LEASE_KEEPALIVE",
+		10: "COMPACT",
+		11: "INCREMENT",
+		12: "INGEST",
 	}
 	Command_CommandType_value = map[string]int32{
-		"PUT":          0,
-		"DELETE":       1,
-		"DUMMY":        2,
-		"PUT_BATCH":    3,
-		"DELETE_BATCH": 4,
-		"TXN":          5,
-		"SEQUENCE":     6,
+		"PUT":             0,
+		"DELETE":          1,
+		"DUMMY":           2,
+		"PUT_BATCH":       3,
+		"DELETE_BATCH":    4,
+		"TXN":             5,
+		"SEQUENCE":        6,
+		"LEASE_GRANT":     7,
+		"LEASE_REVOKE":    8,
+		"LEASE_KEEPALIVE": 9,
+		"COMPACT":         10,
+		"INCREMENT":       11,
+		"INGEST":          12,
 	}
 )
 
@@ -87,6 +105,111 @@ func (Command_CommandType) EnumDescriptor() ([]byte, []int) {
 	return file_mvcc_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type RequestOp_Range_SortOrder int32
+
+const (
+	// NONE is the default order, ascending by key unless sort_target is set.
+	RequestOp_Range_NONE    RequestOp_Range_SortOrder = 0
+	RequestOp_Range_ASCEND  RequestOp_Range_SortOrder = 1
+	RequestOp_Range_DESCEND RequestOp_Range_SortOrder = 2
+)
+
+// Enum value maps for RequestOp_Range_SortOrder.
+var (
+	RequestOp_Range_SortOrder_name = map[int32]string{
+		0: "NONE",
+		1: "ASCEND",
+		2: "DESCEND",
+	}
+	RequestOp_Range_SortOrder_value = map[string]int32{
+		"NONE":    0,
+		"ASCEND":  1,
+		"DESCEND": 2,
+	}
+)
+
+func (x RequestOp_Range_SortOrder) Enum() *RequestOp_Range_SortOrder {
+	p := new(RequestOp_Range_SortOrder)
+	*p = x
+	return p
+}
+
+func (x RequestOp_Range_SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RequestOp_Range_SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_mvcc_proto_enumTypes[1].Descriptor()
+}
+
+func (RequestOp_Range_SortOrder) Type() protoreflect.EnumType {
+	return &file_mvcc_proto_enumTypes[1]
+}
+
+func (x RequestOp_Range_SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RequestOp_Range_SortOrder.Descriptor instead.
+func (RequestOp_Range_SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{4, 0, 0}
+}
+
+type RequestOp_Range_SortTarget int32
+
+const (
+	RequestOp_Range_KEY     RequestOp_Range_SortTarget = 0
+	RequestOp_Range_VERSION RequestOp_Range_SortTarget = 1
+	RequestOp_Range_CREATE  RequestOp_Range_SortTarget = 2
+	RequestOp_Range_MOD     RequestOp_Range_SortTarget = 3
+	RequestOp_Range_VALUE   RequestOp_Range_SortTarget = 4
+)
+
+// Enum value maps for RequestOp_Range_SortTarget.
+var (
+	RequestOp_Range_SortTarget_name = map[int32]string{
+		0: "KEY",
+		1: "VERSION",
+		2: "CREATE",
+		3: "MOD",
+		4: "VALUE",
+	}
+	RequestOp_Range_SortTarget_value = map[string]int32{
+		"KEY":     0,
+		"VERSION": 1,
+		"CREATE":  2,
+		"MOD":     3,
+		"VALUE":   4,
+	}
+)
+
+func (x RequestOp_Range_SortTarget) Enum() *RequestOp_Range_SortTarget {
+	p := new(RequestOp_Range_SortTarget)
+	*p = x
+	return p
+}
+
+func (x RequestOp_Range_SortTarget) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RequestOp_Range_SortTarget) Descriptor() protoreflect.EnumDescriptor {
+	return file_mvcc_proto_enumTypes[2].Descriptor()
+}
+
+func (RequestOp_Range_SortTarget) Type() protoreflect.EnumType {
+	return &file_mvcc_proto_enumTypes[2]
+}
+
+func (x RequestOp_Range_SortTarget) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RequestOp_Range_SortTarget.Descriptor instead.
+func (RequestOp_Range_SortTarget) EnumDescriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{4, 0, 1}
+}
+
 type Compare_CompareResult int32
 
 const (
@@ -123,11 +246,11 @@ func (x Compare_CompareResult) String() string {
 }
 
 func (Compare_CompareResult) Descriptor() protoreflect.EnumDescriptor {
-	return file_mvcc_proto_enumTypes[1].Descriptor()
+	return file_mvcc_proto_enumTypes[3].Descriptor()
 }
 
 func (Compare_CompareResult) Type() protoreflect.EnumType {
-	return &file_mvcc_proto_enumTypes[1]
+	return &file_mvcc_proto_enumTypes[3]
 }
 
 func (x Compare_CompareResult) Number() protoreflect.EnumNumber {
@@ -136,22 +259,34 @@ func (x Compare_CompareResult) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Compare_CompareResult.Descriptor instead.
 func (Compare_CompareResult) EnumDescriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{5, 0}
+	return file_mvcc_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type Compare_CompareTarget int32
 
 const (
-	Compare_VALUE Compare_CompareTarget = 0
+	Compare_VALUE   Compare_CompareTarget = 0
+	Compare_VERSION Compare_CompareTarget = 1
+	Compare_CREATE  Compare_CompareTarget = 2
+	Compare_MOD     Compare_CompareTarget = 3
+	Compare_LEASE   Compare_CompareTarget = 4
 )
 
 // Enum value maps for Compare_CompareTarget.
 var (
 	Compare_CompareTarget_name = map[int32]string{
 		0: "VALUE",
+		1: "VERSION",
+		2: "CREATE",
+		3: "MOD",
+		4: "LEASE",
 	}
 	Compare_CompareTarget_value = map[string]int32{
-		"VALUE": 0,
+		"VALUE":   0,
+		"VERSION": 1,
+		"CREATE":  2,
+		"MOD":     3,
+		"LEASE":   4,
 	}
 )
 
@@ -166,11 +301,11 @@ func (x Compare_CompareTarget) String() string {
 }
 
 func (Compare_CompareTarget) Descriptor() protoreflect.EnumDescriptor {
-	return file_mvcc_proto_enumTypes[2].Descriptor()
+	return file_mvcc_proto_enumTypes[4].Descriptor()
 }
 
 func (Compare_CompareTarget) Type() protoreflect.EnumType {
-	return &file_mvcc_proto_enumTypes[2]
+	return &file_mvcc_proto_enumTypes[4]
 }
 
 func (x Compare_CompareTarget) Number() protoreflect.EnumNumber {
@@ -179,7 +314,53 @@ func (x Compare_CompareTarget) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Compare_CompareTarget.Descriptor instead.
 func (Compare_CompareTarget) EnumDescriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{5, 1}
+	return file_mvcc_proto_rawDescGZIP(), []int{6, 1}
+}
+
+type Event_EventType int32
+
+const (
+	Event_PUT    Event_EventType = 0
+	Event_DELETE Event_EventType = 1
+)
+
+// Enum value maps for Event_EventType.
+var (
+	Event_EventType_name = map[int32]string{
+		0: "PUT",
+		1: "DELETE",
+	}
+	Event_EventType_value = map[string]int32{
+		"PUT":    0,
+		"DELETE": 1,
+	}
+)
+
+func (x Event_EventType) Enum() *Event_EventType {
+	p := new(Event_EventType)
+	*p = x
+	return p
+}
+
+func (x Event_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mvcc_proto_enumTypes[5].Descriptor()
+}
+
+func (Event_EventType) Type() protoreflect.EnumType {
+	return &file_mvcc_proto_enumTypes[5]
+}
+
+func (x Event_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event_EventType.Descriptor instead.
+func (Event_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{8, 0}
 }
 
 type Command struct {
@@ -196,7 +377,7 @@ type Command struct {
 	// kv holds the KeyValue for the event.
 	// A PUT event contains current kv pair.
 	// A PUT event with kv.Version=1 indicates the creation of a key.
-	// A DELETE/EXPIRE event contains the deleted key with
+	// A DELETE event contains the deleted key with
 	// its modification revision set to the revision of deletion.
 	Kv *KeyValue `protobuf:"bytes,3,opt,name=kv,proto3" json:"kv,omitempty"`
 	// leader_index holds the value of the log index of a leader cluster from which this command was replicated from.
@@ -217,6 +398,18 @@ type Command struct {
 	Sequence []*Command `protobuf:"bytes,10,rep,name=sequence,proto3" json:"sequence,omitempty"`
 	// count if to count number of records affected by a command.
 	Count bool `protobuf:"varint,11,opt,name=count,proto3" json:"count,omitempty"`
+	// lease is the lease to grant, revoke or keep alive.
+	Lease *Lease `protobuf:"bytes,12,opt,name=lease,proto3,oneof" json:"lease,omitempty"`
+	// timestamp is the wall clock time of the proposer in unix milliseconds, the lease expiry is computed from it.
+	Timestamp int64 `protobuf:"varint,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// revision is the revision to compact the history of the keys to (COMPACT), the highest revision of the ingested keys (INGEST),
+	// the revision at the leader index of the restored snapshot (DUMMY) or the revision the restored command was applied with
+	// in the backed up table (other commands nested in a SEQUENCE).
+	Revision int64 `protobuf:"varint,14,opt,name=revision,proto3" json:"revision,omitempty"`
+	// increment is the increment of the counter to apply.
+	Increment *RequestOp_Increment `protobuf:"bytes,15,opt,name=increment,proto3,oneof" json:"increment,omitempty"`
+	// ingest are the SST files ingested into the table storage, the files must not overlap.
+	Ingest [][]byte `protobuf:"bytes,16,rep,name=ingest,proto3" json:"ingest,omitempty"`
 }
 
 func (x *Command) Reset() {
@@ -321,6 +514,41 @@ func (x *Command) GetCount() bool {
 	return false
 }
 
+func (x *Command) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+func (x *Command) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Command) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Command) GetIncrement() *RequestOp_Increment {
+	if x != nil {
+		return x.Increment
+	}
+	return nil
+}
+
+func (x *Command) GetIngest() [][]byte {
+	if x != nil {
+		return x.Ingest
+	}
+	return nil
+}
+
 type CommandResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -330,6 +558,8 @@ type CommandResult struct {
 	Responses []*ResponseOp `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
 	// revision is the key-value store revision when the request was applied.
 	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// lease is the state of the lease affected by the command.
+	Lease *Lease `protobuf:"bytes,3,opt,name=lease,proto3,oneof" json:"lease,omitempty"`
 }
 
 func (x *CommandResult) Reset() {
@@ -378,6 +608,80 @@ func (x *CommandResult) GetRevision() uint64 {
 	return 0
 }
 
+func (x *CommandResult) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+type Lease struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id is the ID of the lease.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ttl is the time-to-live of the lease in seconds.
+	Ttl int64 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// expiry is the time of the lease expiry in unix milliseconds.
+	// A revoke with the expiry set revokes the lease only if it was not kept alive in the meantime.
+	Expiry int64 `protobuf:"varint,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
+}
+
+func (x *Lease) Reset() {
+	*x = Lease{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lease) ProtoMessage() {}
+
+func (x *Lease) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lease.ProtoReflect.Descriptor instead.
+func (*Lease) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Lease) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Lease) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *Lease) GetExpiry() int64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
 type Txn struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -398,7 +702,7 @@ type Txn struct {
 func (x *Txn) Reset() {
 	*x = Txn{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[2]
+		mi := &file_mvcc_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -411,7 +715,7 @@ func (x *Txn) String() string {
 func (*Txn) ProtoMessage() {}
 
 func (x *Txn) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[2]
+	mi := &file_mvcc_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +728,7 @@ func (x *Txn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Txn.ProtoReflect.Descriptor instead.
 func (*Txn) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{2}
+	return file_mvcc_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Txn) GetCompare() []*Compare {
@@ -460,13 +764,15 @@ type RequestOp struct {
 	//	*RequestOp_RequestRange
 	//	*RequestOp_RequestPut
 	//	*RequestOp_RequestDeleteRange
+	//	*RequestOp_RequestTxn
+	//	*RequestOp_RequestIncrement
 	Request isRequestOp_Request `protobuf_oneof:"request"`
 }
 
 func (x *RequestOp) Reset() {
 	*x = RequestOp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[3]
+		mi := &file_mvcc_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -479,7 +785,7 @@ func (x *RequestOp) String() string {
 func (*RequestOp) ProtoMessage() {}
 
 func (x *RequestOp) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[3]
+	mi := &file_mvcc_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +798,7 @@ func (x *RequestOp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp.ProtoReflect.Descriptor instead.
 func (*RequestOp) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3}
+	return file_mvcc_proto_rawDescGZIP(), []int{4}
 }
 
 func (m *RequestOp) GetRequest() isRequestOp_Request {
@@ -523,6 +829,20 @@ func (x *RequestOp) GetRequestDeleteRange() *RequestOp_DeleteRange {
 	return nil
 }
 
+func (x *RequestOp) GetRequestTxn() *Txn {
+	if x, ok := x.GetRequest().(*RequestOp_RequestTxn); ok {
+		return x.RequestTxn
+	}
+	return nil
+}
+
+func (x *RequestOp) GetRequestIncrement() *RequestOp_Increment {
+	if x, ok := x.GetRequest().(*RequestOp_RequestIncrement); ok {
+		return x.RequestIncrement
+	}
+	return nil
+}
+
 type isRequestOp_Request interface {
 	isRequestOp_Request()
 }
@@ -539,12 +859,26 @@ type RequestOp_RequestDeleteRange struct {
 	RequestDeleteRange *RequestOp_DeleteRange `protobuf:"bytes,3,opt,name=request_delete_range,json=requestDeleteRange,proto3,oneof"`
 }
 
+type RequestOp_RequestTxn struct {
+	// request_txn is a nested transaction evaluated atomically as a part of the enclosing transaction.
+	RequestTxn *Txn `protobuf:"bytes,4,opt,name=request_txn,json=requestTxn,proto3,oneof"`
+}
+
+type RequestOp_RequestIncrement struct {
+	// request_increment atomically adds the delta to the counter.
+	RequestIncrement *RequestOp_Increment `protobuf:"bytes,5,opt,name=request_increment,json=requestIncrement,proto3,oneof"`
+}
+
 func (*RequestOp_RequestRange) isRequestOp_Request() {}
 
 func (*RequestOp_RequestPut) isRequestOp_Request() {}
 
 func (*RequestOp_RequestDeleteRange) isRequestOp_Request() {}
 
+func (*RequestOp_RequestTxn) isRequestOp_Request() {}
+
+func (*RequestOp_RequestIncrement) isRequestOp_Request() {}
+
 type ResponseOp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -557,13 +891,15 @@ type ResponseOp struct {
 	//	*ResponseOp_ResponseRange
 	//	*ResponseOp_ResponsePut
 	//	*ResponseOp_ResponseDeleteRange
+	//	*ResponseOp_ResponseTxn
+	//	*ResponseOp_ResponseIncrement
 	Response isResponseOp_Response `protobuf_oneof:"response"`
 }
 
 func (x *ResponseOp) Reset() {
 	*x = ResponseOp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[4]
+		mi := &file_mvcc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -576,7 +912,7 @@ func (x *ResponseOp) String() string {
 func (*ResponseOp) ProtoMessage() {}
 
 func (x *ResponseOp) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[4]
+	mi := &file_mvcc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -589,7 +925,7 @@ func (x *ResponseOp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp.ProtoReflect.Descriptor instead.
 func (*ResponseOp) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4}
+	return file_mvcc_proto_rawDescGZIP(), []int{5}
 }
 
 func (m *ResponseOp) GetResponse() isResponseOp_Response {
@@ -620,6 +956,20 @@ func (x *ResponseOp) GetResponseDeleteRange() *ResponseOp_DeleteRange {
 	return nil
 }
 
+func (x *ResponseOp) GetResponseTxn() *ResponseOp_Txn {
+	if x, ok := x.GetResponse().(*ResponseOp_ResponseTxn); ok {
+		return x.ResponseTxn
+	}
+	return nil
+}
+
+func (x *ResponseOp) GetResponseIncrement() *ResponseOp_Increment {
+	if x, ok := x.GetResponse().(*ResponseOp_ResponseIncrement); ok {
+		return x.ResponseIncrement
+	}
+	return nil
+}
+
 type isResponseOp_Response interface {
 	isResponseOp_Response()
 }
@@ -636,12 +986,24 @@ type ResponseOp_ResponseDeleteRange struct {
 	ResponseDeleteRange *ResponseOp_DeleteRange `protobuf:"bytes,3,opt,name=response_delete_range,json=responseDeleteRange,proto3,oneof"`
 }
 
+type ResponseOp_ResponseTxn struct {
+	ResponseTxn *ResponseOp_Txn `protobuf:"bytes,4,opt,name=response_txn,json=responseTxn,proto3,oneof"`
+}
+
+type ResponseOp_ResponseIncrement struct {
+	ResponseIncrement *ResponseOp_Increment `protobuf:"bytes,5,opt,name=response_increment,json=responseIncrement,proto3,oneof"`
+}
+
 func (*ResponseOp_ResponseRange) isResponseOp_Response() {}
 
 func (*ResponseOp_ResponsePut) isResponseOp_Response() {}
 
 func (*ResponseOp_ResponseDeleteRange) isResponseOp_Response() {}
 
+func (*ResponseOp_ResponseTxn) isResponseOp_Response() {}
+
+func (*ResponseOp_ResponseIncrement) isResponseOp_Response() {}
+
 // Compare property `target` for every KV from DB in [key, range_end) with target_union using the operation `result`. e.g. `DB[key].target result target_union.target`,
 // that means that for asymmetric operations LESS and GREATER the target property of the key from the DB is the left-hand side of the comparison.
 // Examples:
@@ -663,6 +1025,10 @@ type Compare struct {
 	// Types that are assignable to TargetUnion:
 	//
 	//	*Compare_Value
+	//	*Compare_CreateRevision
+	//	*Compare_ModRevision
+	//	*Compare_Version
+	//	*Compare_Lease
 	TargetUnion isCompare_TargetUnion `protobuf_oneof:"target_union"`
 	// range_end compares the given target to all keys in the range [key, range_end).
 	// See RangeRequest for more details on key ranges.
@@ -672,7 +1038,7 @@ type Compare struct {
 func (x *Compare) Reset() {
 	*x = Compare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[5]
+		mi := &file_mvcc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -685,7 +1051,7 @@ func (x *Compare) String() string {
 func (*Compare) ProtoMessage() {}
 
 func (x *Compare) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[5]
+	mi := &file_mvcc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +1064,7 @@ func (x *Compare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Compare.ProtoReflect.Descriptor instead.
 func (*Compare) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{5}
+	return file_mvcc_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Compare) GetResult() Compare_CompareResult {
@@ -736,6 +1102,34 @@ func (x *Compare) GetValue() []byte {
 	return nil
 }
 
+func (x *Compare) GetCreateRevision() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_CreateRevision); ok {
+		return x.CreateRevision
+	}
+	return 0
+}
+
+func (x *Compare) GetModRevision() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_ModRevision); ok {
+		return x.ModRevision
+	}
+	return 0
+}
+
+func (x *Compare) GetVersion() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_Version); ok {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Compare) GetLease() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_Lease); ok {
+		return x.Lease
+	}
+	return 0
+}
+
 func (x *Compare) GetRangeEnd() []byte {
 	if x != nil {
 		return x.RangeEnd
@@ -752,8 +1146,36 @@ type Compare_Value struct {
 	Value []byte `protobuf:"bytes,4,opt,name=value,proto3,oneof"`
 }
 
+type Compare_CreateRevision struct {
+	// create_revision is the creation revision of the given key.
+	CreateRevision int64 `protobuf:"varint,5,opt,name=create_revision,json=createRevision,proto3,oneof"`
+}
+
+type Compare_ModRevision struct {
+	// mod_revision is the last modified revision of the given key.
+	ModRevision int64 `protobuf:"varint,6,opt,name=mod_revision,json=modRevision,proto3,oneof"`
+}
+
+type Compare_Version struct {
+	// version is the version of the given key.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3,oneof"`
+}
+
+type Compare_Lease struct {
+	// lease is the lease id of the given key.
+	Lease int64 `protobuf:"varint,8,opt,name=lease,proto3,oneof"` // leave room for more target_union field tags, jump to 64
+}
+
 func (*Compare_Value) isCompare_TargetUnion() {}
 
+func (*Compare_CreateRevision) isCompare_TargetUnion() {}
+
+func (*Compare_ModRevision) isCompare_TargetUnion() {}
+
+func (*Compare_Version) isCompare_TargetUnion() {}
+
+func (*Compare_Lease) isCompare_TargetUnion() {}
+
 type KeyValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -767,12 +1189,20 @@ type KeyValue struct {
 	ModRevision int64 `protobuf:"varint,3,opt,name=mod_revision,json=modRevision,proto3" json:"mod_revision,omitempty"`
 	// value is the value held by the key, in bytes.
 	Value []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// version is the version of the key. A deletion resets
+	// the version to zero and any modification of the key
+	// increases its version.
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// lease is the ID of the lease that attached to key.
+	// When the attached lease expires, the key will be deleted.
+	// If lease is 0, then no lease is attached to the key.
+	Lease int64 `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *KeyValue) Reset() {
 	*x = KeyValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[6]
+		mi := &file_mvcc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -785,7 +1215,7 @@ func (x *KeyValue) String() string {
 func (*KeyValue) ProtoMessage() {}
 
 func (x *KeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[6]
+	mi := &file_mvcc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,7 +1228,7 @@ func (x *KeyValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
 func (*KeyValue) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{6}
+	return file_mvcc_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *KeyValue) GetKey() []byte {
@@ -829,10 +1259,96 @@ func (x *KeyValue) GetValue() []byte {
 	return nil
 }
 
-type RequestOp_Range struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *KeyValue) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyValue) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type is the kind of event. If type is a PUT, it indicates
+	// new data has been stored to the key. If type is a DELETE,
+	// it indicates the key was deleted.
+	Type Event_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=mvcc.v1.Event_EventType" json:"type,omitempty"`
+	// kv holds the KeyValue for the event.
+	// A PUT event contains current kv pair.
+	// A PUT event with kv.Version=1 indicates the creation of a key.
+	// A DELETE event contains the deleted key with
+	// its modification revision set to the revision of deletion.
+	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
+	// prev_kv holds the key-value pair before the event happens.
+	PrevKv *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Event) GetType() Event_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return Event_PUT
+}
+
+func (x *Event) GetKv() *KeyValue {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
+func (x *Event) GetPrevKv() *KeyValue {
+	if x != nil {
+		return x.PrevKv
+	}
+	return nil
+}
+
+type RequestOp_Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
 	// key is the first key for the range. If range_end is not given, the request only looks up key.
 	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -849,12 +1365,33 @@ type RequestOp_Range struct {
 	KeysOnly bool `protobuf:"varint,4,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`
 	// count_only when set returns only the count of the keys in the range.
 	CountOnly bool `protobuf:"varint,5,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	// min_mod_revision is the lower bound for returned key mod revisions; all keys with
+	// lesser mod revisions will be filtered away.
+	MinModRevision int64 `protobuf:"varint,6,opt,name=min_mod_revision,json=minModRevision,proto3" json:"min_mod_revision,omitempty"`
+	// max_mod_revision is the upper bound for returned key mod revisions; all keys with
+	// greater mod revisions will be filtered away.
+	MaxModRevision int64 `protobuf:"varint,7,opt,name=max_mod_revision,json=maxModRevision,proto3" json:"max_mod_revision,omitempty"`
+	// min_create_revision is the lower bound for returned key create revisions; all keys with
+	// lesser create revisions will be filtered away.
+	MinCreateRevision int64 `protobuf:"varint,8,opt,name=min_create_revision,json=minCreateRevision,proto3" json:"min_create_revision,omitempty"`
+	// max_create_revision is the upper bound for returned key create revisions; all keys with
+	// greater create revisions will be filtered away.
+	MaxCreateRevision int64 `protobuf:"varint,9,opt,name=max_create_revision,json=maxCreateRevision,proto3" json:"max_create_revision,omitempty"`
+	// sort_order is the order for returned sorted results.
+	SortOrder RequestOp_Range_SortOrder `protobuf:"varint,10,opt,name=sort_order,json=sortOrder,proto3,enum=mvcc.v1.RequestOp_Range_SortOrder" json:"sort_order,omitempty"`
+	// sort_target is the key-value field to use for sorting. Sorting by any other target than KEY
+	// reads the whole range into memory, the limit is applied after sorting.
+	SortTarget RequestOp_Range_SortTarget `protobuf:"varint,11,opt,name=sort_target,json=sortTarget,proto3,enum=mvcc.v1.RequestOp_Range_SortTarget" json:"sort_target,omitempty"`
+	// revision is the point-in-time of the key-value store to use for the range.
+	// If revision is less or equal to zero, the range is over the newest key-value store.
+	// If the revision has been compacted, ErrCompacted is returned as a response.
+	Revision int64 `protobuf:"varint,12,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
 func (x *RequestOp_Range) Reset() {
 	*x = RequestOp_Range{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[7]
+		mi := &file_mvcc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -867,7 +1404,7 @@ func (x *RequestOp_Range) String() string {
 func (*RequestOp_Range) ProtoMessage() {}
 
 func (x *RequestOp_Range) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[7]
+	mi := &file_mvcc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -880,7 +1417,7 @@ func (x *RequestOp_Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp_Range.ProtoReflect.Descriptor instead.
 func (*RequestOp_Range) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3, 0}
+	return file_mvcc_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *RequestOp_Range) GetKey() []byte {
@@ -918,6 +1455,55 @@ func (x *RequestOp_Range) GetCountOnly() bool {
 	return false
 }
 
+func (x *RequestOp_Range) GetMinModRevision() int64 {
+	if x != nil {
+		return x.MinModRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetMaxModRevision() int64 {
+	if x != nil {
+		return x.MaxModRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetMinCreateRevision() int64 {
+	if x != nil {
+		return x.MinCreateRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetMaxCreateRevision() int64 {
+	if x != nil {
+		return x.MaxCreateRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetSortOrder() RequestOp_Range_SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return RequestOp_Range_NONE
+}
+
+func (x *RequestOp_Range) GetSortTarget() RequestOp_Range_SortTarget {
+	if x != nil {
+		return x.SortTarget
+	}
+	return RequestOp_Range_KEY
+}
+
+func (x *RequestOp_Range) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type RequestOp_Put struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -929,12 +1515,15 @@ type RequestOp_Put struct {
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	// prev_kv if true the previous key-value pair will be returned in the put response.
 	PrevKv bool `protobuf:"varint,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	// lease is the lease ID to associate with the key in the key-value store. A lease
+	// value of 0 indicates no lease.
+	Lease int64 `protobuf:"varint,4,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *RequestOp_Put) Reset() {
 	*x = RequestOp_Put{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[8]
+		mi := &file_mvcc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -947,7 +1536,7 @@ func (x *RequestOp_Put) String() string {
 func (*RequestOp_Put) ProtoMessage() {}
 
 func (x *RequestOp_Put) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[8]
+	mi := &file_mvcc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -960,7 +1549,7 @@ func (x *RequestOp_Put) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp_Put.ProtoReflect.Descriptor instead.
 func (*RequestOp_Put) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3, 1}
+	return file_mvcc_proto_rawDescGZIP(), []int{4, 1}
 }
 
 func (x *RequestOp_Put) GetKey() []byte {
@@ -984,6 +1573,13 @@ func (x *RequestOp_Put) GetPrevKv() bool {
 	return false
 }
 
+func (x *RequestOp_Put) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
 type RequestOp_DeleteRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1010,7 +1606,7 @@ type RequestOp_DeleteRange struct {
 func (x *RequestOp_DeleteRange) Reset() {
 	*x = RequestOp_DeleteRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[9]
+		mi := &file_mvcc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1023,7 +1619,7 @@ func (x *RequestOp_DeleteRange) String() string {
 func (*RequestOp_DeleteRange) ProtoMessage() {}
 
 func (x *RequestOp_DeleteRange) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[9]
+	mi := &file_mvcc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,7 +1632,7 @@ func (x *RequestOp_DeleteRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp_DeleteRange.ProtoReflect.Descriptor instead.
 func (*RequestOp_DeleteRange) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3, 2}
+	return file_mvcc_proto_rawDescGZIP(), []int{4, 2}
 }
 
 func (x *RequestOp_DeleteRange) GetKey() []byte {
@@ -1067,6 +1663,90 @@ func (x *RequestOp_DeleteRange) GetCount() bool {
 	return false
 }
 
+type RequestOp_Increment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key is the key, in bytes, of the counter to increment.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// delta is added to the value of the counter, the value is a big-endian encoded 8-byte signed integer.
+	Delta int64 `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	// initial is the value of the counter the delta is added to if the key does not exist.
+	Initial int64 `protobuf:"varint,3,opt,name=initial,proto3" json:"initial,omitempty"`
+	// min is the lower bound (inclusive) of the counter, the delta is not added if the result would be lower.
+	Min *int64 `protobuf:"varint,4,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// max is the upper bound (inclusive) of the counter, the delta is not added if the result would be higher.
+	Max *int64 `protobuf:"varint,5,opt,name=max,proto3,oneof" json:"max,omitempty"`
+}
+
+func (x *RequestOp_Increment) Reset() {
+	*x = RequestOp_Increment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestOp_Increment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestOp_Increment) ProtoMessage() {}
+
+func (x *RequestOp_Increment) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestOp_Increment.ProtoReflect.Descriptor instead.
+func (*RequestOp_Increment) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{4, 3}
+}
+
+func (x *RequestOp_Increment) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *RequestOp_Increment) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *RequestOp_Increment) GetInitial() int64 {
+	if x != nil {
+		return x.Initial
+	}
+	return 0
+}
+
+func (x *RequestOp_Increment) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *RequestOp_Increment) GetMax() int64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
 type ResponseOp_Range struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1084,7 +1764,7 @@ type ResponseOp_Range struct {
 func (x *ResponseOp_Range) Reset() {
 	*x = ResponseOp_Range{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[10]
+		mi := &file_mvcc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1097,7 +1777,7 @@ func (x *ResponseOp_Range) String() string {
 func (*ResponseOp_Range) ProtoMessage() {}
 
 func (x *ResponseOp_Range) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[10]
+	mi := &file_mvcc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +1790,7 @@ func (x *ResponseOp_Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp_Range.ProtoReflect.Descriptor instead.
 func (*ResponseOp_Range) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4, 0}
+	return file_mvcc_proto_rawDescGZIP(), []int{5, 0}
 }
 
 func (x *ResponseOp_Range) GetKvs() []*KeyValue {
@@ -1146,7 +1826,7 @@ type ResponseOp_Put struct {
 func (x *ResponseOp_Put) Reset() {
 	*x = ResponseOp_Put{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[11]
+		mi := &file_mvcc_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1159,7 +1839,7 @@ func (x *ResponseOp_Put) String() string {
 func (*ResponseOp_Put) ProtoMessage() {}
 
 func (x *ResponseOp_Put) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[11]
+	mi := &file_mvcc_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1172,7 +1852,7 @@ func (x *ResponseOp_Put) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp_Put.ProtoReflect.Descriptor instead.
 func (*ResponseOp_Put) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4, 1}
+	return file_mvcc_proto_rawDescGZIP(), []int{5, 1}
 }
 
 func (x *ResponseOp_Put) GetPrevKv() *KeyValue {
@@ -1196,7 +1876,7 @@ type ResponseOp_DeleteRange struct {
 func (x *ResponseOp_DeleteRange) Reset() {
 	*x = ResponseOp_DeleteRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[12]
+		mi := &file_mvcc_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1209,7 +1889,7 @@ func (x *ResponseOp_DeleteRange) String() string {
 func (*ResponseOp_DeleteRange) ProtoMessage() {}
 
 func (x *ResponseOp_DeleteRange) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[12]
+	mi := &file_mvcc_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1222,7 +1902,7 @@ func (x *ResponseOp_DeleteRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp_DeleteRange.ProtoReflect.Descriptor instead.
 func (*ResponseOp_DeleteRange) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4, 2}
+	return file_mvcc_proto_rawDescGZIP(), []int{5, 2}
 }
 
 func (x *ResponseOp_DeleteRange) GetDeleted() int64 {
@@ -1239,11 +1919,127 @@ func (x *ResponseOp_DeleteRange) GetPrevKvs() []*KeyValue {
 	return nil
 }
 
+type ResponseOp_Txn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// succeeded is set to true if the compare of the nested transaction evaluated to true or false otherwise.
+	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// responses is a list of responses corresponding to the results from applying
+	// success if succeeded is true or failure if succeeded is false.
+	Responses []*ResponseOp `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (x *ResponseOp_Txn) Reset() {
+	*x = ResponseOp_Txn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseOp_Txn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseOp_Txn) ProtoMessage() {}
+
+func (x *ResponseOp_Txn) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseOp_Txn.ProtoReflect.Descriptor instead.
+func (*ResponseOp_Txn) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{5, 3}
+}
+
+func (x *ResponseOp_Txn) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *ResponseOp_Txn) GetResponses() []*ResponseOp {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+type ResponseOp_Increment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// value is the value of the counter after the operation.
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	// applied is false if the delta was not added, the value of the key is left intact in such a case.
+	// The delta is not added if the result would be out of the bounds (or would overflow).
+	Applied bool `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+}
+
+func (x *ResponseOp_Increment) Reset() {
+	*x = ResponseOp_Increment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseOp_Increment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseOp_Increment) ProtoMessage() {}
+
+func (x *ResponseOp_Increment) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseOp_Increment.ProtoReflect.Descriptor instead.
+func (*ResponseOp_Increment) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{5, 4}
+}
+
+func (x *ResponseOp_Increment) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ResponseOp_Increment) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
 var File_mvcc_proto protoreflect.FileDescriptor
 
 var file_mvcc_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6d, 0x76,
-	0x63, 0x63, 0x2e, 0x76, 0x31, 0x22, 0xff, 0x03, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x63, 0x63, 0x2e, 0x76, 0x31, 0x22, 0xb6, 0x06, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
 	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e,
@@ -1266,22 +2062,49 @@ var file_mvcc_proto_rawDesc = []byte{
 	0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
 	0x61, 0x6e, 0x64, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a,
 	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44,
-	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x55, 0x4d, 0x4d, 0x59,
-	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10,
-	0x03, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x42, 0x41, 0x54, 0x43,
-	0x48, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x58, 0x4e, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08,
-	0x53, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x06, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x06, 0x0a, 0x04, 0x5f,
-	0x74, 0x78, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e,
-	0x64, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0x5e, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x76,
-	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70,
-	0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72,
-	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72,
-	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12,
+	0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61,
+	0x73, 0x65, 0x48, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x76,
+	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e,
+	0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x04, 0x52, 0x09, 0x69, 0x6e, 0x63,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x69, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x22, 0xc5, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x55, 0x4d, 0x4d, 0x59, 0x10,
+	0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x03,
+	0x12, 0x10, 0x0a, 0x0c, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48,
+	0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x58, 0x4e, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x53,
+	0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x45, 0x41,
+	0x53, 0x45, 0x5f, 0x47, 0x52, 0x41, 0x4e, 0x54, 0x10, 0x07, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x45,
+	0x41, 0x53, 0x45, 0x5f, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x10, 0x08, 0x12, 0x13, 0x0a, 0x0f,
+	0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x4b, 0x45, 0x45, 0x50, 0x41, 0x4c, 0x49, 0x56, 0x45, 0x10,
+	0x09, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x43, 0x54, 0x10, 0x0a, 0x12, 0x0d,
+	0x0a, 0x09, 0x49, 0x4e, 0x43, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x0b, 0x12, 0x0a, 0x0a,
+	0x06, 0x49, 0x4e, 0x47, 0x45, 0x53, 0x54, 0x10, 0x0c, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x74,
+	0x78, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64,
+	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69,
+	0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0x93,
+	0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x29, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x48, 0x00,
+	0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x22, 0x41, 0x0a, 0x05, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x8d, 0x01, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12,
 	0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
 	0x72, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x73,
@@ -1290,7 +2113,7 @@ var file_mvcc_proto_rawDesc = []byte{
 	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x61, 0x69,
 	0x6c, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x76, 0x63,
 	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x52, 0x07,
-	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0xa6, 0x04, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0x95, 0x0a, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x4f, 0x70, 0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d,
 	0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70,
@@ -1304,85 +2127,175 @@ var file_mvcc_proto_rawDesc = []byte{
 	0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x4f, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48,
 	0x00, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x88, 0x01, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x73, 0x5f, 0x6f, 0x6e, 0x6c,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x4f, 0x6e, 0x6c,
-	0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79,
-	0x1a, 0x46, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
-	0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x1a, 0x6b, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e,
-	0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b,
-	0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x12,
-	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0xd3, 0x03, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x12,
-	0x42, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x52, 0x61, 0x6e,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61,
-	0x6e, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
-	0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x76, 0x63, 0x63,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x50,
-	0x75, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x75,
-	0x74, 0x12, 0x55, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x56, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x12, 0x23, 0x0a, 0x03, 0x6b, 0x76, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x03, 0x6b, 0x76, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x1a, 0x31, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f,
-	0x6b, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x70, 0x72, 0x65,
-	0x76, 0x4b, 0x76, 0x1a, 0x55, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e,
-	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x08,
-	0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xae, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x72, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63,
-	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x40, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x22, 0x40, 0x0a, 0x0d, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x51,
-	0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
-	0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x03, 0x22, 0x1a, 0x0a, 0x0d, 0x43,
-	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x09, 0x0a, 0x05,
-	0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x00, 0x42, 0x0e, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x7e, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f,
-	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21,
-	0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x74, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x74, 0x78, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x76, 0x63,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x54, 0x78, 0x6e, 0x12, 0x4b, 0x0a, 0x11, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x1a, 0xd5, 0x04, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x28,
+	0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x4d, 0x6f, 0x64,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f,
+	0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4d, 0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x11, 0x6d, 0x69, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x11, 0x6d, 0x61, 0x78, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09, 0x73, 0x6f, 0x72, 0x74,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0b, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6d, 0x76, 0x63,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x0a, 0x73, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2e, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x41, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45,
+	0x53, 0x43, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x22, 0x42, 0x0a, 0x0a, 0x53, 0x6f, 0x72, 0x74, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x59, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x43,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x4f, 0x44, 0x10, 0x03,
+	0x12, 0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x04, 0x1a, 0x5c, 0x0a, 0x03, 0x50,
+	0x75, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72,
+	0x65, 0x76, 0x5f, 0x6b, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x65,
+	0x76, 0x4b, 0x76, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x1a, 0x6b, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f,
+	0x6b, 0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x8b, 0x01, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61,
+	0x78, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04,
+	0x5f, 0x6d, 0x61, 0x78, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0xf6, 0x05, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x12, 0x42,
+	0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x70,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x50, 0x75,
+	0x74, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x75, 0x74,
+	0x12, 0x55, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4f, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x5f, 0x74, 0x78, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x4f, 0x70, 0x2e, 0x54, 0x78, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x54, 0x78, 0x6e, 0x12, 0x4e, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x5f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x56, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x23,
+	0x0a, 0x03, 0x6b, 0x76, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76,
+	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03,
+	0x6b, 0x76, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x31, 0x0a,
+	0x03, 0x50, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76,
+	0x1a, 0x55, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x72, 0x65,
+	0x76, 0x5f, 0x6b, 0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76,
+	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07,
+	0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x73, 0x1a, 0x56, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x09,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4f, 0x70, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x1a,
+	0x3b, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x42, 0x0a, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xdf, 0x03, 0x0a, 0x07, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d,
+	0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29,
+	0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0c, 0x6d, 0x6f, 0x64,
+	0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x05, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18,
+	0x40, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x22,
+	0x40, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x09, 0x0a, 0x05, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x47,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x53, 0x53,
+	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10,
+	0x03, 0x22, 0x47, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52,
+	0x45, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x4f, 0x44, 0x10, 0x03, 0x12,
+	0x09, 0x0a, 0x05, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x10, 0x04, 0x42, 0x0e, 0x0a, 0x0c, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0xae, 0x01, 0x0a, 0x08, 0x4b,
+	0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x05,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x02, 0x6b, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x02, 0x6b, 0x76, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b,
+	0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76,
+	0x4b, 0x76, 0x22, 0x20, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45,
+	0x54, 0x45, 0x10, 0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x72, 0x65, 0x67, 0x61, 0x74, 0x74,
+	0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1397,52 +2310,73 @@ func file_mvcc_proto_rawDescGZIP() []byte {
 	return file_mvcc_proto_rawDescData
 }
 
-var file_mvcc_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_mvcc_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_mvcc_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_mvcc_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_mvcc_proto_goTypes = []interface{}{
-	(Command_CommandType)(0),       // 0: mvcc.v1.Command.CommandType
-	(Compare_CompareResult)(0),     // 1: mvcc.v1.Compare.CompareResult
-	(Compare_CompareTarget)(0),     // 2: mvcc.v1.Compare.CompareTarget
-	(*Command)(nil),                // 3: mvcc.v1.Command
-	(*CommandResult)(nil),          // 4: mvcc.v1.CommandResult
-	(*Txn)(nil),                    // 5: mvcc.v1.Txn
-	(*RequestOp)(nil),              // 6: mvcc.v1.RequestOp
-	(*ResponseOp)(nil),             // 7: mvcc.v1.ResponseOp
-	(*Compare)(nil),                // 8: mvcc.v1.Compare
-	(*KeyValue)(nil),               // 9: mvcc.v1.KeyValue
-	(*RequestOp_Range)(nil),        // 10: mvcc.v1.RequestOp.Range
-	(*RequestOp_Put)(nil),          // 11: mvcc.v1.RequestOp.Put
-	(*RequestOp_DeleteRange)(nil),  // 12: mvcc.v1.RequestOp.DeleteRange
-	(*ResponseOp_Range)(nil),       // 13: mvcc.v1.ResponseOp.Range
-	(*ResponseOp_Put)(nil),         // 14: mvcc.v1.ResponseOp.Put
-	(*ResponseOp_DeleteRange)(nil), // 15: mvcc.v1.ResponseOp.DeleteRange
+	(Command_CommandType)(0),        // 0: mvcc.v1.Command.CommandType
+	(RequestOp_Range_SortOrder)(0),  // 1: mvcc.v1.RequestOp.Range.SortOrder
+	(RequestOp_Range_SortTarget)(0), // 2: mvcc.v1.RequestOp.Range.SortTarget
+	(Compare_CompareResult)(0),      // 3: mvcc.v1.Compare.CompareResult
+	(Compare_CompareTarget)(0),      // 4: mvcc.v1.Compare.CompareTarget
+	(Event_EventType)(0),            // 5: mvcc.v1.Event.EventType
+	(*Command)(nil),                 // 6: mvcc.v1.Command
+	(*CommandResult)(nil),           // 7: mvcc.v1.CommandResult
+	(*Lease)(nil),                   // 8: mvcc.v1.Lease
+	(*Txn)(nil),                     // 9: mvcc.v1.Txn
+	(*RequestOp)(nil),               // 10: mvcc.v1.RequestOp
+	(*ResponseOp)(nil),              // 11: mvcc.v1.ResponseOp
+	(*Compare)(nil),                 // 12: mvcc.v1.Compare
+	(*KeyValue)(nil),                // 13: mvcc.v1.KeyValue
+	(*Event)(nil),                   // 14: mvcc.v1.Event
+	(*RequestOp_Range)(nil),         // 15: mvcc.v1.RequestOp.Range
+	(*RequestOp_Put)(nil),           // 16: mvcc.v1.RequestOp.Put
+	(*RequestOp_DeleteRange)(nil),   // 17: mvcc.v1.RequestOp.DeleteRange
+	(*RequestOp_Increment)(nil),     // 18: mvcc.v1.RequestOp.Increment
+	(*ResponseOp_Range)(nil),        // 19: mvcc.v1.ResponseOp.Range
+	(*ResponseOp_Put)(nil),          // 20: mvcc.v1.ResponseOp.Put
+	(*ResponseOp_DeleteRange)(nil),  // 21: mvcc.v1.ResponseOp.DeleteRange
+	(*ResponseOp_Txn)(nil),          // 22: mvcc.v1.ResponseOp.Txn
+	(*ResponseOp_Increment)(nil),    // 23: mvcc.v1.ResponseOp.Increment
 }
 var file_mvcc_proto_depIdxs = []int32{
 	0,  // 0: mvcc.v1.Command.type:type_name -> mvcc.v1.Command.CommandType
-	9,  // 1: mvcc.v1.Command.kv:type_name -> mvcc.v1.KeyValue
-	9,  // 2: mvcc.v1.Command.batch:type_name -> mvcc.v1.KeyValue
-	5,  // 3: mvcc.v1.Command.txn:type_name -> mvcc.v1.Txn
-	3,  // 4: mvcc.v1.Command.sequence:type_name -> mvcc.v1.Command
-	7,  // 5: mvcc.v1.CommandResult.responses:type_name -> mvcc.v1.ResponseOp
-	8,  // 6: mvcc.v1.Txn.compare:type_name -> mvcc.v1.Compare
-	6,  // 7: mvcc.v1.Txn.success:type_name -> mvcc.v1.RequestOp
-	6,  // 8: mvcc.v1.Txn.failure:type_name -> mvcc.v1.RequestOp
-	10, // 9: mvcc.v1.RequestOp.request_range:type_name -> mvcc.v1.RequestOp.Range
-	11, // 10: mvcc.v1.RequestOp.request_put:type_name -> mvcc.v1.RequestOp.Put
-	12, // 11: mvcc.v1.RequestOp.request_delete_range:type_name -> mvcc.v1.RequestOp.DeleteRange
-	13, // 12: mvcc.v1.ResponseOp.response_range:type_name -> mvcc.v1.ResponseOp.Range
-	14, // 13: mvcc.v1.ResponseOp.response_put:type_name -> mvcc.v1.ResponseOp.Put
-	15, // 14: mvcc.v1.ResponseOp.response_delete_range:type_name -> mvcc.v1.ResponseOp.DeleteRange
-	1,  // 15: mvcc.v1.Compare.result:type_name -> mvcc.v1.Compare.CompareResult
-	2,  // 16: mvcc.v1.Compare.target:type_name -> mvcc.v1.Compare.CompareTarget
-	9,  // 17: mvcc.v1.ResponseOp.Range.kvs:type_name -> mvcc.v1.KeyValue
-	9,  // 18: mvcc.v1.ResponseOp.Put.prev_kv:type_name -> mvcc.v1.KeyValue
-	9,  // 19: mvcc.v1.ResponseOp.DeleteRange.prev_kvs:type_name -> mvcc.v1.KeyValue
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	13, // 1: mvcc.v1.Command.kv:type_name -> mvcc.v1.KeyValue
+	13, // 2: mvcc.v1.Command.batch:type_name -> mvcc.v1.KeyValue
+	9,  // 3: mvcc.v1.Command.txn:type_name -> mvcc.v1.Txn
+	6,  // 4: mvcc.v1.Command.sequence:type_name -> mvcc.v1.Command
+	8,  // 5: mvcc.v1.Command.lease:type_name -> mvcc.v1.Lease
+	18, // 6: mvcc.v1.Command.increment:type_name -> mvcc.v1.RequestOp.Increment
+	11, // 7: mvcc.v1.CommandResult.responses:type_name -> mvcc.v1.ResponseOp
+	8,  // 8: mvcc.v1.CommandResult.lease:type_name -> mvcc.v1.Lease
+	12, // 9: mvcc.v1.Txn.compare:type_name -> mvcc.v1.Compare
+	10, // 10: mvcc.v1.Txn.success:type_name -> mvcc.v1.RequestOp
+	10, // 11: mvcc.v1.Txn.failure:type_name -> mvcc.v1.RequestOp
+	15, // 12: mvcc.v1.RequestOp.request_range:type_name -> mvcc.v1.RequestOp.Range
+	16, // 13: mvcc.v1.RequestOp.request_put:type_name -> mvcc.v1.RequestOp.Put
+	17, // 14: mvcc.v1.RequestOp.request_delete_range:type_name -> mvcc.v1.RequestOp.DeleteRange
+	9,  // 15: mvcc.v1.RequestOp.request_txn:type_name -> mvcc.v1.Txn
+	18, // 16: mvcc.v1.RequestOp.request_increment:type_name -> mvcc.v1.RequestOp.Increment
+	19, // 17: mvcc.v1.ResponseOp.response_range:type_name -> mvcc.v1.ResponseOp.Range
+	20, // 18: mvcc.v1.ResponseOp.response_put:type_name -> mvcc.v1.ResponseOp.Put
+	21, // 19: mvcc.v1.ResponseOp.response_delete_range:type_name -> mvcc.v1.ResponseOp.DeleteRange
+	22, // 20: mvcc.v1.ResponseOp.response_txn:type_name -> mvcc.v1.ResponseOp.Txn
+	23, // 21: mvcc.v1.ResponseOp.response_increment:type_name -> mvcc.v1.ResponseOp.Increment
+	3,  // 22: mvcc.v1.Compare.result:type_name -> mvcc.v1.Compare.CompareResult
+	4,  // 23: mvcc.v1.Compare.target:type_name -> mvcc.v1.Compare.CompareTarget
+	5,  // 24: mvcc.v1.Event.type:type_name -> mvcc.v1.Event.EventType
+	13, // 25: mvcc.v1.Event.kv:type_name -> mvcc.v1.KeyValue
+	13, // 26: mvcc.v1.Event.prev_kv:type_name -> mvcc.v1.KeyValue
+	1,  // 27: mvcc.v1.RequestOp.Range.sort_order:type_name -> mvcc.v1.RequestOp.Range.SortOrder
+	2,  // 28: mvcc.v1.RequestOp.Range.sort_target:type_name -> mvcc.v1.RequestOp.Range.SortTarget
+	13, // 29: mvcc.v1.ResponseOp.Range.kvs:type_name -> mvcc.v1.KeyValue
+	13, // 30: mvcc.v1.ResponseOp.Put.prev_kv:type_name -> mvcc.v1.KeyValue
+	13, // 31: mvcc.v1.ResponseOp.DeleteRange.prev_kvs:type_name -> mvcc.v1.KeyValue
+	11, // 32: mvcc.v1.ResponseOp.Txn.responses:type_name -> mvcc.v1.ResponseOp
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_mvcc_proto_init() }
@@ -1476,7 +2410,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Txn); i {
+			switch v := v.(*Lease); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1488,7 +2422,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp); i {
+			switch v := v.(*Txn); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1500,7 +2434,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOp); i {
+			switch v := v.(*RequestOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1512,7 +2446,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Compare); i {
+			switch v := v.(*ResponseOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1524,7 +2458,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyValue); i {
+			switch v := v.(*Compare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1536,7 +2470,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp_Range); i {
+			switch v := v.(*KeyValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1548,7 +2482,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp_Put); i {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1560,7 +2494,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp_DeleteRange); i {
+			switch v := v.(*RequestOp_Range); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1572,7 +2506,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOp_Range); i {
+			switch v := v.(*RequestOp_Put); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1584,7 +2518,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOp_Put); i {
+			switch v := v.(*RequestOp_DeleteRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1596,6 +2530,42 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestOp_Increment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Put); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResponseOp_DeleteRange); i {
 			case 0:
 				return &v.state
@@ -1607,28 +2577,62 @@ func file_mvcc_proto_init() {
 				return nil
 			}
 		}
+		file_mvcc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Txn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Increment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_mvcc_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_mvcc_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_mvcc_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_mvcc_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*RequestOp_RequestRange)(nil),
 		(*RequestOp_RequestPut)(nil),
 		(*RequestOp_RequestDeleteRange)(nil),
+		(*RequestOp_RequestTxn)(nil),
+		(*RequestOp_RequestIncrement)(nil),
 	}
-	file_mvcc_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_mvcc_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*ResponseOp_ResponseRange)(nil),
 		(*ResponseOp_ResponsePut)(nil),
 		(*ResponseOp_ResponseDeleteRange)(nil),
+		(*ResponseOp_ResponseTxn)(nil),
+		(*ResponseOp_ResponseIncrement)(nil),
 	}
-	file_mvcc_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_mvcc_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*Compare_Value)(nil),
+		(*Compare_CreateRevision)(nil),
+		(*Compare_ModRevision)(nil),
+		(*Compare_Version)(nil),
+		(*Compare_Lease)(nil),
 	}
+	file_mvcc_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mvcc_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   13,
+			NumEnums:      6,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -122,6 +122,109 @@ func (Table_Type) EnumDescriptor() ([]byte, []int) {
 	return file_replication_proto_rawDescGZIP(), []int{2, 0}
 }
 
+type TableConfig_RecoveryType int32
+
+const (
+	// RECOVERY_DEFAULT uses the cluster-wide snapshot recovery type.
+	TableConfig_RECOVERY_DEFAULT TableConfig_RecoveryType = 0
+	TableConfig_SNAPSHOT         TableConfig_RecoveryType = 1
+	TableConfig_CHECKPOINT       TableConfig_RecoveryType = 2
+)
+
+// Enum value maps for TableConfig_RecoveryType.
+var (
+	TableConfig_RecoveryType_name = map[int32]string{
+		0: "RECOVERY_DEFAULT",
+		1: "SNAPSHOT",
+		2: "CHECKPOINT",
+	}
+	TableConfig_RecoveryType_value = map[string]int32{
+		"RECOVERY_DEFAULT": 0,
+		"SNAPSHOT":         1,
+		"CHECKPOINT":       2,
+	}
+)
+
+func (x TableConfig_RecoveryType) Enum() *TableConfig_RecoveryType {
+	p := new(TableConfig_RecoveryType)
+	*p = x
+	return p
+}
+
+func (x TableConfig_RecoveryType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TableConfig_RecoveryType) Descriptor() protoreflect.EnumDescriptor {
+	return file_replication_proto_enumTypes[2].Descriptor()
+}
+
+func (TableConfig_RecoveryType) Type() protoreflect.EnumType {
+	return &file_replication_proto_enumTypes[2]
+}
+
+func (x TableConfig_RecoveryType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TableConfig_RecoveryType.Descriptor instead.
+func (TableConfig_RecoveryType) EnumDescriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type TableConfig_Compression int32
+
+const (
+	// COMPRESSION_DEFAULT uses the snappy compression.
+	TableConfig_COMPRESSION_DEFAULT TableConfig_Compression = 0
+	TableConfig_NONE                TableConfig_Compression = 1
+	TableConfig_SNAPPY              TableConfig_Compression = 2
+	TableConfig_ZSTD                TableConfig_Compression = 3
+)
+
+// Enum value maps for TableConfig_Compression.
+var (
+	TableConfig_Compression_name = map[int32]string{
+		0: "COMPRESSION_DEFAULT",
+		1: "NONE",
+		2: "SNAPPY",
+		3: "ZSTD",
+	}
+	TableConfig_Compression_value = map[string]int32{
+		"COMPRESSION_DEFAULT": 0,
+		"NONE":                1,
+		"SNAPPY":              2,
+		"ZSTD":                3,
+	}
+)
+
+func (x TableConfig_Compression) Enum() *TableConfig_Compression {
+	p := new(TableConfig_Compression)
+	*p = x
+	return p
+}
+
+func (x TableConfig_Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TableConfig_Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_replication_proto_enumTypes[3].Descriptor()
+}
+
+func (TableConfig_Compression) Type() protoreflect.EnumType {
+	return &file_replication_proto_enumTypes[3]
+}
+
+func (x TableConfig_Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TableConfig_Compression.Descriptor instead.
+func (TableConfig_Compression) EnumDescriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{3, 1}
+}
+
 type MetadataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -214,6 +317,8 @@ type Table struct {
 
 	Name string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Type Table_Type `protobuf:"varint,2,opt,name=type,proto3,enum=replication.v1.Table_Type" json:"type,omitempty"`
+	// config is the configuration specific to the table, the replicated tables are created with the same configuration.
+	Config *TableConfig `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
 }
 
 func (x *Table) Reset() {
@@ -262,6 +367,134 @@ func (x *Table) GetType() Table_Type {
 	return Table_REPLICATED
 }
 
+func (x *Table) GetConfig() *TableConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// TableConfig is the configuration specific to a single table.
+type TableConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// snapshot_entries defines how often the table is snapshotted in terms of the number of applied Raft log entries, 0 disables the automatic snapshotting.
+	SnapshotEntries *uint64 `protobuf:"varint,1,opt,name=snapshot_entries,json=snapshotEntries,proto3,oneof" json:"snapshot_entries,omitempty"`
+	// compaction_overhead is the number of most recent Raft log entries retained after the Raft log compaction.
+	CompactionOverhead *uint64 `protobuf:"varint,2,opt,name=compaction_overhead,json=compactionOverhead,proto3,oneof" json:"compaction_overhead,omitempty"`
+	// max_in_mem_log_size is the target size in bytes allowed for storing in-memory Raft logs.
+	MaxInMemLogSize *uint64 `protobuf:"varint,3,opt,name=max_in_mem_log_size,json=maxInMemLogSize,proto3,oneof" json:"max_in_mem_log_size,omitempty"`
+	// recovery_type is the in-cluster snapshot recovery type.
+	RecoveryType TableConfig_RecoveryType `protobuf:"varint,4,opt,name=recovery_type,json=recoveryType,proto3,enum=replication.v1.TableConfig_RecoveryType" json:"recovery_type,omitempty"`
+	// compression is the block compression of the table storage.
+	Compression TableConfig_Compression `protobuf:"varint,5,opt,name=compression,proto3,enum=replication.v1.TableConfig_Compression" json:"compression,omitempty"`
+	// bloom_filter_bits_per_key is the number of bits per key of the bloom filters of the table storage, 0 disables the bloom filters.
+	BloomFilterBitsPerKey *uint32 `protobuf:"varint,6,opt,name=bloom_filter_bits_per_key,json=bloomFilterBitsPerKey,proto3,oneof" json:"bloom_filter_bits_per_key,omitempty"`
+	// memtable_size is the size of a single memtable of the table storage in bytes.
+	MemtableSize *uint64 `protobuf:"varint,7,opt,name=memtable_size,json=memtableSize,proto3,oneof" json:"memtable_size,omitempty"`
+	// max_value_size is the maximum size of a single value in bytes.
+	MaxValueSize *uint64 `protobuf:"varint,8,opt,name=max_value_size,json=maxValueSize,proto3,oneof" json:"max_value_size,omitempty"`
+	// history_retention is the number of the most recent revisions the history of the keys is retained for, 0 retains the whole history.
+	HistoryRetention *uint64 `protobuf:"varint,9,opt,name=history_retention,json=historyRetention,proto3,oneof" json:"history_retention,omitempty"`
+}
+
+func (x *TableConfig) Reset() {
+	*x = TableConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_replication_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableConfig) ProtoMessage() {}
+
+func (x *TableConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableConfig.ProtoReflect.Descriptor instead.
+func (*TableConfig) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TableConfig) GetSnapshotEntries() uint64 {
+	if x != nil && x.SnapshotEntries != nil {
+		return *x.SnapshotEntries
+	}
+	return 0
+}
+
+func (x *TableConfig) GetCompactionOverhead() uint64 {
+	if x != nil && x.CompactionOverhead != nil {
+		return *x.CompactionOverhead
+	}
+	return 0
+}
+
+func (x *TableConfig) GetMaxInMemLogSize() uint64 {
+	if x != nil && x.MaxInMemLogSize != nil {
+		return *x.MaxInMemLogSize
+	}
+	return 0
+}
+
+func (x *TableConfig) GetRecoveryType() TableConfig_RecoveryType {
+	if x != nil {
+		return x.RecoveryType
+	}
+	return TableConfig_RECOVERY_DEFAULT
+}
+
+func (x *TableConfig) GetCompression() TableConfig_Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return TableConfig_COMPRESSION_DEFAULT
+}
+
+func (x *TableConfig) GetBloomFilterBitsPerKey() uint32 {
+	if x != nil && x.BloomFilterBitsPerKey != nil {
+		return *x.BloomFilterBitsPerKey
+	}
+	return 0
+}
+
+func (x *TableConfig) GetMemtableSize() uint64 {
+	if x != nil && x.MemtableSize != nil {
+		return *x.MemtableSize
+	}
+	return 0
+}
+
+func (x *TableConfig) GetMaxValueSize() uint64 {
+	if x != nil && x.MaxValueSize != nil {
+		return *x.MaxValueSize
+	}
+	return 0
+}
+
+func (x *TableConfig) GetHistoryRetention() uint64 {
+	if x != nil && x.HistoryRetention != nil {
+		return *x.HistoryRetention
+	}
+	return 0
+}
+
 type SnapshotRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -269,12 +502,16 @@ type SnapshotRequest struct {
 
 	// table is name of the table to stream
 	Table []byte `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	// id of the interrupted snapshot stream to resume, a new snapshot is streamed if empty or the snapshot is no longer held by the server.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// offset in the snapshot to resume the stream from.
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 }
 
 func (x *SnapshotRequest) Reset() {
 	*x = SnapshotRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[3]
+		mi := &file_replication_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -287,7 +524,7 @@ func (x *SnapshotRequest) String() string {
 func (*SnapshotRequest) ProtoMessage() {}
 
 func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[3]
+	mi := &file_replication_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +537,7 @@ func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
 func (*SnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{3}
+	return file_replication_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SnapshotRequest) GetTable() []byte {
@@ -310,6 +547,20 @@ func (x *SnapshotRequest) GetTable() []byte {
 	return nil
 }
 
+func (x *SnapshotRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
 type SnapshotChunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -321,12 +572,18 @@ type SnapshotChunk struct {
 	Len uint64 `protobuf:"varint,2,opt,name=len,proto3" json:"len,omitempty"`
 	// index the index for which the snapshot was created
 	Index uint64 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// offset of the data in the snapshot.
+	Offset uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// checksum is the CRC-32 checksum of the data using the Castagnoli polynomial.
+	Checksum *uint32 `protobuf:"varint,5,opt,name=checksum,proto3,oneof" json:"checksum,omitempty"`
+	// id of the snapshot, the stream could be resumed using the id if set.
+	Id string `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (x *SnapshotChunk) Reset() {
 	*x = SnapshotChunk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[4]
+		mi := &file_replication_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -339,7 +596,7 @@ func (x *SnapshotChunk) String() string {
 func (*SnapshotChunk) ProtoMessage() {}
 
 func (x *SnapshotChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[4]
+	mi := &file_replication_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +609,7 @@ func (x *SnapshotChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotChunk.ProtoReflect.Descriptor instead.
 func (*SnapshotChunk) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{4}
+	return file_replication_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SnapshotChunk) GetData() []byte {
@@ -376,6 +633,27 @@ func (x *SnapshotChunk) GetIndex() uint64 {
 	return 0
 }
 
+func (x *SnapshotChunk) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SnapshotChunk) GetChecksum() uint32 {
+	if x != nil && x.Checksum != nil {
+		return *x.Checksum
+	}
+	return 0
+}
+
+func (x *SnapshotChunk) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 // ReplicateRequest request of the replication data at given leader_index
 type ReplicateRequest struct {
 	state         protoimpl.MessageState
@@ -386,12 +664,19 @@ type ReplicateRequest struct {
 	Table []byte `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
 	// leader_index is the index in the leader raft log of the last stored item in the follower
 	LeaderIndex uint64 `protobuf:"varint,2,opt,name=leader_index,json=leaderIndex,proto3" json:"leader_index,omitempty"`
+	// stream keeps the stream open once the commands present in the log are sent, the new commands are then pushed
+	// as they are applied. Leaders not supporting the streaming end the stream once the commands present in the log are sent.
+	Stream bool `protobuf:"varint,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	// heartbeat_interval is the maximum interval in milliseconds between the messages of the open stream, the leader sends
+	// the ReplicateResponse carrying only the leader_index if there is no new command to push. Applies only to the stream,
+	// the server default is used if not set.
+	HeartbeatInterval int64 `protobuf:"varint,4,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
 }
 
 func (x *ReplicateRequest) Reset() {
 	*x = ReplicateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[5]
+		mi := &file_replication_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -404,7 +689,7 @@ func (x *ReplicateRequest) String() string {
 func (*ReplicateRequest) ProtoMessage() {}
 
 func (x *ReplicateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[5]
+	mi := &file_replication_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +702,7 @@ func (x *ReplicateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicateRequest.ProtoReflect.Descriptor instead.
 func (*ReplicateRequest) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{5}
+	return file_replication_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ReplicateRequest) GetTable() []byte {
@@ -434,6 +719,20 @@ func (x *ReplicateRequest) GetLeaderIndex() uint64 {
 	return 0
 }
 
+func (x *ReplicateRequest) GetStream() bool {
+	if x != nil {
+		return x.Stream
+	}
+	return false
+}
+
+func (x *ReplicateRequest) GetHeartbeatInterval() int64 {
+	if x != nil {
+		return x.HeartbeatInterval
+	}
+	return 0
+}
+
 // ReplicateResponse response to the ReplicateRequest
 type ReplicateResponse struct {
 	state         protoimpl.MessageState
@@ -447,12 +746,15 @@ type ReplicateResponse struct {
 	Response isReplicateResponse_Response `protobuf_oneof:"response"`
 	// leader_index is the largest applied leader index at the time of the client RPC.
 	LeaderIndex uint64 `protobuf:"varint,8,opt,name=leader_index,json=leaderIndex,proto3" json:"leader_index,omitempty"`
+	// leader_timestamp is the wall clock time of the leader in unix milliseconds at which the leader_index was applied,
+	// the follower having applied the leader_index holds the state of the leader at that time. Zero if unknown.
+	LeaderTimestamp int64 `protobuf:"varint,9,opt,name=leader_timestamp,json=leaderTimestamp,proto3" json:"leader_timestamp,omitempty"`
 }
 
 func (x *ReplicateResponse) Reset() {
 	*x = ReplicateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[6]
+		mi := &file_replication_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -465,7 +767,7 @@ func (x *ReplicateResponse) String() string {
 func (*ReplicateResponse) ProtoMessage() {}
 
 func (x *ReplicateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[6]
+	mi := &file_replication_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +780,7 @@ func (x *ReplicateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicateResponse.ProtoReflect.Descriptor instead.
 func (*ReplicateResponse) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{6}
+	return file_replication_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *ReplicateResponse) GetResponse() isReplicateResponse_Response {
@@ -509,6 +811,13 @@ func (x *ReplicateResponse) GetLeaderIndex() uint64 {
 	return 0
 }
 
+func (x *ReplicateResponse) GetLeaderTimestamp() int64 {
+	if x != nil {
+		return x.LeaderTimestamp
+	}
+	return 0
+}
+
 type isReplicateResponse_Response interface {
 	isReplicateResponse_Response()
 }
@@ -538,7 +847,7 @@ type ReplicateCommandsResponse struct {
 func (x *ReplicateCommandsResponse) Reset() {
 	*x = ReplicateCommandsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[7]
+		mi := &file_replication_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -551,7 +860,7 @@ func (x *ReplicateCommandsResponse) String() string {
 func (*ReplicateCommandsResponse) ProtoMessage() {}
 
 func (x *ReplicateCommandsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[7]
+	mi := &file_replication_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +873,7 @@ func (x *ReplicateCommandsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicateCommandsResponse.ProtoReflect.Descriptor instead.
 func (*ReplicateCommandsResponse) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{7}
+	return file_replication_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReplicateCommandsResponse) GetCommands() []*ReplicateCommand {
@@ -588,7 +897,7 @@ type ReplicateCommand struct {
 func (x *ReplicateCommand) Reset() {
 	*x = ReplicateCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[8]
+		mi := &file_replication_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -601,7 +910,7 @@ func (x *ReplicateCommand) String() string {
 func (*ReplicateCommand) ProtoMessage() {}
 
 func (x *ReplicateCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[8]
+	mi := &file_replication_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,7 +923,7 @@ func (x *ReplicateCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicateCommand.ProtoReflect.Descriptor instead.
 func (*ReplicateCommand) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{8}
+	return file_replication_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ReplicateCommand) GetLeaderIndex() uint64 {
@@ -642,7 +951,7 @@ type ReplicateErrResponse struct {
 func (x *ReplicateErrResponse) Reset() {
 	*x = ReplicateErrResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_replication_proto_msgTypes[9]
+		mi := &file_replication_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -655,7 +964,7 @@ func (x *ReplicateErrResponse) String() string {
 func (*ReplicateErrResponse) ProtoMessage() {}
 
 func (x *ReplicateErrResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_replication_proto_msgTypes[9]
+	mi := &file_replication_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -668,7 +977,7 @@ func (x *ReplicateErrResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicateErrResponse.ProtoReflect.Descriptor instead.
 func (*ReplicateErrResponse) Descriptor() ([]byte, []int) {
-	return file_replication_proto_rawDescGZIP(), []int{9}
+	return file_replication_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ReplicateErrResponse) GetError() ReplicateError {
@@ -689,26 +998,93 @@ var file_replication_proto_rawDesc = []byte{
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x06, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x05, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x22, 0x21, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x45,
-	0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x4f,
-	0x43, 0x41, 0x4c, 0x10, 0x01, 0x22, 0x27, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x4b,
-	0x0a, 0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
-	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x03, 0x6c, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x4b, 0x0a, 0x10, 0x52,
-	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f,
-	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xeb, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x70,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0xa3, 0x01, 0x0a, 0x05, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x21, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x45, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x01, 0x22, 0xb0, 0x06, 0x0a, 0x0b,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2e, 0x0a, 0x10, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x34, 0x0a, 0x13, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x68, 0x65,
+	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x76, 0x65, 0x72, 0x68, 0x65, 0x61, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x31, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x5f,
+	0x6c, 0x6f, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02,
+	0x52, 0x0f, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x4d, 0x65, 0x6d, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x7a,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x4d, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x49, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3d,
+	0x0a, 0x19, 0x62, 0x6c, 0x6f, 0x6f, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x62,
+	0x69, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x03, 0x52, 0x15, 0x62, 0x6c, 0x6f, 0x6f, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x42, 0x69, 0x74, 0x73, 0x50, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a,
+	0x0d, 0x6d, 0x65, 0x6d, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x04, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x48,
+	0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65,
+	0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x48, 0x06, 0x52,
+	0x10, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x88, 0x01, 0x01, 0x22, 0x42, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59,
+	0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x4e,
+	0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x48, 0x45, 0x43,
+	0x4b, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x02, 0x22, 0x46, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x4d, 0x50, 0x52,
+	0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4e,
+	0x41, 0x50, 0x50, 0x59, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x5a, 0x53, 0x54, 0x44, 0x10, 0x03,
+	0x42, 0x13, 0x0a, 0x11, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x68, 0x65, 0x61, 0x64, 0x42, 0x16, 0x0a,
+	0x14, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x5f, 0x6c, 0x6f, 0x67,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x62, 0x6c, 0x6f, 0x6f, 0x6d, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x6b, 0x65, 0x79, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x65, 0x6d, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4f,
+	0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22,
+	0xa1, 0x01, 0x0a, 0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x6c, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x22, 0x92, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2d, 0x0a, 0x12, 0x68, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x96, 0x02, 0x0a, 0x11, 0x52, 0x65, 0x70,
 	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58,
 	0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
 	0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x72, 0x65, 0x70, 0x6c,
@@ -722,46 +1098,49 @@ var file_replication_proto_rawDesc = []byte{
 	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65,
 	0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x59, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x73, 0x22, 0x61, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f,
-	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
-	0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x22, 0x4c, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x72, 0x65,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x2a, 0x35, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x53, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50,
-	0x53, 0x48, 0x4f, 0x54, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x41, 0x44, 0x45, 0x52,
-	0x5f, 0x42, 0x45, 0x48, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x32, 0x54, 0x0a, 0x08, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x48, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x1f, 0x2e, 0x72,
-	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
-	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0x56, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x4a, 0x0a, 0x06, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x32, 0x59, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x52,
-	0x0a, 0x09, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x72, 0x65,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
-	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x30, 0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x72, 0x65, 0x67, 0x61, 0x74, 0x74, 0x61, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x59, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x61, 0x0a, 0x10,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22,
+	0x4c, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x35, 0x0a,
+	0x0e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x10, 0x0a, 0x0c, 0x55, 0x53, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10,
+	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x41, 0x44, 0x45, 0x52, 0x5f, 0x42, 0x45, 0x48, 0x49,
+	0x4e, 0x44, 0x10, 0x01, 0x32, 0x54, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x48, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x56, 0x0a, 0x08, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x4a, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x1f, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x30, 0x01, 0x32, 0x59, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x52, 0x0a, 0x09, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x0d, 0x5a,
+	0x0b, 0x2e, 0x2f, 0x72, 0x65, 0x67, 0x61, 0x74, 0x74, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -776,42 +1155,48 @@ func file_replication_proto_rawDescGZIP() []byte {
 	return file_replication_proto_rawDescData
 }
 
-var file_replication_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_replication_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_replication_proto_goTypes = []interface{}{
 	(ReplicateError)(0),               // 0: replication.v1.ReplicateError
 	(Table_Type)(0),                   // 1: replication.v1.Table.Type
-	(*MetadataRequest)(nil),           // 2: replication.v1.MetadataRequest
-	(*MetadataResponse)(nil),          // 3: replication.v1.MetadataResponse
-	(*Table)(nil),                     // 4: replication.v1.Table
-	(*SnapshotRequest)(nil),           // 5: replication.v1.SnapshotRequest
-	(*SnapshotChunk)(nil),             // 6: replication.v1.SnapshotChunk
-	(*ReplicateRequest)(nil),          // 7: replication.v1.ReplicateRequest
-	(*ReplicateResponse)(nil),         // 8: replication.v1.ReplicateResponse
-	(*ReplicateCommandsResponse)(nil), // 9: replication.v1.ReplicateCommandsResponse
-	(*ReplicateCommand)(nil),          // 10: replication.v1.ReplicateCommand
-	(*ReplicateErrResponse)(nil),      // 11: replication.v1.ReplicateErrResponse
-	(*Command)(nil),                   // 12: mvcc.v1.Command
+	(TableConfig_RecoveryType)(0),     // 2: replication.v1.TableConfig.RecoveryType
+	(TableConfig_Compression)(0),      // 3: replication.v1.TableConfig.Compression
+	(*MetadataRequest)(nil),           // 4: replication.v1.MetadataRequest
+	(*MetadataResponse)(nil),          // 5: replication.v1.MetadataResponse
+	(*Table)(nil),                     // 6: replication.v1.Table
+	(*TableConfig)(nil),               // 7: replication.v1.TableConfig
+	(*SnapshotRequest)(nil),           // 8: replication.v1.SnapshotRequest
+	(*SnapshotChunk)(nil),             // 9: replication.v1.SnapshotChunk
+	(*ReplicateRequest)(nil),          // 10: replication.v1.ReplicateRequest
+	(*ReplicateResponse)(nil),         // 11: replication.v1.ReplicateResponse
+	(*ReplicateCommandsResponse)(nil), // 12: replication.v1.ReplicateCommandsResponse
+	(*ReplicateCommand)(nil),          // 13: replication.v1.ReplicateCommand
+	(*ReplicateErrResponse)(nil),      // 14: replication.v1.ReplicateErrResponse
+	(*Command)(nil),                   // 15: mvcc.v1.Command
 }
 var file_replication_proto_depIdxs = []int32{
-	4,  // 0: replication.v1.MetadataResponse.tables:type_name -> replication.v1.Table
+	6,  // 0: replication.v1.MetadataResponse.tables:type_name -> replication.v1.Table
 	1,  // 1: replication.v1.Table.type:type_name -> replication.v1.Table.Type
-	9,  // 2: replication.v1.ReplicateResponse.commands_response:type_name -> replication.v1.ReplicateCommandsResponse
-	11, // 3: replication.v1.ReplicateResponse.error_response:type_name -> replication.v1.ReplicateErrResponse
-	10, // 4: replication.v1.ReplicateCommandsResponse.commands:type_name -> replication.v1.ReplicateCommand
-	12, // 5: replication.v1.ReplicateCommand.command:type_name -> mvcc.v1.Command
-	0,  // 6: replication.v1.ReplicateErrResponse.error:type_name -> replication.v1.ReplicateError
-	2,  // 7: replication.v1.Metadata.Get:input_type -> replication.v1.MetadataRequest
-	5,  // 8: replication.v1.Snapshot.Stream:input_type -> replication.v1.SnapshotRequest
-	7,  // 9: replication.v1.Log.Replicate:input_type -> replication.v1.ReplicateRequest
-	3,  // 10: replication.v1.Metadata.Get:output_type -> replication.v1.MetadataResponse
-	6,  // 11: replication.v1.Snapshot.Stream:output_type -> replication.v1.SnapshotChunk
-	8,  // 12: replication.v1.Log.Replicate:output_type -> replication.v1.ReplicateResponse
-	10, // [10:13] is the sub-list for method output_type
-	7,  // [7:10] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	7,  // 2: replication.v1.Table.config:type_name -> replication.v1.TableConfig
+	2,  // 3: replication.v1.TableConfig.recovery_type:type_name -> replication.v1.TableConfig.RecoveryType
+	3,  // 4: replication.v1.TableConfig.compression:type_name -> replication.v1.TableConfig.Compression
+	12, // 5: replication.v1.ReplicateResponse.commands_response:type_name -> replication.v1.ReplicateCommandsResponse
+	14, // 6: replication.v1.ReplicateResponse.error_response:type_name -> replication.v1.ReplicateErrResponse
+	13, // 7: replication.v1.ReplicateCommandsResponse.commands:type_name -> replication.v1.ReplicateCommand
+	15, // 8: replication.v1.ReplicateCommand.command:type_name -> mvcc.v1.Command
+	0,  // 9: replication.v1.ReplicateErrResponse.error:type_name -> replication.v1.ReplicateError
+	4,  // 10: replication.v1.Metadata.Get:input_type -> replication.v1.MetadataRequest
+	8,  // 11: replication.v1.Snapshot.Stream:input_type -> replication.v1.SnapshotRequest
+	10, // 12: replication.v1.Log.Replicate:input_type -> replication.v1.ReplicateRequest
+	5,  // 13: replication.v1.Metadata.Get:output_type -> replication.v1.MetadataResponse
+	9,  // 14: replication.v1.Snapshot.Stream:output_type -> replication.v1.SnapshotChunk
+	11, // 15: replication.v1.Log.Replicate:output_type -> replication.v1.ReplicateResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_replication_proto_init() }
@@ -858,7 +1243,7 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotRequest); i {
+			switch v := v.(*TableConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -870,7 +1255,7 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SnapshotChunk); i {
+			switch v := v.(*SnapshotRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -882,7 +1267,7 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplicateRequest); i {
+			switch v := v.(*SnapshotChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,7 +1279,7 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplicateResponse); i {
+			switch v := v.(*ReplicateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -906,7 +1291,7 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplicateCommandsResponse); i {
+			switch v := v.(*ReplicateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -918,7 +1303,7 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplicateCommand); i {
+			switch v := v.(*ReplicateCommandsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -930,6 +1315,18 @@ func file_replication_proto_init() {
 			}
 		}
 		file_replication_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicateCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_replication_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReplicateErrResponse); i {
 			case 0:
 				return &v.state
@@ -942,7 +1339,9 @@ func file_replication_proto_init() {
 			}
 		}
 	}
-	file_replication_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_replication_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_replication_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_replication_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*ReplicateResponse_CommandsResponse)(nil),
 		(*ReplicateResponse_ErrorResponse)(nil),
 	}
@@ -951,8 +1350,8 @@ func file_replication_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_replication_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   10,
+			NumEnums:      4,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
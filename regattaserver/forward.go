@@ -0,0 +1,150 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultWaitTimeout is the default maximum time the forwarded write waits for the local replica to catch up.
+const DefaultWaitTimeout = 10 * time.Second
+
+// RevisionService waits for the local replica to catch up with the leader cluster.
+type RevisionService interface {
+	WaitForRevision(ctx context.Context, table []byte, revision uint64) error
+}
+
+// ForwardingKVServer implements KV service from proto/regatta.proto for follower clusters.
+// Reads and writes to the local tables are served by the local replica while other writes are forwarded to the leader cluster.
+type ForwardingKVServer struct {
+	ReadonlyKVServer
+	// Leader the KV API client of the leader cluster.
+	Leader regattapb.KVClient
+	// Revisions if set, write responses are returned only after the local replica caught up with the revision of the write.
+	Revisions RevisionService
+	// WaitTimeout the maximum time to wait for the local replica to catch up, DefaultWaitTimeout if 0.
+	WaitTimeout time.Duration
+}
+
+// Put implements proto/regatta.proto KV.Put method.
+func (f *ForwardingKVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+	if f.isLocalTable(req.Table) {
+		return f.ReadonlyKVServer.Put(ctx, req)
+	}
+	resp, err := f.Leader.Put(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.waitFor(ctx, req.Table, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRange implements proto/regatta.proto KV.DeleteRange method.
+func (f *ForwardingKVServer) DeleteRange(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+	if f.isLocalTable(req.Table) {
+		return f.ReadonlyKVServer.DeleteRange(ctx, req)
+	}
+	resp, err := f.Leader.DeleteRange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.waitFor(ctx, req.Table, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Increment implements proto/regatta.proto KV.Increment method.
+func (f *ForwardingKVServer) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	if f.isLocalTable(req.Table) {
+		return f.ReadonlyKVServer.Increment(ctx, req)
+	}
+	resp, err := f.Leader.Increment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.waitFor(ctx, req.Table, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PutBatch implements proto/regatta.proto KV.PutBatch method.
+func (f *ForwardingKVServer) PutBatch(ctx context.Context, req *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error) {
+	if f.isLocalTable(req.Table) {
+		return f.ReadonlyKVServer.PutBatch(ctx, req)
+	}
+	resp, err := f.Leader.PutBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.waitFor(ctx, req.Table, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteBatch implements proto/regatta.proto KV.DeleteBatch method.
+func (f *ForwardingKVServer) DeleteBatch(ctx context.Context, req *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error) {
+	if f.isLocalTable(req.Table) {
+		return f.ReadonlyKVServer.DeleteBatch(ctx, req)
+	}
+	resp, err := f.Leader.DeleteBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.waitFor(ctx, req.Table, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Txn processes multiple requests in a single transaction.
+// Readonly transactions and transactions on the local tables are served by the local replica, the rest is forwarded to the leader cluster.
+func (f *ForwardingKVServer) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
+	if isReadonlyTransaction(req) || f.isLocalTable(req.Table) {
+		return f.KVServer.Txn(ctx, req)
+	}
+	resp, err := f.Leader.Txn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.waitFor(ctx, req.Table, resp.Header); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// waitFor waits until the local replica of the table replicated the revision from the header.
+// Unavailable is returned if the table is not replicated to this cluster, local tables are never forwarded.
+func (f *ForwardingKVServer) waitFor(ctx context.Context, table []byte, header *regattapb.ResponseHeader) error {
+	if f.Revisions == nil || header == nil {
+		return nil
+	}
+	timeout := f.WaitTimeout
+	if timeout == 0 {
+		timeout = DefaultWaitTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := f.Revisions.WaitForRevision(ctx, table, header.Revision); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			// The table is excluded from the replication or not created in this cluster yet.
+			return status.Errorf(codes.Unavailable, "table '%s' is not replicated to this cluster, the write was applied in the leader cluster at the revision %d", table, header.Revision)
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return status.FromContextError(err).Err()
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
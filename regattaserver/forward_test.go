@@ -0,0 +1,208 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockLeaderKV records forwarded requests and responds with a header of the configured revision.
+type mockLeaderKV struct {
+	regattapb.KVClient
+	revision  uint64
+	err       error
+	forwarded []any
+}
+
+func (m *mockLeaderKV) header() *regattapb.ResponseHeader {
+	return &regattapb.ResponseHeader{Revision: m.revision}
+}
+
+func (m *mockLeaderKV) Put(_ context.Context, in *regattapb.PutRequest, _ ...grpc.CallOption) (*regattapb.PutResponse, error) {
+	m.forwarded = append(m.forwarded, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &regattapb.PutResponse{Header: m.header()}, nil
+}
+
+func (m *mockLeaderKV) DeleteRange(_ context.Context, in *regattapb.DeleteRangeRequest, _ ...grpc.CallOption) (*regattapb.DeleteRangeResponse, error) {
+	m.forwarded = append(m.forwarded, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &regattapb.DeleteRangeResponse{Header: m.header(), Deleted: 1}, nil
+}
+
+func (m *mockLeaderKV) Txn(_ context.Context, in *regattapb.TxnRequest, _ ...grpc.CallOption) (*regattapb.TxnResponse, error) {
+	m.forwarded = append(m.forwarded, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &regattapb.TxnResponse{Header: m.header(), Succeeded: true}, nil
+}
+
+func (m *mockLeaderKV) Increment(_ context.Context, in *regattapb.IncrementRequest, _ ...grpc.CallOption) (*regattapb.IncrementResponse, error) {
+	m.forwarded = append(m.forwarded, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &regattapb.IncrementResponse{Header: m.header(), Value: in.Delta, Applied: true}, nil
+}
+
+func (m *mockLeaderKV) PutBatch(_ context.Context, in *regattapb.PutBatchRequest, _ ...grpc.CallOption) (*regattapb.PutBatchResponse, error) {
+	m.forwarded = append(m.forwarded, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &regattapb.PutBatchResponse{Header: m.header()}, nil
+}
+
+func (m *mockLeaderKV) DeleteBatch(_ context.Context, in *regattapb.DeleteBatchRequest, _ ...grpc.CallOption) (*regattapb.DeleteBatchResponse, error) {
+	m.forwarded = append(m.forwarded, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &regattapb.DeleteBatchResponse{Header: m.header()}, nil
+}
+
+// mockRevisions records the awaited revisions.
+type mockRevisions struct {
+	err     error
+	block   bool
+	awaited []uint64
+}
+
+func (m *mockRevisions) WaitForRevision(ctx context.Context, _ []byte, revision uint64) error {
+	m.awaited = append(m.awaited, revision)
+	if m.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return m.err
+}
+
+func TestForwardingKVServer_Forward(t *testing.T) {
+	r := require.New(t)
+	leader := &mockLeaderKV{revision: 10}
+	kv := ForwardingKVServer{
+		ReadonlyKVServer: ReadonlyKVServer{KVServer: KVServer{Storage: &MockStorage{}}},
+		Leader:           leader,
+	}
+
+	put := &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: table1Value1}
+	putResp, err := kv.Put(context.Background(), put)
+	r.NoError(err)
+	r.Equal(uint64(10), putResp.Header.Revision)
+
+	del := &regattapb.DeleteRangeRequest{Table: table1Name, Key: key1Name}
+	delResp, err := kv.DeleteRange(context.Background(), del)
+	r.NoError(err)
+	r.Equal(int64(1), delResp.Deleted)
+
+	inc := &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 5}
+	incResp, err := kv.Increment(context.Background(), inc)
+	r.NoError(err)
+	r.Equal(int64(5), incResp.Value)
+
+	putBatch := &regattapb.PutBatchRequest{Table: table1Name, Kvs: []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}}}
+	_, err = kv.PutBatch(context.Background(), putBatch)
+	r.NoError(err)
+
+	delBatch := &regattapb.DeleteBatchRequest{Table: table1Name, Keys: [][]byte{key1Name}}
+	_, err = kv.DeleteBatch(context.Background(), delBatch)
+	r.NoError(err)
+
+	txn := &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name}}},
+		},
+	}
+	txnResp, err := kv.Txn(context.Background(), txn)
+	r.NoError(err)
+	r.True(txnResp.Succeeded)
+
+	t.Log("Readonly Txn is not forwarded")
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key1Name}}},
+		},
+	})
+	r.NoError(err)
+
+	r.Equal([]any{put, del, inc, putBatch, delBatch, txn}, leader.forwarded)
+}
+
+func TestForwardingKVServer_LocalTable(t *testing.T) {
+	r := require.New(t)
+	leader := &mockLeaderKV{revision: 10}
+	kv := ForwardingKVServer{
+		ReadonlyKVServer: ReadonlyKVServer{
+			KVServer: KVServer{Storage: &MockStorage{putResponse: regattapb.PutResponse{Header: &regattapb.ResponseHeader{Revision: 2}}}},
+			Tables:   MockTableService{tables: []table.Table{{Name: string(table1Name), Local: true}}},
+		},
+		Leader: leader,
+	}
+
+	resp, err := kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: table1Value1})
+	r.NoError(err)
+	r.Equal(uint64(2), resp.Header.Revision)
+	r.Empty(leader.forwarded)
+}
+
+func TestForwardingKVServer_LeaderError(t *testing.T) {
+	r := require.New(t)
+	leaderErr := status.Error(codes.DeadlineExceeded, "context deadline exceeded")
+	revisions := &mockRevisions{}
+	kv := ForwardingKVServer{
+		ReadonlyKVServer: ReadonlyKVServer{KVServer: KVServer{Storage: &MockStorage{}}},
+		Leader:           &mockLeaderKV{err: leaderErr},
+		Revisions:        revisions,
+	}
+
+	_, err := kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.Equal(leaderErr, err)
+	r.Empty(revisions.awaited)
+}
+
+func TestForwardingKVServer_WaitForRevision(t *testing.T) {
+	r := require.New(t)
+	revisions := &mockRevisions{}
+	kv := ForwardingKVServer{
+		ReadonlyKVServer: ReadonlyKVServer{KVServer: KVServer{Storage: &MockStorage{}}},
+		Leader:           &mockLeaderKV{revision: 10},
+		Revisions:        revisions,
+	}
+
+	_, err := kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.NoError(err)
+	r.Equal([]uint64{10}, revisions.awaited)
+
+	t.Log("Replication did not catch up in time")
+	revisions.err = context.DeadlineExceeded
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error()).Error())
+
+	t.Log("Table not replicated")
+	revisions.err = errors.ErrTableNotFound
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.EqualError(err, status.Errorf(codes.Unavailable, "table '%s' is not replicated to this cluster, the write was applied in the leader cluster at the revision 10", table1Name).Error())
+
+	t.Log("Wait is bounded without the request deadline")
+	revisions.err, revisions.block = nil, true
+	kv.WaitTimeout = 50 * time.Millisecond
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error()).Error())
+}
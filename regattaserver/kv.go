@@ -19,29 +19,9 @@ type KVServer struct {
 }
 
 // Range implements proto/regatta.proto KV.Range method.
-// Currently, only subset of functionality is implemented.
-// The versioning functionality is not available.
 func (s *KVServer) Range(ctx context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error) {
-	if req.GetLimit() < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "limit must be a positive number")
-	} else if req.GetKeysOnly() && req.GetCountOnly() {
-		return nil, status.Error(codes.InvalidArgument, "keys_only and count_only must not be set at the same time")
-	} else if req.GetMinModRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "min_mod_revision not implemented")
-	} else if req.GetMaxModRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "max_mod_revision not implemented")
-	} else if req.GetMinCreateRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "min_create_revision not implemented")
-	} else if req.GetMaxCreateRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "max_create_revision not implemented")
-	}
-
-	if len(req.GetTable()) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "table must be set")
-	}
-
-	if len(req.GetKey()) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "key must be set")
+	if err := validateRangeRequest(req); err != nil {
+		return nil, err
 	}
 
 	val, err := s.Storage.Range(ctx, req)
@@ -49,11 +29,60 @@ func (s *KVServer) Range(ctx context.Context, req *regattapb.RangeRequest) (*reg
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return val, nil
 }
 
+// Cursor implements proto/regatta.proto KV.Cursor method.
+func (s *KVServer) Cursor(req *regattapb.RangeRequest, srv regattapb.KV_CursorServer) error {
+	if err := validateRangeRequest(req); err != nil {
+		return err
+	}
+	if req.GetSortTarget() != regattapb.RequestOp_Range_KEY {
+		return status.Error(codes.InvalidArgument, "cursor supports sorting by key only")
+	}
+
+	err := s.Storage.Cursor(srv.Context(), req, srv.Send)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return status.FromContextError(err).Err()
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func validateRangeRequest(req *regattapb.RangeRequest) error {
+	if req.GetLimit() < 0 {
+		return status.Errorf(codes.InvalidArgument, "limit must be a positive number")
+	} else if req.GetKeysOnly() && req.GetCountOnly() {
+		return status.Error(codes.InvalidArgument, "keys_only and count_only must not be set at the same time")
+	} else if req.GetMinModRevision() < 0 || req.GetMaxModRevision() < 0 || req.GetMinCreateRevision() < 0 || req.GetMaxCreateRevision() < 0 {
+		return status.Error(codes.InvalidArgument, "revision filters must be positive numbers")
+	} else if req.GetRevision() < 0 {
+		return status.Error(codes.InvalidArgument, "revision must be a positive number")
+	}
+
+	if len(req.GetTable()) == 0 {
+		return status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return status.Error(codes.InvalidArgument, "key must be set")
+	}
+	return nil
+}
+
 // Put implements proto/regatta.proto KV.Put method.
 func (s *KVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
 	if len(req.GetTable()) == 0 {
@@ -69,6 +98,9 @@ func (s *KVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regatta
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return nil, status.Error(codes.NotFound, "lease not found")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return r, nil
@@ -94,6 +126,79 @@ func (s *KVServer) DeleteRange(ctx context.Context, req *regattapb.DeleteRangeRe
 	return r, nil
 }
 
+// Increment implements proto/regatta.proto KV.Increment method.
+func (s *KVServer) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "key must be set")
+	}
+
+	if req.Min != nil && req.Max != nil && req.GetMin() > req.GetMax() {
+		return nil, status.Errorf(codes.InvalidArgument, "min must not be greater than max")
+	}
+
+	r, err := s.Storage.Increment(ctx, req)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrNotCounter) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return r, nil
+}
+
+// PutBatch implements proto/regatta.proto KV.PutBatch method.
+func (s *KVServer) PutBatch(ctx context.Context, req *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKvs()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "kvs must be set")
+	}
+
+	r, err := s.Storage.PutBatch(ctx, req)
+	if err != nil {
+		return nil, batchError(err)
+	}
+	return r, nil
+}
+
+// DeleteBatch implements proto/regatta.proto KV.DeleteBatch method.
+func (s *KVServer) DeleteBatch(ctx context.Context, req *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKeys()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "keys must be set")
+	}
+
+	r, err := s.Storage.DeleteBatch(ctx, req)
+	if err != nil {
+		return nil, batchError(err)
+	}
+	return r, nil
+}
+
+func batchError(err error) error {
+	switch {
+	case errors.Is(err, serrors.ErrTableNotFound):
+		return status.Error(codes.NotFound, "table not found")
+	case errors.Is(err, serrors.ErrEmptyKey), errors.Is(err, serrors.ErrKeyLengthExceeded), errors.Is(err, serrors.ErrValueLengthExceeded):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, serrors.ErrBatchSizeExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 // Txn processes multiple requests in a single transaction.
 // A txn request increments the revision of the key-value store
 // and generates events with the same revision for every completed request.
@@ -108,47 +213,106 @@ func (s *KVServer) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regatta
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return nil, status.Error(codes.NotFound, "lease not found")
+		}
+		if errors.Is(err, serrors.ErrTxnTooDeep) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, serrors.ErrNotCounter) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return r, nil
 }
 
 // ReadonlyKVServer implements read part of KV service from proto/regatta.proto.
+// Writes are allowed only to the tables local to the follower cluster.
 type ReadonlyKVServer struct {
 	KVServer
+	// Tables if set, the writes to the local tables are served.
+	Tables TableService
 }
 
 // Put implements proto/regatta.proto KV.Put method.
-func (r *ReadonlyKVServer) Put(_ context.Context, _ *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+func (r *ReadonlyKVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+	if r.isLocalTable(req.Table) {
+		return r.KVServer.Put(ctx, req)
+	}
 	return nil, status.Error(codes.Unimplemented, "method Put not implemented for follower")
 }
 
 // DeleteRange implements proto/regatta.proto KV.DeleteRange method.
-func (r *ReadonlyKVServer) DeleteRange(_ context.Context, _ *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+func (r *ReadonlyKVServer) DeleteRange(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+	if r.isLocalTable(req.Table) {
+		return r.KVServer.DeleteRange(ctx, req)
+	}
 	return nil, status.Error(codes.Unimplemented, "method DeleteRange not implemented for follower")
 }
 
+// Increment implements proto/regatta.proto KV.Increment method.
+func (r *ReadonlyKVServer) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	if r.isLocalTable(req.Table) {
+		return r.KVServer.Increment(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method Increment not implemented for follower")
+}
+
+// PutBatch implements proto/regatta.proto KV.PutBatch method.
+func (r *ReadonlyKVServer) PutBatch(ctx context.Context, req *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error) {
+	if r.isLocalTable(req.Table) {
+		return r.KVServer.PutBatch(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method PutBatch not implemented for follower")
+}
+
+// DeleteBatch implements proto/regatta.proto KV.DeleteBatch method.
+func (r *ReadonlyKVServer) DeleteBatch(ctx context.Context, req *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error) {
+	if r.isLocalTable(req.Table) {
+		return r.KVServer.DeleteBatch(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method DeleteBatch not implemented for follower")
+}
+
 // Txn processes multiple requests in a single transaction.
 // A txn request increments the revision of the key-value store
 // and generates events with the same revision for every completed request.
 // It is allowed to modify the same key several times within one txn (the result will be the last Op that modified the key).
 // Readonly transactions allowed using follower API.
 func (r *ReadonlyKVServer) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
-	if isReadonlyTransaction(req) {
+	if isReadonlyTransaction(req) || r.isLocalTable(req.Table) {
 		return r.KVServer.Txn(ctx, req)
 	}
 	return nil, status.Error(codes.Unimplemented, "writable Txn not implemented for follower")
 }
 
-func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
-	for _, op := range req.Success {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
-			return false
-		}
+// isLocalTable reports whether the table is local to the follower cluster.
+func (r *ReadonlyKVServer) isLocalTable(name []byte) bool {
+	if r.Tables == nil || len(name) == 0 {
+		return false
 	}
+	t, err := r.Tables.GetTable(string(name))
+	return err == nil && t.Local
+}
+
+func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
+	return isReadonlyOps(req.Success) && isReadonlyOps(req.Failure)
+}
 
-	for _, op := range req.Failure {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
+// isReadonlyOps reports whether the ops are only ranges or read-only nested transactions.
+func isReadonlyOps(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+		case *regattapb.RequestOp_RequestTxn:
+			if !isReadonlyOps(o.RequestTxn.Success) || !isReadonlyOps(o.RequestTxn.Failure) {
+				return false
+			}
+		default:
 			return false
 		}
 	}
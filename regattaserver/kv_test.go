@@ -8,8 +8,9 @@ import (
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/errors"
-	"github.com/stretchr/testify/assert"
+	"github.com/jamf/regatta/storage/table"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -357,43 +358,133 @@ func TestKVServer_RangeInvalidArgument(t *testing.T) {
 	r.EqualError(err, status.Errorf(codes.InvalidArgument, "keys_only and count_only must not be set at the same time").Error())
 }
 
-func TestKVServer_RangeUnimplemented(t *testing.T) {
-	a := assert.New(t)
+func TestKVServer_RangeRevisionFilters(t *testing.T) {
+	r := require.New(t)
 	kv := KVServer{
-		Storage: &MockStorage{},
+		Storage: &MockStorage{rangeResponse: regattapb.RangeResponse{Count: 1}},
 	}
 
-	t.Log("Get kv with unimplemented min_mod_revision")
+	t.Log("Get kv with negative min_mod_revision")
 	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{
 		Table:          table1Name,
 		Key:            key1Name,
-		MinModRevision: 1,
+		MinModRevision: -1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "min_mod_revision not implemented").Error())
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "revision filters must be positive numbers").Error())
 
-	t.Log("Get kv with unimplemented max_mod_revision")
+	t.Log("Get kv with negative max_create_revision")
 	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
-		Table:          table1Name,
-		Key:            key1Name,
-		MaxModRevision: 1,
+		Table:             table1Name,
+		Key:               key1Name,
+		MaxCreateRevision: -1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "max_mod_revision not implemented").Error())
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "revision filters must be positive numbers").Error())
 
-	t.Log("Get kv with unimplemented min_create_revision")
-	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
+	t.Log("Get kv with revision filters")
+	res, err := kv.Range(context.Background(), &regattapb.RangeRequest{
 		Table:             table1Name,
 		Key:               key1Name,
+		MinModRevision:    1,
+		MaxModRevision:    2,
 		MinCreateRevision: 1,
+		MaxCreateRevision: 2,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "min_create_revision not implemented").Error())
+	r.NoError(err)
+	r.Equal(int64(1), res.Count)
+}
 
-	t.Log("Get kv with unimplemented max_create_revision")
+func TestKVServer_RangeAtRevision(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{rangeResponse: regattapb.RangeResponse{Count: 1}},
+	}
+
+	t.Log("Get kv with negative revision")
+	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{
+		Table:    table1Name,
+		Key:      key1Name,
+		Revision: -1,
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "revision must be a positive number").Error())
+
+	t.Log("Get kv at revision")
+	res, err := kv.Range(context.Background(), &regattapb.RangeRequest{
+		Table:    table1Name,
+		Key:      key1Name,
+		Revision: 1,
+	})
+	r.NoError(err)
+	r.Equal(int64(1), res.Count)
+
+	t.Log("Get kv at compacted revision")
+	kv.Storage = &MockStorage{rangeError: errors.ErrCompacted}
 	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
-		Table:             table1Name,
-		Key:               key1Name,
-		MaxCreateRevision: 1,
+		Table:    table1Name,
+		Key:      key1Name,
+		Revision: 1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "max_create_revision not implemented").Error())
+	r.EqualError(err, status.Error(codes.OutOfRange, errors.ErrCompacted.Error()).Error())
+
+	t.Log("Get kv at future revision")
+	kv.Storage = &MockStorage{rangeError: errors.ErrFutureRevision}
+	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
+		Table:    table1Name,
+		Key:      key1Name,
+		Revision: 100,
+	})
+	r.EqualError(err, status.Error(codes.OutOfRange, errors.ErrFutureRevision.Error()).Error())
+}
+
+type mockCursorStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*regattapb.RangeResponse
+}
+
+func (m *mockCursorStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockCursorStream) Send(resp *regattapb.RangeResponse) error {
+	m.sent = append(m.sent, resp)
+	return nil
+}
+
+func TestKVServer_Cursor(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{rangeResponse: regattapb.RangeResponse{Kvs: []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}}, Count: 1}},
+	}
+
+	t.Log("Cursor with empty table name")
+	stream := &mockCursorStream{ctx: context.Background()}
+	err := kv.Cursor(&regattapb.RangeRequest{Key: key1Name}, stream)
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Cursor with both keys_only and count_only set")
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, KeysOnly: true, CountOnly: true}, stream)
+	r.EqualError(err, status.Error(codes.InvalidArgument, "keys_only and count_only must not be set at the same time").Error())
+
+	t.Log("Cursor sorted by value")
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, SortTarget: regattapb.RequestOp_Range_VALUE}, stream)
+	r.EqualError(err, status.Error(codes.InvalidArgument, "cursor supports sorting by key only").Error())
+
+	t.Log("Cursor existing range")
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, RangeEnd: key3Name}, stream)
+	r.NoError(err)
+	r.Len(stream.sent, 1)
+	r.Equal(int64(1), stream.sent[0].Count)
+	r.Equal(key1Name, stream.sent[0].Kvs[0].Key)
+
+	t.Log("Cursor non-existent table")
+	kv = KVServer{Storage: &MockStorage{rangeError: errors.ErrTableNotFound}}
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name}, stream)
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+
+	t.Log("Cursor canceled by the client")
+	kv = KVServer{Storage: &MockStorage{rangeError: context.Canceled}}
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name}, stream)
+	r.Equal(codes.Canceled, status.Code(err))
 }
 
 func TestKVServer_PutInvalidArgument(t *testing.T) {
@@ -426,6 +517,16 @@ func TestKVServer_PutInvalidArgument(t *testing.T) {
 		Value: table1Value1,
 	})
 	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+
+	t.Log("Put with non-existing lease")
+	kv.Storage = &MockStorage{putError: errors.ErrLeaseNotFound}
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{
+		Table: table1Name,
+		Key:   key1Name,
+		Value: table1Value1,
+		Lease: 1,
+	})
+	r.EqualError(err, status.Errorf(codes.NotFound, "lease not found").Error())
 }
 
 func TestKVServer_DeleteRangeInvalidArgument(t *testing.T) {
@@ -473,6 +574,116 @@ func TestKVServer_DeleteRange(t *testing.T) {
 	r.Equal(int64(1), drresp.GetDeleted())
 }
 
+func TestKVServer_Increment(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{incrementResponse: regattapb.IncrementResponse{Value: 2, Applied: true}},
+	}
+
+	t.Log("Increment with empty table name")
+	_, err := kv.Increment(context.Background(), &regattapb.IncrementRequest{Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Increment with empty key name")
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
+
+	t.Log("Increment with inverted bounds")
+	minimum, maximum := int64(10), int64(0)
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1, Min: &minimum, Max: &maximum})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "min must not be greater than max").Error())
+
+	t.Log("Increment")
+	res, err := kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.NoError(err)
+	r.Equal(int64(2), res.GetValue())
+	r.True(res.GetApplied())
+
+	t.Log("Increment in non-existing table")
+	kv.Storage = &MockStorage{putError: errors.ErrTableNotFound}
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: []byte("non_existing_table"), Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+
+	t.Log("Increment of a key not holding a counter")
+	kv.Storage = &MockStorage{putError: errors.ErrNotCounter}
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Error(codes.FailedPrecondition, errors.ErrNotCounter.Error()).Error())
+}
+
+func TestKVServer_PutBatch(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{putBatchResponse: regattapb.PutBatchResponse{Header: &regattapb.ResponseHeader{Revision: 5}}},
+	}
+
+	t.Log("Put batch with empty table name")
+	_, err := kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Kvs: []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}},
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Put empty batch")
+	_, err = kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{Table: table1Name})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "kvs must be set").Error())
+
+	t.Log("Put batch")
+	res, err := kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: table1Name,
+		Kvs:   []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}, {Key: key2Name, Value: table1Value2}},
+	})
+	r.NoError(err)
+	r.Equal(uint64(5), res.GetHeader().GetRevision())
+
+	t.Log("Put batch exceeding the size limit")
+	kv.Storage = &MockStorage{putError: errors.ErrBatchSizeExceeded}
+	_, err = kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: table1Name,
+		Kvs:   []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}},
+	})
+	r.EqualError(err, status.Error(codes.ResourceExhausted, errors.ErrBatchSizeExceeded.Error()).Error())
+
+	t.Log("Put batch with too long key")
+	kv.Storage = &MockStorage{putError: errors.ErrKeyLengthExceeded}
+	_, err = kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: table1Name,
+		Kvs:   []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}},
+	})
+	r.EqualError(err, status.Error(codes.InvalidArgument, errors.ErrKeyLengthExceeded.Error()).Error())
+
+	t.Log("Put batch into non-existing table")
+	kv.Storage = &MockStorage{putError: errors.ErrTableNotFound}
+	_, err = kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte("non_existing_table"),
+		Kvs:   []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}},
+	})
+	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+}
+
+func TestKVServer_DeleteBatch(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{deleteBatchResponse: regattapb.DeleteBatchResponse{Header: &regattapb.ResponseHeader{Revision: 6}}},
+	}
+
+	t.Log("Delete batch with empty table name")
+	_, err := kv.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{Keys: [][]byte{key1Name}})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Delete empty batch")
+	_, err = kv.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{Table: table1Name})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "keys must be set").Error())
+
+	t.Log("Delete batch")
+	res, err := kv.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{Table: table1Name, Keys: [][]byte{key1Name, key2Name}})
+	r.NoError(err)
+	r.Equal(uint64(6), res.GetHeader().GetRevision())
+
+	t.Log("Delete batch from non-existing table")
+	kv.Storage = &MockStorage{deleteError: errors.ErrTableNotFound}
+	_, err = kv.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{Table: []byte("non_existing_table"), Keys: [][]byte{key1Name}})
+	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+}
+
 func TestReadonlyKVServer_Put(t *testing.T) {
 	r := require.New(t)
 	kv := ReadonlyKVServer{
@@ -489,6 +700,39 @@ func TestReadonlyKVServer_Put(t *testing.T) {
 	r.EqualError(err, status.Errorf(codes.Unimplemented, "method Put not implemented for follower").Error())
 }
 
+func TestReadonlyKVServer_Batch(t *testing.T) {
+	r := require.New(t)
+	kv := ReadonlyKVServer{
+		KVServer: KVServer{
+			Storage: &MockStorage{},
+		},
+	}
+
+	_, err := kv.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: table1Name,
+		Kvs:   []*regattapb.KeyValue{{Key: key1Name}},
+	})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method PutBatch not implemented for follower").Error())
+
+	_, err = kv.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{
+		Table: table1Name,
+		Keys:  [][]byte{key1Name},
+	})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method DeleteBatch not implemented for follower").Error())
+}
+
+func TestReadonlyKVServer_Increment(t *testing.T) {
+	r := require.New(t)
+	kv := ReadonlyKVServer{
+		KVServer: KVServer{
+			Storage: &MockStorage{},
+		},
+	}
+
+	_, err := kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method Increment not implemented for follower").Error())
+}
+
 func TestReadonlyKVServer_DeleteRange(t *testing.T) {
 	r := require.New(t)
 	kv := ReadonlyKVServer{
@@ -505,6 +749,35 @@ func TestReadonlyKVServer_DeleteRange(t *testing.T) {
 	r.EqualError(err, status.Errorf(codes.Unimplemented, "method DeleteRange not implemented for follower").Error())
 }
 
+func TestReadonlyKVServer_LocalTable(t *testing.T) {
+	r := require.New(t)
+	kv := ReadonlyKVServer{
+		KVServer: KVServer{
+			Storage: &MockStorage{},
+		},
+		Tables: MockTableService{tables: []table.Table{{Name: string(table1Name), Local: true}}},
+	}
+
+	_, err := kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: table1Value1})
+	r.NoError(err)
+	_, err = kv.DeleteRange(context.Background(), &regattapb.DeleteRangeRequest{Table: table1Name, Key: key1Name})
+	r.NoError(err)
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.NoError(err)
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name}}},
+		},
+	})
+	r.NoError(err)
+
+	t.Log("Replicated table")
+	kv.Tables = MockTableService{tables: []table.Table{{Name: string(table1Name)}}}
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: table1Value1})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method Put not implemented for follower").Error())
+}
+
 func TestReadonlyKVServer_Txn(t *testing.T) {
 	r := require.New(t)
 	kv := ReadonlyKVServer{
@@ -537,4 +810,41 @@ func TestReadonlyKVServer_Txn(t *testing.T) {
 		},
 	})
 	r.NoError(err)
+	t.Log("Writable nested Txn")
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Failure: []*regattapb.RequestOp{
+			{
+				Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{
+					Success: []*regattapb.RequestOp{
+						{
+							Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{
+								Key: key1Name,
+							}},
+						},
+					},
+				}},
+			},
+		},
+	})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "writable Txn not implemented for follower").Error())
+
+	t.Log("Readonly nested Txn")
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Failure: []*regattapb.RequestOp{
+			{
+				Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{
+					Success: []*regattapb.RequestOp{
+						{
+							Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{
+								Key: key1Name,
+							}},
+						},
+					},
+				}},
+			},
+		},
+	})
+	r.NoError(err)
 }
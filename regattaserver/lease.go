@@ -0,0 +1,127 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LeaseServer implements Lease service from proto/regatta.proto.
+type LeaseServer struct {
+	regattapb.UnimplementedLeaseServer
+	Storage LeaseService
+}
+
+// Grant implements proto/regatta.proto Lease.Grant method.
+func (s *LeaseServer) Grant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+	if req.GetTtl() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl must be a positive number")
+	}
+	if req.GetId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be a positive number")
+	}
+
+	r, err := s.Storage.LeaseGrant(ctx, req)
+	if err != nil {
+		return nil, leaseError(err)
+	}
+	return r, nil
+}
+
+// Revoke implements proto/regatta.proto Lease.Revoke method.
+func (s *LeaseServer) Revoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	r, err := s.Storage.LeaseRevoke(ctx, req)
+	if err != nil {
+		return nil, leaseError(err)
+	}
+	return r, nil
+}
+
+// KeepAlive implements proto/regatta.proto Lease.KeepAlive method.
+func (s *LeaseServer) KeepAlive(srv regattapb.Lease_KeepAliveServer) error {
+	for {
+		req, err := srv.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(req.GetTable()) == 0 {
+			return status.Error(codes.InvalidArgument, "table must be set")
+		}
+
+		r, err := s.Storage.LeaseKeepAlive(srv.Context(), req)
+		if err != nil {
+			// Lease not found is reported in band the same way etcd does, by the response with a non-positive TTL.
+			if !errors.Is(err, serrors.ErrLeaseNotFound) {
+				return leaseError(err)
+			}
+			r = &regattapb.LeaseKeepAliveResponse{Id: req.GetId()}
+		}
+		if err := srv.Send(r); err != nil {
+			return err
+		}
+	}
+}
+
+// TimeToLive implements proto/regatta.proto Lease.TimeToLive method.
+func (s *LeaseServer) TimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	r, err := s.Storage.LeaseTimeToLive(ctx, req)
+	if err != nil {
+		return nil, leaseError(err)
+	}
+	return r, nil
+}
+
+// ReadonlyLeaseServer implements read part of Lease service from proto/regatta.proto.
+type ReadonlyLeaseServer struct {
+	LeaseServer
+}
+
+// Grant implements proto/regatta.proto Lease.Grant method.
+func (r *ReadonlyLeaseServer) Grant(_ context.Context, _ *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Grant not implemented for follower")
+}
+
+// Revoke implements proto/regatta.proto Lease.Revoke method.
+func (r *ReadonlyLeaseServer) Revoke(_ context.Context, _ *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Revoke not implemented for follower")
+}
+
+// KeepAlive implements proto/regatta.proto Lease.KeepAlive method.
+func (r *ReadonlyLeaseServer) KeepAlive(_ regattapb.Lease_KeepAliveServer) error {
+	return status.Error(codes.Unimplemented, "method KeepAlive not implemented for follower")
+}
+
+func leaseError(err error) error {
+	switch {
+	case errors.Is(err, serrors.ErrTableNotFound):
+		return status.Error(codes.NotFound, "table not found")
+	case errors.Is(err, serrors.ErrLeaseNotFound):
+		return status.Error(codes.NotFound, "lease not found")
+	case errors.Is(err, serrors.ErrLeaseExists):
+		return status.Error(codes.AlreadyExists, "lease already exists")
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
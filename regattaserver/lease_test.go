@@ -0,0 +1,189 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockLeaseService keeps the granted leases in memory, the leases never expire.
+type mockLeaseService struct {
+	leases map[int64]int64
+}
+
+func (m *mockLeaseService) LeaseGrant(_ context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	if string(req.Table) != string(table1Name) {
+		return nil, serrors.ErrTableNotFound
+	}
+	id := req.Id
+	if id == 0 {
+		id = int64(len(m.leases) + 1)
+	}
+	if _, ok := m.leases[id]; ok {
+		return nil, serrors.ErrLeaseExists
+	}
+	m.leases[id] = req.Ttl
+	return &regattapb.LeaseGrantResponse{Header: &regattapb.ResponseHeader{}, Id: id, Ttl: req.Ttl}, nil
+}
+
+func (m *mockLeaseService) LeaseRevoke(_ context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	if _, ok := m.leases[req.Id]; !ok {
+		return nil, serrors.ErrLeaseNotFound
+	}
+	delete(m.leases, req.Id)
+	return &regattapb.LeaseRevokeResponse{Header: &regattapb.ResponseHeader{}}, nil
+}
+
+func (m *mockLeaseService) LeaseKeepAlive(_ context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error) {
+	ttl, ok := m.leases[req.Id]
+	if !ok {
+		return nil, serrors.ErrLeaseNotFound
+	}
+	return &regattapb.LeaseKeepAliveResponse{Header: &regattapb.ResponseHeader{}, Id: req.Id, Ttl: ttl}, nil
+}
+
+func (m *mockLeaseService) LeaseTimeToLive(_ context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	ttl, ok := m.leases[req.Id]
+	if !ok {
+		return nil, serrors.ErrLeaseNotFound
+	}
+	return &regattapb.LeaseTimeToLiveResponse{Header: &regattapb.ResponseHeader{}, Id: req.Id, Ttl: ttl, GrantedTtl: ttl}, nil
+}
+
+type mockKeepAliveStream struct {
+	grpc.ServerStream
+	requests  []*regattapb.LeaseKeepAliveRequest
+	responses []*regattapb.LeaseKeepAliveResponse
+}
+
+func (m *mockKeepAliveStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockKeepAliveStream) Send(resp *regattapb.LeaseKeepAliveResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+func (m *mockKeepAliveStream) Recv() (*regattapb.LeaseKeepAliveRequest, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func TestLeaseServer_Grant(t *testing.T) {
+	r := require.New(t)
+	ls := LeaseServer{Storage: &mockLeaseService{leases: map[int64]int64{}}}
+
+	t.Log("Grant with empty table name")
+	_, err := ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Ttl: 10})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Grant with non-positive TTL")
+	_, err = ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Table: table1Name})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "ttl must be a positive number").Error())
+
+	t.Log("Grant with negative ID")
+	_, err = ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Table: table1Name, Ttl: 10, Id: -1})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "id must be a positive number").Error())
+
+	t.Log("Grant in non-existent table")
+	_, err = ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Table: []byte("missing"), Ttl: 10})
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+
+	t.Log("Grant lease")
+	resp, err := ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Table: table1Name, Ttl: 10})
+	r.NoError(err)
+	r.Equal(int64(1), resp.Id)
+	r.Equal(int64(10), resp.Ttl)
+
+	t.Log("Grant existing lease")
+	_, err = ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Table: table1Name, Ttl: 10, Id: 1})
+	r.EqualError(err, status.Error(codes.AlreadyExists, "lease already exists").Error())
+}
+
+func TestLeaseServer_Revoke(t *testing.T) {
+	r := require.New(t)
+	ls := LeaseServer{Storage: &mockLeaseService{leases: map[int64]int64{1: 10}}}
+
+	t.Log("Revoke with empty table name")
+	_, err := ls.Revoke(context.Background(), &regattapb.LeaseRevokeRequest{Id: 1})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Revoke lease")
+	_, err = ls.Revoke(context.Background(), &regattapb.LeaseRevokeRequest{Table: table1Name, Id: 1})
+	r.NoError(err)
+
+	t.Log("Revoke non-existent lease")
+	_, err = ls.Revoke(context.Background(), &regattapb.LeaseRevokeRequest{Table: table1Name, Id: 1})
+	r.EqualError(err, status.Error(codes.NotFound, "lease not found").Error())
+}
+
+func TestLeaseServer_KeepAlive(t *testing.T) {
+	r := require.New(t)
+	ls := LeaseServer{Storage: &mockLeaseService{leases: map[int64]int64{1: 10}}}
+
+	stream := &mockKeepAliveStream{requests: []*regattapb.LeaseKeepAliveRequest{
+		{Table: table1Name, Id: 1},
+		{Table: table1Name, Id: 2},
+		{Table: table1Name, Id: 1},
+	}}
+	r.NoError(ls.KeepAlive(stream))
+	r.Equal([]*regattapb.LeaseKeepAliveResponse{
+		{Header: &regattapb.ResponseHeader{}, Id: 1, Ttl: 10},
+		{Id: 2},
+		{Header: &regattapb.ResponseHeader{}, Id: 1, Ttl: 10},
+	}, stream.responses)
+
+	t.Log("KeepAlive with empty table name")
+	stream = &mockKeepAliveStream{requests: []*regattapb.LeaseKeepAliveRequest{{Id: 1}}}
+	r.EqualError(ls.KeepAlive(stream), status.Error(codes.InvalidArgument, "table must be set").Error())
+}
+
+func TestLeaseServer_TimeToLive(t *testing.T) {
+	r := require.New(t)
+	ls := LeaseServer{Storage: &mockLeaseService{leases: map[int64]int64{1: 10}}}
+
+	t.Log("TimeToLive with empty table name")
+	_, err := ls.TimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Id: 1})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("TimeToLive of the lease")
+	resp, err := ls.TimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: table1Name, Id: 1})
+	r.NoError(err)
+	r.Equal(int64(10), resp.GrantedTtl)
+
+	t.Log("TimeToLive of non-existent lease")
+	_, err = ls.TimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: table1Name, Id: 2})
+	r.EqualError(err, status.Error(codes.NotFound, "lease not found").Error())
+}
+
+func TestReadonlyLeaseServer(t *testing.T) {
+	r := require.New(t)
+	ls := ReadonlyLeaseServer{LeaseServer: LeaseServer{Storage: &mockLeaseService{leases: map[int64]int64{1: 10}}}}
+
+	_, err := ls.Grant(context.Background(), &regattapb.LeaseGrantRequest{Table: table1Name, Ttl: 10})
+	r.EqualError(err, status.Error(codes.Unimplemented, "method Grant not implemented for follower").Error())
+
+	_, err = ls.Revoke(context.Background(), &regattapb.LeaseRevokeRequest{Table: table1Name, Id: 1})
+	r.EqualError(err, status.Error(codes.Unimplemented, "method Revoke not implemented for follower").Error())
+
+	err = ls.KeepAlive(&mockKeepAliveStream{})
+	r.EqualError(err, status.Error(codes.Unimplemented, "method KeepAlive not implemented for follower").Error())
+
+	resp, err := ls.TimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: table1Name, Id: 1})
+	r.NoError(err)
+	r.Equal(int64(10), resp.GrantedTtl)
+}
@@ -9,10 +9,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/replication/snapshot"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/lni/dragonboat/v4"
+	"github.com/lni/dragonboat/v4/raftpb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -21,6 +27,8 @@ import (
 type ResetServer struct {
 	regattapb.UnimplementedMaintenanceServer
 	Tables TableService
+	// Replication reports the replication lag of the tables, optional.
+	Replication ReplicationService
 }
 
 func (m *ResetServer) Reset(ctx context.Context, req *regattapb.ResetRequest) (*regattapb.ResetResponse, error) {
@@ -60,10 +68,58 @@ func (m *ResetServer) Reset(ctx context.Context, req *regattapb.ResetRequest) (*
 	return &regattapb.ResetResponse{}, nil
 }
 
+// CreateTable creates a new table local to the follower cluster.
+func (m *ResetServer) CreateTable(_ context.Context, req *regattapb.CreateTableRequest) (*regattapb.CreateTableResponse, error) {
+	return createTable(m.Tables, req, m.Tables.CreateLocalTable)
+}
+
+// DeleteTable deletes the table local to the follower cluster, the replicated tables could not be deleted.
+func (m *ResetServer) DeleteTable(_ context.Context, req *regattapb.DeleteTableRequest) (*regattapb.DeleteTableResponse, error) {
+	if len(req.Name) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be set")
+	}
+	t, err := m.Tables.GetTable(req.Name)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !t.Local {
+		return nil, status.Error(codes.FailedPrecondition, "only local tables could be deleted in follower")
+	}
+	if err := m.Tables.DeleteTable(req.Name); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.DeleteTableResponse{}, nil
+}
+
+// ListTables lists all the tables in the cluster.
+func (m *ResetServer) ListTables(context.Context, *regattapb.ListTablesRequest) (*regattapb.ListTablesResponse, error) {
+	resp, err := listTables(m.Tables)
+	if err != nil || m.Replication == nil {
+		return resp, err
+	}
+	for _, info := range resp.Tables {
+		if lag, ok := m.Replication.Lag(info.Name); ok {
+			info.ReplicationLag = ptr(lag.Milliseconds())
+		}
+	}
+	return resp, nil
+}
+
+// maxBackupSequenceSize the desired size of the SEQUENCE commands of the incremental backup.
+const maxBackupSequenceSize = 1024 * 1024
+
 // BackupServer implements some Maintenance service methods from proto/regatta.proto.
 type BackupServer struct {
 	regattapb.UnimplementedMaintenanceServer
 	Tables TableService
+	// LogReader reads the table logs for the incremental backups, the incremental backups are not supported if nil.
+	LogReader LogReaderService
 }
 
 func (m *BackupServer) Backup(req *regattapb.BackupRequest, srv regattapb.Maintenance_BackupServer) error {
@@ -88,9 +144,18 @@ func (m *BackupServer) Backup(req *regattapb.BackupRequest, srv regattapb.Mainte
 		_ = os.Remove(sf.Path())
 	}()
 
-	_, err = table.Snapshot(ctx, sf)
-	if err != nil {
-		return err
+	var index uint64
+	if req.SinceIndex != 0 {
+		index, err = m.backupLog(ctx, table, req.SinceIndex, sf)
+		if err != nil {
+			return err
+		}
+	} else {
+		res, err := table.Snapshot(ctx, sf)
+		if err != nil {
+			return err
+		}
+		index = res.Index
 	}
 	err = sf.Sync()
 	if err != nil {
@@ -101,10 +166,106 @@ func (m *BackupServer) Backup(req *regattapb.BackupRequest, srv regattapb.Mainte
 		return err
 	}
 
-	_, err = io.Copy(&snapshot.Writer{Sender: srv}, bufio.NewReaderSize(sf.File, snapshot.DefaultSnapshotChunkSize))
+	w := &snapshot.Writer{Sender: srv, Index: index}
+	_, err = io.Copy(w, bufio.NewReaderSize(sf.File, snapshot.DefaultSnapshotChunkSize))
+	if err != nil {
+		return err
+	}
+	// The index is sent even if there is no data to back up.
+	if w.Offset == 0 {
+		_, err = w.Write(nil)
+	}
 	return err
 }
 
+// backupLog writes the commands of the table log applied after the since index as SEQUENCE commands and returns
+// the applied index the backup was taken at. The commands keep the revisions they were applied with.
+func (m *BackupServer) backupLog(ctx context.Context, t table.ActiveTable, since uint64, w io.Writer) (uint64, error) {
+	if m.LogReader == nil {
+		return 0, status.Error(codes.Unimplemented, "incremental backups are not supported")
+	}
+	rctx, cancel := context.WithTimeout(ctx, indexReadTimeout)
+	defer cancel()
+	applied, err := t.RevisionOffset(rctx, true)
+	if err != nil {
+		return 0, err
+	}
+	if since > applied.Index {
+		return 0, status.Errorf(codes.FailedPrecondition, "table '%s' is behind the index %d", t.Name, since)
+	}
+
+	seq := &regattapb.Command{Table: []byte(t.Name), Type: regattapb.Command_SEQUENCE}
+	size := 0
+	flush := func() error {
+		if len(seq.Sequence) == 0 {
+			return nil
+		}
+		bts, err := seq.MarshalVT()
+		if err != nil {
+			return err
+		}
+		seq.Sequence = seq.Sequence[:0]
+		size = 0
+		_, err = w.Write(bts)
+		return err
+	}
+
+	logRange := dragonboat.LogRange{FirstIndex: since + 1, LastIndex: applied.Index + 1}
+	for logRange.FirstIndex < logRange.LastIndex {
+		entries, err := m.LogReader.QueryRaftLog(ctx, t.ClusterID, logRange, maxBackupSequenceSize)
+		switch {
+		case errors.Is(err, serrors.ErrLogAhead):
+			return 0, status.Errorf(codes.FailedPrecondition, "table '%s' log after the index %d was compacted, full backup is required", t.Name, since)
+		case err != nil:
+			return 0, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, e := range entries {
+			if e.Type != raftpb.EncodedEntry {
+				continue
+			}
+			cmd := &regattapb.Command{}
+			if err := cmd.UnmarshalVT(e.Cmd[1:]); err != nil {
+				return 0, err
+			}
+			if cmd.Type == regattapb.Command_DUMMY {
+				continue
+			}
+			// The restored commands are applied with the revision of the backed up ones, the revision offsets are
+			// not expected to change within the backed up log. The leader index of the backed up table is not restored.
+			rev := e.Index + applied.RevisionOffset
+			if cmd.LeaderIndex != nil {
+				rev = *cmd.LeaderIndex + applied.LeaderRevisionOffset
+			}
+			cmd.LeaderIndex = nil
+			if cmd.Type != regattapb.Command_COMPACT && cmd.Type != regattapb.Command_INGEST {
+				cmd.Revision = int64(rev)
+			}
+			// The ingested keys are restored in an entry of their own, never together with other writes.
+			ingest := cmd.Type == regattapb.Command_INGEST
+			if ingest {
+				if err := flush(); err != nil {
+					return 0, err
+				}
+			}
+			seq.Sequence = append(seq.Sequence, cmd)
+			size += cmd.SizeVT()
+			if ingest || size >= maxBackupSequenceSize {
+				if err := flush(); err != nil {
+					return 0, err
+				}
+			}
+		}
+		logRange.FirstIndex = entries[len(entries)-1].Index + 1
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return applied.Index, nil
+}
+
 func (m *BackupServer) Restore(srv regattapb.Maintenance_RestoreServer) error {
 	msg, err := srv.Recv()
 	if err != nil {
@@ -134,13 +295,166 @@ func (m *BackupServer) Restore(srv regattapb.Maintenance_RestoreServer) error {
 	if err != nil {
 		return err
 	}
-	err = m.Tables.Restore(string(info.Table), sf)
+	var r io.Reader = sf
+	if info.Revision != 0 || info.Timestamp != 0 {
+		r = &pointInTimeReader{r: sf, revision: info.Revision, timestamp: info.Timestamp}
+	}
+	err = m.Tables.Restore(string(info.Table), r)
 	if err != nil {
 		return err
 	}
 	return srv.SendAndClose(&regattapb.RestoreResponse{})
 }
 
+// pointInTimeReader reads the restored commands up to the revision and the timestamp if set. The key values of the table
+// snapshot must not be newer than the revision, the SEQUENCE commands of the replayed log are cut at the first command
+// past the revision or proposed after the timestamp.
+type pointInTimeReader struct {
+	r         io.Reader
+	revision  int64
+	timestamp int64
+	done      bool
+}
+
+func (p *pointInTimeReader) Read(b []byte) (int, error) {
+	if p.done {
+		return 0, io.EOF
+	}
+	n, err := p.r.Read(b)
+	if err != nil {
+		return n, err
+	}
+	cmd := &regattapb.Command{}
+	if err := cmd.UnmarshalVT(b[:n]); err != nil {
+		return 0, err
+	}
+	switch cmd.Type {
+	case regattapb.Command_PUT:
+		if p.revision != 0 && cmd.Kv.GetModRevision() > p.revision {
+			return 0, status.Errorf(codes.FailedPrecondition, "table backup is newer than the revision %d", p.revision)
+		}
+	case regattapb.Command_SEQUENCE:
+		for i, c := range cmd.Sequence {
+			if !p.past(c) {
+				continue
+			}
+			p.done = true
+			if i == 0 {
+				return 0, io.EOF
+			}
+			cmd.Sequence = cmd.Sequence[:i]
+			return cmd.MarshalToSizedBufferVT(b[:cmd.SizeVT()])
+		}
+	}
+	return n, nil
+}
+
+// past whether the replayed command is past the point in time restored to. The commands proposed without the timestamp
+// are not compared by the timestamp.
+func (p *pointInTimeReader) past(cmd *regattapb.Command) bool {
+	if p.revision != 0 && cmd.Revision > p.revision {
+		return true
+	}
+	return p.timestamp != 0 && cmd.Timestamp > p.timestamp
+}
+
+// Compact discards the history of the table older than the requested revision.
+func (m *BackupServer) Compact(ctx context.Context, req *regattapb.CompactRequest) (*regattapb.CompactResponse, error) {
+	if len(req.Table) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+	if req.Revision <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "revision must be a positive number")
+	}
+	t, err := m.Tables.GetTable(string(req.Table))
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		dctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+		ctx = dctx
+	}
+	if err := t.Compact(ctx, req.Revision); err != nil {
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.CompactResponse{}, nil
+}
+
+// CreateTable creates a new table in the cluster.
+func (m *BackupServer) CreateTable(_ context.Context, req *regattapb.CreateTableRequest) (*regattapb.CreateTableResponse, error) {
+	return createTable(m.Tables, req, m.Tables.CreateTableWithOverrides)
+}
+
+// DeleteTable deletes the table from the cluster.
+func (m *BackupServer) DeleteTable(_ context.Context, req *regattapb.DeleteTableRequest) (*regattapb.DeleteTableResponse, error) {
+	if len(req.Name) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be set")
+	}
+	if err := m.Tables.DeleteTable(req.Name); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.DeleteTableResponse{}, nil
+}
+
+// ListTables lists all the tables in the cluster.
+func (m *BackupServer) ListTables(context.Context, *regattapb.ListTablesRequest) (*regattapb.ListTablesResponse, error) {
+	return listTables(m.Tables)
+}
+
+func createTable(tables TableService, req *regattapb.CreateTableRequest, create func(string, *table.Overrides) error) (*regattapb.CreateTableResponse, error) {
+	if len(req.Name) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be set")
+	}
+	overrides, err := table.OverridesFromConfig(req.Config)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := create(req.Name, overrides); err != nil {
+		if errors.Is(err, serrors.ErrTableExists) {
+			return nil, status.Error(codes.AlreadyExists, "table already exists")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	t, err := tables.GetTable(req.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.CreateTableResponse{Id: t.ClusterID}, nil
+}
+
+func listTables(tables TableService) (*regattapb.ListTablesResponse, error) {
+	tabs, err := tables.GetTables()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &regattapb.ListTablesResponse{Tables: make([]*regattapb.TableInfo, 0, len(tabs))}
+	for _, tab := range tabs {
+		info := &regattapb.TableInfo{Name: tab.Name, Id: tab.ClusterID, Config: tab.Overrides.Config()}
+		if tab.Local {
+			info.Type = regattapb.Table_LOCAL
+		}
+		resp.Tables = append(resp.Tables, info)
+	}
+	slices.SortFunc(resp.Tables, func(a, b *regattapb.TableInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return resp, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
 type backupReader struct {
 	stream regattapb.Maintenance_RestoreServer
 }
@@ -1,3 +1,220 @@
 // Copyright JAMF Software, LLC
 
 package regattaserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackupServer_CompactInvalidArgument(t *testing.T) {
+	r := require.New(t)
+	m := BackupServer{Tables: MockTableService{}}
+
+	t.Log("Compact with empty table name")
+	_, err := m.Compact(context.Background(), &regattapb.CompactRequest{Revision: 1})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Compact with non-positive revision")
+	_, err = m.Compact(context.Background(), &regattapb.CompactRequest{Table: table1Name})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "revision must be a positive number").Error())
+}
+
+func TestBackupServer_CreateTable(t *testing.T) {
+	r := require.New(t)
+	m := BackupServer{Tables: MockTableService{tables: []table.Table{{Name: "table", ClusterID: 10001}}}}
+
+	t.Log("CreateTable with empty name")
+	_, err := m.CreateTable(context.Background(), &regattapb.CreateTableRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+
+	t.Log("CreateTable")
+	resp, err := m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table"})
+	r.NoError(err)
+	r.Equal(uint64(10001), resp.Id)
+
+	t.Log("CreateTable with config")
+	_, err = m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table", Config: &regattapb.TableConfig{Compression: regattapb.TableConfig_ZSTD}})
+	r.NoError(err)
+
+	t.Log("CreateTable with invalid config")
+	zero := uint64(0)
+	_, err = m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table", Config: &regattapb.TableConfig{MemtableSize: &zero}})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "memtable_size must be a positive number").Error())
+	_, err = m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table", Config: &regattapb.TableConfig{MaxValueSize: &zero}})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "max_value_size must be a positive number").Error())
+	_, err = m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table", Config: &regattapb.TableConfig{Compression: 42}})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "unknown compression").Error())
+
+	t.Log("CreateTable already existing")
+	m.Tables = MockTableService{error: serrors.ErrTableExists}
+	_, err = m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table"})
+	r.EqualError(err, status.Error(codes.AlreadyExists, "table already exists").Error())
+}
+
+func TestBackupServer_DeleteTable(t *testing.T) {
+	r := require.New(t)
+	m := BackupServer{Tables: MockTableService{}}
+
+	t.Log("DeleteTable with empty name")
+	_, err := m.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+
+	t.Log("DeleteTable")
+	_, err = m.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{Name: "table"})
+	r.NoError(err)
+
+	t.Log("DeleteTable non-existent")
+	m.Tables = MockTableService{error: serrors.ErrTableNotFound}
+	_, err = m.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{Name: "table"})
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+}
+
+func TestBackupServer_ListTables(t *testing.T) {
+	r := require.New(t)
+	m := BackupServer{Tables: MockTableService{tables: []table.Table{{Name: "table_2", ClusterID: 10002}, {Name: "table_1", ClusterID: 10001}}}}
+
+	resp, err := m.ListTables(context.Background(), &regattapb.ListTablesRequest{})
+	r.NoError(err)
+	r.Equal([]*regattapb.TableInfo{{Name: "table_1", Id: 10001}, {Name: "table_2", Id: 10002}}, resp.Tables)
+}
+
+func TestResetServer_Tables(t *testing.T) {
+	r := require.New(t)
+	m := ResetServer{Tables: MockTableService{tables: []table.Table{{Name: "table", ClusterID: 10001, Local: true}}}}
+
+	t.Log("CreateTable")
+	resp, err := m.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: "table"})
+	r.NoError(err)
+	r.Equal(uint64(10001), resp.Id)
+
+	t.Log("ListTables")
+	list, err := m.ListTables(context.Background(), &regattapb.ListTablesRequest{})
+	r.NoError(err)
+	r.Equal([]*regattapb.TableInfo{{Name: "table", Id: 10001, Type: regattapb.Table_LOCAL}}, list.Tables)
+
+	t.Log("DeleteTable local")
+	_, err = m.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{Name: "table"})
+	r.NoError(err)
+
+	t.Log("DeleteTable replicated")
+	m.Tables = MockTableService{tables: []table.Table{{Name: "table", ClusterID: 10001, Replicated: true}}}
+	_, err = m.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{Name: "table"})
+	r.EqualError(err, status.Error(codes.FailedPrecondition, "only local tables could be deleted in follower").Error())
+}
+
+type mockReplication map[string]time.Duration
+
+func (m mockReplication) Lag(table string) (time.Duration, bool) {
+	lag, ok := m[table]
+	return lag, ok
+}
+
+func (m mockReplication) Freshness(table string) (time.Time, bool) {
+	lag, ok := m[table]
+	return time.Now().Add(-lag), ok
+}
+
+func TestResetServer_ListTables_ReplicationLag(t *testing.T) {
+	r := require.New(t)
+	m := ResetServer{
+		Tables:      MockTableService{tables: []table.Table{{Name: "replicated", ClusterID: 10001}, {Name: "unknown", ClusterID: 10002}}},
+		Replication: mockReplication{"replicated": 1500 * time.Millisecond},
+	}
+	list, err := m.ListTables(context.Background(), &regattapb.ListTablesRequest{})
+	r.NoError(err)
+	r.Equal([]*regattapb.TableInfo{
+		{Name: "replicated", Id: 10001, ReplicationLag: ptr(int64(1500))},
+		{Name: "unknown", Id: 10002},
+	}, list.Tables)
+}
+
+// commandsReader reads a single command per Read like the restored snapshot file.
+type commandsReader []*regattapb.Command
+
+func (c *commandsReader) Read(p []byte) (int, error) {
+	if len(*c) == 0 {
+		return 0, io.EOF
+	}
+	cmd := (*c)[0]
+	*c = (*c)[1:]
+	return cmd.MarshalToSizedBufferVT(p[:cmd.SizeVT()])
+}
+
+func readCommands(t *testing.T, reader io.Reader) ([]*regattapb.Command, error) {
+	var res []*regattapb.Command
+	buf := make([]byte, 1024)
+	for {
+		n, err := reader.Read(buf)
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		cmd := &regattapb.Command{}
+		require.NoError(t, cmd.UnmarshalVT(buf[:n]))
+		res = append(res, cmd)
+	}
+}
+
+func TestPointInTimeReader_Read(t *testing.T) {
+	r := require.New(t)
+	put := func(k string, rev int64) *regattapb.Command {
+		return &regattapb.Command{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte(k), CreateRevision: rev, ModRevision: rev, Version: 1}}
+	}
+	logged := func(k string, rev int64, ts int64) *regattapb.Command {
+		return &regattapb.Command{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte(k)}, Revision: rev, Timestamp: ts}
+	}
+	seq := func(cmds ...*regattapb.Command) *regattapb.Command {
+		return &regattapb.Command{Type: regattapb.Command_SEQUENCE, Sequence: cmds}
+	}
+	backup := func() *commandsReader {
+		return &commandsReader{
+			put("key_1", 2),
+			put("key_2", 3),
+			seq(logged("key_3", 4, 100), logged("key_4", 5, 200)),
+			seq(logged("key_5", 6, 0), logged("key_6", 7, 300)),
+		}
+	}
+
+	t.Log("restore to revision")
+	got, err := readCommands(t, &pointInTimeReader{r: backup(), revision: 6})
+	r.NoError(err)
+	r.Len(got, 4)
+	r.Len(got[3].Sequence, 1)
+	r.Equal([]byte("key_5"), got[3].Sequence[0].Kv.Key)
+
+	t.Log("restore to revision within the first replayed sequence")
+	got, err = readCommands(t, &pointInTimeReader{r: backup(), revision: 4})
+	r.NoError(err)
+	r.Len(got, 3)
+	r.Len(got[2].Sequence, 1)
+
+	t.Log("restore to timestamp")
+	got, err = readCommands(t, &pointInTimeReader{r: backup(), timestamp: 150})
+	r.NoError(err)
+	r.Len(got, 3)
+	r.Len(got[2].Sequence, 1)
+	r.Equal([]byte("key_3"), got[2].Sequence[0].Kv.Key)
+
+	t.Log("commands without timestamp are restored")
+	got, err = readCommands(t, &pointInTimeReader{r: backup(), timestamp: 250})
+	r.NoError(err)
+	r.Len(got, 4)
+	r.Len(got[3].Sequence, 1)
+
+	t.Log("restore to revision preceding the snapshot")
+	_, err = readCommands(t, &pointInTimeReader{r: backup(), revision: 2})
+	r.EqualError(err, status.Error(codes.FailedPrecondition, "table backup is newer than the revision 2").Error())
+}
@@ -5,20 +5,37 @@ package regattaserver
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/storage/watch"
 	"github.com/lni/dragonboat/v4"
 	"github.com/lni/dragonboat/v4/raftpb"
 )
 
 type KVService interface {
 	Range(ctx context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error)
+	Cursor(ctx context.Context, req *regattapb.RangeRequest, consumer func(*regattapb.RangeResponse) error) error
 	Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error)
 	Delete(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error)
+	Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error)
+	PutBatch(ctx context.Context, req *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error)
+	DeleteBatch(ctx context.Context, req *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error)
 	Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error)
 }
 
+type WatchService interface {
+	Watch(ctx context.Context, req *regattapb.WatchRequest) (*watch.Watcher, *regattapb.ResponseHeader, error)
+}
+
+type LeaseService interface {
+	LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error)
+	LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error)
+	LeaseKeepAlive(ctx context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error)
+	LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error)
+}
+
 type SnapshotService interface {
 	Snapshot(ctx context.Context, writer io.Writer) error
 }
@@ -26,9 +43,17 @@ type SnapshotService interface {
 type TableService interface {
 	GetTables() ([]table.Table, error)
 	GetTable(name string) (table.ActiveTable, error)
+	CreateTableWithOverrides(name string, overrides *table.Overrides) error
+	CreateLocalTable(name string, overrides *table.Overrides) error
+	DeleteTable(name string) error
 	Restore(name string, reader io.Reader) error
 }
 
 type LogReaderService interface {
 	QueryRaftLog(ctx context.Context, clusterID uint64, logRange dragonboat.LogRange, maxSize uint64) ([]raftpb.Entry, error)
 }
+
+type ReplicationService interface {
+	Lag(table string) (time.Duration, bool)
+	Freshness(table string) (time.Time, bool)
+}
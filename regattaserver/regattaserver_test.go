@@ -16,6 +16,9 @@ type MockStorage struct {
 	putResponse         regattapb.PutResponse
 	deleteRangeResponse regattapb.DeleteRangeResponse
 	txnResponse         regattapb.TxnResponse
+	putBatchResponse    regattapb.PutBatchResponse
+	incrementResponse   regattapb.IncrementResponse
+	deleteBatchResponse regattapb.DeleteBatchResponse
 	rangeError          error
 	putError            error
 	deleteError         error
@@ -25,6 +28,13 @@ func (s *MockStorage) Range(_ context.Context, _ *regattapb.RangeRequest) (*rega
 	return &s.rangeResponse, s.rangeError
 }
 
+func (s *MockStorage) Cursor(_ context.Context, _ *regattapb.RangeRequest, consumer func(*regattapb.RangeResponse) error) error {
+	if s.rangeError != nil {
+		return s.rangeError
+	}
+	return consumer(&s.rangeResponse)
+}
+
 func (s *MockStorage) Put(_ context.Context, _ *regattapb.PutRequest) (*regattapb.PutResponse, error) {
 	return &s.putResponse, s.putError
 }
@@ -33,6 +43,18 @@ func (s *MockStorage) Delete(_ context.Context, _ *regattapb.DeleteRangeRequest)
 	return &s.deleteRangeResponse, s.deleteError
 }
 
+func (s *MockStorage) Increment(_ context.Context, _ *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	return &s.incrementResponse, s.putError
+}
+
+func (s *MockStorage) PutBatch(_ context.Context, _ *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error) {
+	return &s.putBatchResponse, s.putError
+}
+
+func (s *MockStorage) DeleteBatch(_ context.Context, _ *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error) {
+	return &s.deleteBatchResponse, s.deleteError
+}
+
 func (s *MockStorage) Txn(_ context.Context, _ *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
 	return &s.txnResponse, s.deleteError
 }
@@ -47,7 +69,22 @@ func (t MockTableService) GetTables() ([]table.Table, error) {
 }
 
 func (t MockTableService) GetTable(name string) (table.ActiveTable, error) {
-	return t.tables[0].AsActive(nil), t.error
+	if len(t.tables) == 0 {
+		return table.ActiveTable{}, t.error
+	}
+	return table.ActiveTable{Table: t.tables[0]}, t.error
+}
+
+func (t MockTableService) CreateTableWithOverrides(name string, overrides *table.Overrides) error {
+	return t.error
+}
+
+func (t MockTableService) CreateLocalTable(name string, overrides *table.Overrides) error {
+	return t.error
+}
+
+func (t MockTableService) DeleteTable(name string) error {
+	return t.error
 }
 
 func (t MockTableService) Restore(name string, reader io.Reader) error {
@@ -5,14 +5,19 @@ package regattaserver
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/replication/snapshot"
 	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/storage/table/fsm"
 	"github.com/lni/dragonboat/v4"
 	"github.com/lni/dragonboat/v4/raftpb"
 	"go.uber.org/zap"
@@ -23,6 +28,12 @@ import (
 const (
 	// DefaultMaxGRPCSize is the default maximum size of body of gRPC message to be loaded from dragonboat.
 	DefaultMaxGRPCSize = 4 * 1024 * 1024
+	// DefaultHeartbeatInterval is the default maximum interval between the messages of the open replication stream.
+	DefaultHeartbeatInterval = time.Second
+	// streamPollInterval how often the open replication stream checks the log for the new entries.
+	streamPollInterval = 10 * time.Millisecond
+	// indexReadTimeout the timeout of the linearizable index reads, the open replication streams have no deadline set.
+	indexReadTimeout = 10 * time.Second
 )
 
 // MetadataServer implements Metadata service from proto/replication.proto.
@@ -38,9 +49,13 @@ func (m *MetadataServer) Get(context.Context, *regattapb.MetadataRequest) (*rega
 	}
 	resp := &regattapb.MetadataResponse{}
 	for _, tab := range tabs {
+		if tab.Local {
+			continue
+		}
 		resp.Tables = append(resp.Tables, &regattapb.Table{
-			Type: regattapb.Table_REPLICATED,
-			Name: tab.Name,
+			Type:   regattapb.Table_REPLICATED,
+			Name:   tab.Name,
+			Config: tab.Overrides.Config(),
 		})
 	}
 	return resp, nil
@@ -50,6 +65,22 @@ func (m *MetadataServer) Get(context.Context, *regattapb.MetadataRequest) (*rega
 type SnapshotServer struct {
 	regattapb.UnimplementedSnapshotServer
 	Tables TableService
+	// Follower the snapshots are served by the follower cluster, the snapshots carry the leader index instead of the local one.
+	Follower bool
+	// Retention how long the snapshot of the interrupted stream is held to be resumed, the streams are not resumable if 0.
+	Retention time.Duration
+
+	mtx  sync.Mutex
+	held map[string]*heldSnapshot
+}
+
+// heldSnapshot the snapshot file held by the server for the resumption of the interrupted streams.
+type heldSnapshot struct {
+	id    string
+	table string
+	path  string
+	refs  int
+	timer *time.Timer
 }
 
 func (s *SnapshotServer) Stream(req *regattapb.SnapshotRequest, srv regattapb.Snapshot_StreamServer) error {
@@ -57,51 +88,173 @@ func (s *SnapshotServer) Stream(req *regattapb.SnapshotRequest, srv regattapb.Sn
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "unable to stream from table '%s': %v", req.GetTable(), err)
 	}
+	if s.Follower && table.Local {
+		return status.Errorf(codes.Unavailable, "unable to stream from table '%s': table is local", req.GetTable())
+	}
+
+	offset := req.Offset
+	held := s.acquire(table.Name, req.Id)
+	if held == nil {
+		offset = 0
+		held, err = s.create(srv.Context(), table)
+		if err != nil {
+			return err
+		}
+	}
+	complete := false
+	defer func() {
+		s.release(held, complete)
+	}()
 
-	ctx := srv.Context()
+	f, err := os.Open(held.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if offset > uint64(info.Size()) {
+		return status.Errorf(codes.OutOfRange, "offset %d is beyond the snapshot size %d", offset, info.Size())
+	}
+	if _, err := f.Seek(int64(offset), io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(&snapshot.Writer{Sender: srv, ID: held.id, Offset: offset}, bufio.NewReaderSize(f, snapshot.DefaultSnapshotChunkSize))
+	if err != nil {
+		return err
+	}
+	complete = true
+	return nil
+}
+
+// Close removes all the held snapshots.
+func (s *SnapshotServer) Close() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, h := range s.held {
+		if h.timer != nil {
+			h.timer.Stop()
+		}
+		_ = os.Remove(h.path)
+	}
+	s.held = nil
+}
+
+// create writes a new snapshot of the table into a file, the snapshot is held for the resumption if the retention is set.
+func (s *SnapshotServer) create(ctx context.Context, table table.ActiveTable) (*heldSnapshot, error) {
 	if _, ok := ctx.Deadline(); !ok {
-		dctx, cancel := context.WithTimeout(srv.Context(), 1*time.Hour)
+		dctx, cancel := context.WithTimeout(ctx, 1*time.Hour)
 		defer cancel()
 		ctx = dctx
 	}
 
 	sf, err := snapshot.NewTemp()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func() {
+	held := &heldSnapshot{table: table.Name, path: sf.Path(), refs: 1}
+	if err := s.write(ctx, table, sf); err != nil {
 		_ = sf.Close()
-		_ = os.Remove(sf.Path())
-	}()
+		_ = os.Remove(held.path)
+		return nil, err
+	}
+	if err := sf.Close(); err != nil {
+		_ = os.Remove(held.path)
+		return nil, err
+	}
+
+	if s.Retention == 0 {
+		return held, nil
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		_ = os.Remove(held.path)
+		return nil, err
+	}
+	held.id = hex.EncodeToString(id)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.held == nil {
+		s.held = make(map[string]*heldSnapshot)
+	}
+	s.held[held.id] = held
+	return held, nil
+}
 
-	resp, err := table.Snapshot(ctx, sf)
+func (s *SnapshotServer) write(ctx context.Context, table table.ActiveTable, w io.Writer) error {
+	resp, err := table.Snapshot(ctx, w)
 	if err != nil {
 		return err
 	}
-	// Write dummy command with leader index to commit recovery snapshot.
+	index, revision := resp.Index, resp.Revision
+	if s.Follower {
+		index, revision = resp.LeaderIndex, resp.LeaderRevision
+	}
+	// Write dummy command with leader index to commit recovery snapshot, the revision lets the restored table
+	// continue the revisions of the snapshotted one.
 	final, err := (&regattapb.Command{
-		Table:       req.Table,
+		Table:       []byte(table.Name),
 		Type:        regattapb.Command_DUMMY,
-		LeaderIndex: &resp.Index,
+		LeaderIndex: &index,
+		Revision:    int64(revision),
 	}).MarshalVT()
 	if err != nil {
 		return err
 	}
-	_, err = sf.Write(final)
-	if err != nil {
-		return err
+	_, err = w.Write(final)
+	return err
+}
+
+// acquire returns the held snapshot of the table with the given id, nil if the snapshot is not held.
+func (s *SnapshotServer) acquire(table, id string) *heldSnapshot {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	held, ok := s.held[id]
+	if !ok || held.table != table {
+		return nil
 	}
-	err = sf.Sync()
-	if err != nil {
-		return err
+	if held.timer != nil {
+		held.timer.Stop()
+		held.timer = nil
 	}
-	_, err = sf.Seek(0, io.SeekStart)
-	if err != nil {
-		return err
+	held.refs++
+	return held
+}
+
+// release removes the snapshot once it was completely streamed, the snapshot of the interrupted stream is removed
+// after the retention unless the stream is resumed.
+func (s *SnapshotServer) release(held *heldSnapshot, complete bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	held.refs--
+	if held.refs > 0 {
+		return
+	}
+	if complete || held.id == "" {
+		s.remove(held)
+		return
 	}
+	var timer *time.Timer
+	timer = time.AfterFunc(s.Retention, func() {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		if held.refs == 0 && held.timer == timer {
+			s.remove(held)
+		}
+	})
+	held.timer = timer
+}
 
-	_, err = io.Copy(&snapshot.Writer{Sender: srv}, bufio.NewReaderSize(sf.File, snapshot.DefaultSnapshotChunkSize))
-	return err
+func (s *SnapshotServer) remove(held *heldSnapshot) {
+	if held.id != "" {
+		delete(s.held, held.id)
+	}
+	_ = os.Remove(held.path)
 }
 
 // LogServer implements Log service from proto/replication.proto.
@@ -109,6 +262,11 @@ type LogServer struct {
 	Tables    TableService
 	LogReader LogReaderService
 	Log       *zap.SugaredLogger
+	// Follower the log is served by the follower cluster, the commands replicated from the leader cluster are served
+	// under their leader indexes instead of the local raft indexes.
+	Follower bool
+	// Replication reports the freshness of the tables replicated by the follower cluster, optional.
+	Replication ReplicationService
 
 	maxMessageSize uint64
 	regattapb.UnimplementedLogServer
@@ -144,8 +302,17 @@ func (l *LogServer) Replicate(req *regattapb.ReplicateRequest, server regattapb.
 		return status.Errorf(codes.Unavailable, "unable to replicate table '%s': %v", req.GetTable(), err)
 	}
 
+	if l.Follower {
+		if t.Local {
+			return status.Errorf(codes.Unavailable, "unable to replicate table '%s': table is local", req.GetTable())
+		}
+		return l.replicateFollower(req, t, server)
+	}
+
 	ctx := server.Context()
-	appliedIndex, err := t.LocalIndex(ctx, true)
+	// The time of the read is taken first, the applied index holds at least the state of the table at that time.
+	appliedAt := time.Now()
+	appliedIndex, err := linearizableIndex(ctx, t.LocalIndex)
 	if err != nil {
 		return err
 	}
@@ -173,15 +340,27 @@ func (l *LogServer) Replicate(req *regattapb.ReplicateRequest, server regattapb.
 		}
 
 		if len(entries) == 0 {
-			// query index for update.
-			appliedIndex, err := t.LocalIndex(ctx, false)
-			if err != nil {
-				return err
+			if !req.Stream {
+				// query index for update.
+				appliedAt := time.Now()
+				appliedIndex, err := t.LocalIndex(ctx, false)
+				if err != nil {
+					return err
+				}
+				if err := server.Send(&regattapb.ReplicateResponse{LeaderIndex: appliedIndex.Index, LeaderTimestamp: appliedAt.UnixMilli()}); err != nil {
+					return err
+				}
+				return nil
 			}
-			if err := server.Send(&regattapb.ReplicateResponse{LeaderIndex: appliedIndex.Index}); err != nil {
+			last, at, err := l.waitForLog(ctx, t, logRange.LastIndex-1, heartbeatInterval(req), func(idx uint64, at time.Time) error {
+				return server.Send(&regattapb.ReplicateResponse{LeaderIndex: idx, LeaderTimestamp: at.UnixMilli()})
+			})
+			if err != nil {
 				return err
 			}
-			return nil
+			appliedIndex.Index, appliedAt = last, at
+			logRange.LastIndex = last + 1
+			continue
 		}
 
 		// Transform entries into actual commands.
@@ -195,7 +374,8 @@ func (l *LogServer) Replicate(req *regattapb.ReplicateRequest, server regattapb.
 		}
 
 		msg := &regattapb.ReplicateResponse{
-			LeaderIndex: appliedIndex.Index,
+			LeaderIndex:     appliedIndex.Index,
+			LeaderTimestamp: appliedAt.UnixMilli(),
 			Response: &regattapb.ReplicateResponse_CommandsResponse{
 				CommandsResponse: &regattapb.ReplicateCommandsResponse{
 					Commands: commands,
@@ -211,6 +391,254 @@ func (l *LogServer) Replicate(req *regattapb.ReplicateRequest, server regattapb.
 	}
 }
 
+// replicateFollower replicates the commands of the follower's log. The commands replicated from the leader cluster are
+// stored in the SEQUENCE commands of the follower's log, the rest of the log (e.g. the data of the recovered snapshot)
+// does not carry the leader index and could not be replicated.
+func (l *LogServer) replicateFollower(req *regattapb.ReplicateRequest, t table.ActiveTable, server regattapb.Log_ReplicateServer) error {
+	ctx := server.Context()
+	// The freshness is read before the leader index, the leader index holds at least the leader state of that time.
+	fresh := l.freshness(t.Name)
+	// The leader index must be read first, all the commands up to the leader index are then within the applied log.
+	leaderIndex, err := linearizableIndex(ctx, t.LeaderIndex)
+	if err != nil {
+		return err
+	}
+	appliedIndex, err := t.LocalIndex(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	if leaderIndex.Index+1 < req.LeaderIndex {
+		return server.Send(repErrLeaderBehind)
+	}
+
+	first := appliedIndex.Index + 1
+	if leaderIndex.Index >= req.LeaderIndex {
+		first, err = l.searchLog(ctx, t.ClusterID, req.LeaderIndex, appliedIndex.Index+1)
+		switch {
+		case errors.Is(err, serrors.ErrLogAhead):
+			return server.Send(repErrUseSnapshot)
+		case err != nil:
+			l.Log.Errorf("unknown error searching the raft log: %v", err)
+			return nil
+		}
+	}
+
+	current := leaderIndex.Index
+	next := req.LeaderIndex
+	logRange := dragonboat.LogRange{FirstIndex: first, LastIndex: appliedIndex.Index + 1}
+	for {
+		for logRange.FirstIndex < logRange.LastIndex {
+			if dl, ok := ctx.Deadline(); ok && time.Now().After(dl) {
+				l.Log.Infof("replication passed the deadline, ending stream prematurely")
+				return nil
+			}
+
+			entries, err := l.LogReader.QueryRaftLog(ctx, t.ClusterID, logRange, l.maxMessageSize)
+			switch {
+			case errors.Is(err, serrors.ErrLogAhead):
+				return server.Send(repErrUseSnapshot)
+			case err != nil:
+				l.Log.Errorf("unknown error queriyng the raft log: %v", err)
+				return nil
+			}
+			if len(entries) == 0 {
+				break
+			}
+
+			var commands []*regattapb.ReplicateCommand
+			for _, e := range entries {
+				seq, err := entrySequence(e)
+				if err != nil {
+					return err
+				}
+				for _, cmd := range seq {
+					switch {
+					case cmd.LeaderIndex == nil || *cmd.LeaderIndex < next:
+						// Already replicated or re-proposed command.
+						continue
+					case *cmd.LeaderIndex > next:
+						// The log is not continuous (the table was recovered from the snapshot), the rest of the commands is missing.
+						if len(commands) != 0 {
+							if err := server.Send(commandsResponse(current, fresh, commands)); err != nil {
+								return err
+							}
+						}
+						return server.Send(repErrUseSnapshot)
+					}
+					commands = append(commands, &regattapb.ReplicateCommand{Command: cmd, LeaderIndex: *cmd.LeaderIndex})
+					next++
+				}
+			}
+
+			if len(commands) != 0 {
+				if err := server.Send(commandsResponse(current, fresh, commands)); err != nil {
+					return err
+				}
+			}
+			logRange.FirstIndex = entries[len(entries)-1].Index + 1
+		}
+
+		if next <= leaderIndex.Index {
+			// Some of the requested commands are not in the log.
+			return server.Send(repErrUseSnapshot)
+		}
+
+		// The leader timestamps are known only to the member replicating the table from the leader cluster.
+		heartbeat := func(uint64, time.Time) error {
+			f := l.freshness(t.Name)
+			idx, err := t.LeaderIndex(ctx, false)
+			if err != nil {
+				return err
+			}
+			current, fresh = idx.Index, f
+			return server.Send(&regattapb.ReplicateResponse{LeaderIndex: current, LeaderTimestamp: fresh})
+		}
+		if !req.Stream {
+			return heartbeat(0, time.Time{})
+		}
+		last, _, err := l.waitForLog(ctx, t, logRange.LastIndex-1, heartbeatInterval(req), heartbeat)
+		if err != nil {
+			return err
+		}
+		logRange.LastIndex = last + 1
+	}
+}
+
+// waitForLog waits until the table applies the entries past the last local index and returns the new applied local index
+// along with the time it was read at. The heartbeat is sent right away and then every interval while waiting.
+func (l *LogServer) waitForLog(ctx context.Context, t table.ActiveTable, last uint64, interval time.Duration, heartbeat func(localIndex uint64, at time.Time) error) (uint64, time.Time, error) {
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+	var sent time.Time
+	for {
+		at := time.Now()
+		idx, err := t.LocalIndex(ctx, false)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		if idx.Index > last {
+			return idx.Index, at, nil
+		}
+		if time.Since(sent) >= interval {
+			if err := heartbeat(idx.Index, at); err != nil {
+				return 0, time.Time{}, err
+			}
+			sent = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return 0, time.Time{}, status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// freshness returns the leader timestamp in unix milliseconds of the most recent leader state replicated to the table,
+// 0 if not known.
+func (l *LogServer) freshness(table string) int64 {
+	if l.Replication == nil {
+		return 0
+	}
+	if at, ok := l.Replication.Freshness(table); ok {
+		return at.UnixMilli()
+	}
+	return 0
+}
+
+// linearizableIndex reads the index linearizably within the indexReadTimeout.
+func linearizableIndex(ctx context.Context, read func(context.Context, bool) (*fsm.IndexResponse, error)) (*fsm.IndexResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, indexReadTimeout)
+	defer cancel()
+	return read(ctx, true)
+}
+
+// heartbeatInterval returns the heartbeat interval of the replication stream requested by the follower.
+func heartbeatInterval(req *regattapb.ReplicateRequest) time.Duration {
+	if req.HeartbeatInterval <= 0 {
+		return DefaultHeartbeatInterval
+	}
+	return time.Duration(req.HeartbeatInterval) * time.Millisecond
+}
+
+// searchLog finds the local index of the first entry of the log, up to the last index (exclusive), holding the commands with
+// the leader index greater or equal to the requested one. The leader indexes grow with the local indexes, so the log is bisected.
+func (l *LogServer) searchLog(ctx context.Context, clusterID uint64, leaderIndex uint64, last uint64) (uint64, error) {
+	lo, hi := uint64(1), last
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		idx, err := l.nextLeaderIndex(ctx, clusterID, mid, hi)
+		switch {
+		case errors.Is(err, serrors.ErrLogAhead):
+			// The entry was compacted, if the requested command was compacted too it is found out while streaming.
+			lo = mid + 1
+		case err != nil:
+			return 0, err
+		case idx == 0 || idx >= leaderIndex:
+			hi = mid
+		default:
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// nextLeaderIndex returns the highest leader index of the first entry holding any replicated command within the range,
+// 0 is returned if there is no such entry.
+func (l *LogServer) nextLeaderIndex(ctx context.Context, clusterID uint64, first, last uint64) (uint64, error) {
+	logRange := dragonboat.LogRange{FirstIndex: first, LastIndex: last}
+	for logRange.FirstIndex < logRange.LastIndex {
+		entries, err := l.LogReader.QueryRaftLog(ctx, clusterID, logRange, l.maxMessageSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(entries) == 0 {
+			return 0, nil
+		}
+		for _, e := range entries {
+			seq, err := entrySequence(e)
+			if err != nil {
+				return 0, err
+			}
+			for i := len(seq) - 1; i >= 0; i-- {
+				if seq[i].LeaderIndex != nil {
+					return *seq[i].LeaderIndex, nil
+				}
+			}
+		}
+		logRange.FirstIndex = entries[len(entries)-1].Index + 1
+	}
+	return 0, nil
+}
+
+// entrySequence returns the commands replicated from the leader cluster stored in the raft entry of the follower's log.
+func entrySequence(e raftpb.Entry) ([]*regattapb.Command, error) {
+	if e.Type != raftpb.EncodedEntry {
+		return nil, nil
+	}
+	cmd := &regattapb.Command{}
+	if err := cmd.UnmarshalVT(e.Cmd[1:]); err != nil {
+		return nil, err
+	}
+	if cmd.Type != regattapb.Command_SEQUENCE {
+		return nil, nil
+	}
+	return cmd.Sequence, nil
+}
+
+// commandsResponse creates a ReplicateResponse with commands.
+func commandsResponse(leaderIndex uint64, leaderTimestamp int64, commands []*regattapb.ReplicateCommand) *regattapb.ReplicateResponse {
+	return &regattapb.ReplicateResponse{
+		LeaderIndex:     leaderIndex,
+		LeaderTimestamp: leaderTimestamp,
+		Response: &regattapb.ReplicateResponse_CommandsResponse{
+			CommandsResponse: &regattapb.ReplicateCommandsResponse{
+				Commands: commands,
+			},
+		},
+	}
+}
+
 // errorResponseFactory creates a ReplicateResponse error.
 func errorResponseFactory(err regattapb.ReplicateError) *regattapb.ReplicateResponse {
 	return &regattapb.ReplicateResponse{
@@ -4,7 +4,10 @@ package regattaserver
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/table"
@@ -76,6 +79,48 @@ func TestMetadataServer_Get(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "Get metadata - local table skipped",
+			fields: fields{
+				TableManager: MockTableService{
+					tables: []table.Table{
+						{
+							Name: "foo",
+						},
+						{
+							Name:  "bar",
+							Local: true,
+						},
+					},
+				},
+			},
+			want: &regattapb.MetadataResponse{Tables: []*regattapb.Table{
+				{
+					Name: "foo",
+					Type: regattapb.Table_REPLICATED,
+				},
+			}},
+		},
+		{
+			name: "Get metadata - table config",
+			fields: fields{
+				TableManager: MockTableService{
+					tables: []table.Table{
+						{
+							Name:      "foo",
+							Overrides: &table.Overrides{Compression: ptr(table.CompressionZstd), MemTableSize: ptr(uint64(1024))},
+						},
+					},
+				},
+			},
+			want: &regattapb.MetadataResponse{Tables: []*regattapb.Table{
+				{
+					Name:   "foo",
+					Type:   regattapb.Table_REPLICATED,
+					Config: &regattapb.TableConfig{Compression: regattapb.TableConfig_ZSTD, MemtableSize: ptr(uint64(1024))},
+				},
+			}},
+		},
 		{
 			name: "Get metadata - deadline exceeded",
 			fields: fields{
@@ -155,3 +200,92 @@ func TestEntryToCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestEntrySequence(t *testing.T) {
+	one, two := uint64(1), uint64(2)
+	encode := func(cmd *regattapb.Command) []byte {
+		b, err := cmd.MarshalVT()
+		require.NoError(t, err)
+		return append([]byte{0}, b...)
+	}
+	sequence := []*regattapb.Command{
+		{Table: []byte("test"), Type: regattapb.Command_PUT, LeaderIndex: &one, Kv: &regattapb.KeyValue{Key: []byte("key")}},
+		{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &two},
+	}
+	tests := []struct {
+		name  string
+		entry raftpb.Entry
+		want  []*regattapb.Command
+	}{
+		{
+			name:  "ConfigChange Entry Type",
+			entry: raftpb.Entry{Type: raftpb.ConfigChangeEntry},
+		},
+		{
+			name:  "Not a sequence",
+			entry: raftpb.Entry{Type: raftpb.EncodedEntry, Cmd: encode(&regattapb.Command{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &two})},
+		},
+		{
+			name:  "Sequence",
+			entry: raftpb.Entry{Type: raftpb.EncodedEntry, Cmd: encode(&regattapb.Command{Table: []byte("test"), Type: regattapb.Command_SEQUENCE, Sequence: sequence})},
+			want:  sequence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := entrySequence(tt.entry)
+			r.NoError(err)
+			r.Len(got, len(tt.want))
+			for i := range tt.want {
+				r.Equal(tt.want[i].Type, got[i].Type)
+				r.Equal(tt.want[i].LeaderIndex, got[i].LeaderIndex)
+				r.Equal(tt.want[i].Kv.GetKey(), got[i].Kv.GetKey())
+			}
+		})
+	}
+}
+
+func TestSnapshotServer_heldSnapshots(t *testing.T) {
+	r := require.New(t)
+	s := &SnapshotServer{Retention: 50 * time.Millisecond}
+	hold := func(id string) *heldSnapshot {
+		path := filepath.Join(t.TempDir(), id)
+		r.NoError(os.WriteFile(path, []byte("snapshot"), 0o600))
+		h := &heldSnapshot{id: id, table: "table", path: path, refs: 1}
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		if s.held == nil {
+			s.held = make(map[string]*heldSnapshot)
+		}
+		s.held[id] = h
+		return h
+	}
+
+	t.Log("completely streamed snapshot is removed")
+	h := hold("complete")
+	s.release(h, true)
+	r.Nil(s.acquire("table", "complete"))
+	r.NoFileExists(h.path)
+
+	t.Log("interrupted snapshot is held for the retention")
+	h = hold("interrupted")
+	s.release(h, false)
+	r.Nil(s.acquire("other", "interrupted"))
+	r.Same(h, s.acquire("table", "interrupted"))
+	time.Sleep(2 * s.Retention)
+	r.FileExists(h.path, "acquired snapshot must not expire")
+	s.release(h, false)
+	r.Eventually(func() bool {
+		_, err := os.Stat(h.path)
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+	r.Nil(s.acquire("table", "interrupted"))
+
+	t.Log("held snapshots are removed on close")
+	h = hold("closed")
+	s.release(h, false)
+	s.Close()
+	r.Nil(s.acquire("table", "closed"))
+	r.NoFileExists(h.path)
+}
@@ -0,0 +1,67 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/watch"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchServer implements Watch service from proto/regatta.proto.
+type WatchServer struct {
+	regattapb.UnimplementedWatchServer
+	Storage WatchService
+}
+
+// Watch implements proto/regatta.proto Watch.Watch method.
+func (s *WatchServer) Watch(req *regattapb.WatchRequest, srv regattapb.Watch_WatchServer) error {
+	if len(req.GetTable()) == 0 {
+		return status.Error(codes.InvalidArgument, "table must be set")
+	}
+	if len(req.GetKey()) == 0 {
+		return status.Error(codes.InvalidArgument, "key must be set")
+	}
+	if req.GetStartRevision() < 0 {
+		return status.Error(codes.InvalidArgument, "start_revision must be a positive number")
+	}
+
+	w, header, err := s.Storage.Watch(srv.Context(), req)
+	if err != nil {
+		var cerr *watch.CompactedError
+		switch {
+		case errors.Is(err, serrors.ErrTableNotFound):
+			return status.Error(codes.NotFound, "table not found")
+		case errors.As(err, &cerr):
+			return srv.Send(&regattapb.WatchResponse{Canceled: true, CancelReason: cerr.Error(), CompactRevision: cerr.CompactRevision})
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer w.Close()
+
+	if err := srv.Send(&regattapb.WatchResponse{Header: header, Created: true}); err != nil {
+		return err
+	}
+	for {
+		events, err := w.Next(srv.Context())
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return status.FromContextError(err).Err()
+			}
+			resp := &regattapb.WatchResponse{Header: header, Canceled: true, CancelReason: err.Error()}
+			var cerr *watch.CompactedError
+			if errors.As(err, &cerr) {
+				resp.CompactRevision = cerr.CompactRevision
+			}
+			return srv.Send(resp)
+		}
+		if err := srv.Send(&regattapb.WatchResponse{Header: header, Events: events}); err != nil {
+			return err
+		}
+	}
+}
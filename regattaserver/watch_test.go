@@ -0,0 +1,132 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/watch"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockWatchService struct {
+	hub *watch.Hub
+}
+
+func (m *mockWatchService) Watch(_ context.Context, req *regattapb.WatchRequest) (*watch.Watcher, *regattapb.ResponseHeader, error) {
+	if string(req.Table) != string(table1Name) {
+		return nil, nil, serrors.ErrTableNotFound
+	}
+	w, err := m.hub.Watch(string(req.Table), req.Key, req.RangeEnd, req.StartRevision, req.PrevKv)
+	return w, &regattapb.ResponseHeader{ShardId: 1}, err
+}
+
+type mockWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *regattapb.WatchResponse
+}
+
+func (m *mockWatchStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockWatchStream) Send(resp *regattapb.WatchResponse) error {
+	m.sent <- resp
+	return nil
+}
+
+func newMockWatchStream(ctx context.Context) *mockWatchStream {
+	return &mockWatchStream{ctx: ctx, sent: make(chan *regattapb.WatchResponse, 10)}
+}
+
+func TestWatchServer_WatchInvalidArgument(t *testing.T) {
+	r := require.New(t)
+	ws := WatchServer{Storage: &mockWatchService{hub: watch.NewHub(10, 10)}}
+	stream := newMockWatchStream(context.Background())
+
+	t.Log("Watch with empty table name")
+	err := ws.Watch(&regattapb.WatchRequest{Key: key1Name}, stream)
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Watch with empty key name")
+	err = ws.Watch(&regattapb.WatchRequest{Table: table1Name}, stream)
+	r.EqualError(err, status.Error(codes.InvalidArgument, "key must be set").Error())
+
+	t.Log("Watch with negative start revision")
+	err = ws.Watch(&regattapb.WatchRequest{Table: table1Name, Key: key1Name, StartRevision: -1}, stream)
+	r.EqualError(err, status.Error(codes.InvalidArgument, "start_revision must be a positive number").Error())
+
+	t.Log("Watch non-existent table")
+	err = ws.Watch(&regattapb.WatchRequest{Table: []byte("missing"), Key: key1Name}, stream)
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+}
+
+func TestWatchServer_Watch(t *testing.T) {
+	r := require.New(t)
+	hub := watch.NewHub(10, 10)
+	hub.Reset(string(table1Name), 0)
+	ws := WatchServer{Storage: &mockWatchService{hub: hub}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newMockWatchStream(ctx)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- ws.Watch(&regattapb.WatchRequest{Table: table1Name, Key: key1Name}, stream)
+	}()
+
+	resp := receive(t, stream)
+	r.True(resp.Created)
+	r.Equal(uint64(1), resp.Header.ShardId)
+
+	event := &regattapb.Event{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 1}}
+	hub.Publish(string(table1Name), []*regattapb.Event{event})
+	resp = receive(t, stream)
+	r.Equal([]*regattapb.Event{event}, resp.Events)
+
+	cancel()
+	r.Equal(codes.Canceled, status.Code(<-errc))
+}
+
+func TestWatchServer_WatchCanceled(t *testing.T) {
+	r := require.New(t)
+	hub := watch.NewHub(10, 10)
+	hub.Reset(string(table1Name), 5)
+	ws := WatchServer{Storage: &mockWatchService{hub: hub}}
+
+	t.Log("Watch compacted revision")
+	stream := newMockWatchStream(context.Background())
+	r.NoError(ws.Watch(&regattapb.WatchRequest{Table: table1Name, Key: key1Name, StartRevision: 1}, stream))
+	resp := receive(t, stream)
+	r.True(resp.Canceled)
+	r.Equal(int64(6), resp.CompactRevision)
+
+	t.Log("Watch reset while watching")
+	errc := make(chan error, 1)
+	go func() {
+		errc <- ws.Watch(&regattapb.WatchRequest{Table: table1Name, Key: key1Name}, stream)
+	}()
+	r.True(receive(t, stream).Created)
+	hub.Reset(string(table1Name), 10)
+	resp = receive(t, stream)
+	r.True(resp.Canceled)
+	r.Equal(int64(11), resp.CompactRevision)
+	r.NoError(<-errc)
+}
+
+func receive(t *testing.T, stream *mockWatchStream) *regattapb.WatchResponse {
+	select {
+	case resp := <-stream.sent:
+		return resp
+	case <-time.After(time.Second):
+		t.Fatal("no response received")
+		return nil
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"bufio"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
+	"path"
+	"slices"
 	"sort"
 	"time"
 
@@ -62,7 +64,22 @@ type ManifestTable struct {
 	Name     string `json:"name"`
 	Type     string `json:"type"`
 	FileName string `json:"file_name"`
-	MD5      string `json:"md5"`
+	// MD5 the checksum of the files of the backups taken before the format was introduced.
+	MD5 string `json:"md5,omitempty"`
+	// SHA256 the checksum of the file.
+	SHA256 string `json:"sha256,omitempty"`
+	// Format the version of the file format, the file holds the plain snapshot if not set.
+	Format int `json:"format,omitempty"`
+	// Compression the algorithm the file is compressed with.
+	Compression string `json:"compression,omitempty"`
+	// Encryption the algorithm the file is encrypted with, the file is not encrypted if empty.
+	Encryption string `json:"encryption,omitempty"`
+	// KeyID the identifier of the key the file is encrypted with.
+	KeyID string `json:"key_id,omitempty"`
+	// Index the table index the backup was taken at.
+	Index uint64 `json:"index,omitempty"`
+	// SinceIndex the index of the previous backup of the table, the backup holds only the changes since then if set.
+	SinceIndex uint64 `json:"since_index,omitempty"`
 }
 
 type manifestTables []ManifestTable
@@ -83,8 +100,25 @@ type Backup struct {
 	Conn    *grpc.ClientConn
 	Log     Logger
 	Timeout time.Duration
-	Dir     string
-	clock   Clock
+	// Dir the location of the backup.
+	Dir string
+	// Since the location of the previous backup, only the changes of the tables since the previous backup are backed up if set.
+	Since string
+	// Incrementals the locations of the incremental backups restored on top of the backup in Dir, in the order they were taken.
+	Incrementals []string
+	// Open opens the storage at the location, the locations are local directories if nil.
+	Open func(location string) (Storage, error)
+	// Key the key the backup files are encrypted with and decrypted with on restore, the files are not encrypted if nil.
+	Key *Key
+	// Table the name of the only table restored if set.
+	Table string
+	// TargetTable the name the Table is restored as if set, so the restored data could be inspected before swapping.
+	TargetTable string
+	// Revision the revision the tables are restored to if set.
+	Revision int64
+	// Timestamp the time the tables are restored to if set.
+	Timestamp time.Time
+	clock     Clock
 }
 
 func (b *Backup) ensureDefaults() {
@@ -97,6 +131,11 @@ func (b *Backup) ensureDefaults() {
 	if b.clock == nil {
 		b.clock = monotonic{}
 	}
+	if b.Open == nil {
+		b.Open = func(location string) (Storage, error) {
+			return NewLocalStorage(location)
+		}
+	}
 }
 
 func (b *Backup) Backup() (Manifest, error) {
@@ -112,11 +151,26 @@ func (b *Backup) Backup() (Manifest, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), b.Timeout)
 	defer cancel()
 
-	err := checkDir(b.Dir)
+	st, err := b.Open(b.Dir)
 	if err != nil {
 		return manifest, err
 	}
 
+	since := make(map[string]uint64)
+	if b.Since != "" {
+		prevSt, err := b.Open(b.Since)
+		if err != nil {
+			return manifest, err
+		}
+		prev, err := readManifest(ctx, prevSt)
+		if err != nil {
+			return manifest, err
+		}
+		for _, t := range prev.Tables {
+			since[t.Name] = t.Index
+		}
+	}
+
 	meta, err := mc.Get(ctx, &regattapb.MetadataRequest{})
 	if err != nil {
 		return manifest, err
@@ -124,33 +178,53 @@ func (b *Backup) Backup() (Manifest, error) {
 
 	b.Log.Infof("going to backup %v", meta.Tables)
 	for _, t := range meta.Tables {
-		b.Log.Infof("backing up table '%s'", t.Name)
-		stream, err := sc.Backup(ctx, &regattapb.BackupRequest{Table: []byte(t.Name)})
+		// Tables missing in the previous backup are backed up whole.
+		sinceIndex := since[t.Name]
+		if sinceIndex != 0 {
+			b.Log.Infof("backing up table '%s' since index %d", t.Name, sinceIndex)
+		} else {
+			b.Log.Infof("backing up table '%s'", t.Name)
+		}
+		stream, err := sc.Backup(ctx, &regattapb.BackupRequest{Table: []byte(t.Name), SinceIndex: sinceIndex})
 		if err != nil {
 			return manifest, err
 		}
 		fName := fmt.Sprintf("%s.bak", t.Name)
-		sf, err := os.Create(filepath.Join(b.Dir, fName))
+		sf, err := st.Create(ctx, fName)
 		if err != nil {
 			return manifest, err
 		}
 
-		hash := md5.New()
-		w := io.MultiWriter(hash, sf)
-		_, err = io.Copy(w, snapshot.Reader{Stream: stream})
+		hash := sha256.New()
+		fw, header, err := newFileWriter(io.MultiWriter(hash, sf), b.Key)
+		if err != nil {
+			_ = sf.Abort()
+			return manifest, err
+		}
+		index, err := receive(stream, fw)
+		if cerr := fw.Close(); err == nil {
+			err = cerr
+		}
 		if err != nil {
+			_ = sf.Abort()
 			return manifest, err
 		}
-		err = sf.Sync()
+		err = sf.Commit()
 		if err != nil {
 			return manifest, err
 		}
 
 		manifest.Tables = append(manifest.Tables, ManifestTable{
-			Name:     t.Name,
-			Type:     t.Type.String(),
-			FileName: fName,
-			MD5:      hex.EncodeToString(hash.Sum(nil)),
+			Name:        t.Name,
+			Type:        t.Type.String(),
+			FileName:    fName,
+			SHA256:      hex.EncodeToString(hash.Sum(nil)),
+			Format:      formatVersion,
+			Compression: header.Compression,
+			Encryption:  header.Encryption,
+			KeyID:       header.KeyID,
+			Index:       index,
+			SinceIndex:  sinceIndex,
 		})
 		b.Log.Infof("backed up table '%s'", t.Name)
 	}
@@ -158,19 +232,17 @@ func (b *Backup) Backup() (Manifest, error) {
 	manifest.Finished = b.clock.Now()
 
 	b.Log.Info("tables backed up, writing manifest")
-	manFile, err := os.Create(filepath.Join(b.Dir, manifestFileName))
+	// The manifest is written last so that the backup is not visible before all the table files are written.
+	manFile, err := st.Create(ctx, manifestFileName)
 	if err != nil {
 		return manifest, err
 	}
-	defer func() {
-		_ = manFile.Close()
-	}()
-
 	err = json.NewEncoder(manFile).Encode(manifest)
 	if err != nil {
+		_ = manFile.Abort()
 		return manifest, err
 	}
-	err = manFile.Sync()
+	err = manFile.Commit()
 	if err != nil {
 		return manifest, err
 	}
@@ -186,56 +258,80 @@ func (b *Backup) Restore() error {
 	ctx, cancel := context.WithTimeout(context.Background(), b.Timeout)
 	defer cancel()
 
-	err := checkDir(b.Dir)
+	st, err := b.Open(b.Dir)
 	if err != nil {
 		return err
 	}
 
-	manFile, err := os.Open(filepath.Join(b.Dir, manifestFileName))
+	manifest, err := readManifest(ctx, st)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = manFile.Close()
-	}()
+	b.Log.Info("manifest loaded")
 
-	manifest := Manifest{}
-	err = json.NewDecoder(manFile).Decode(&manifest)
+	chains, err := b.loadChains(ctx, st, manifest)
 	if err != nil {
 		return err
 	}
-	b.Log.Info("manifest loaded")
-
-	b.Log.Infof("going to restore %v", manifest.Tables)
 
-	hash := md5.New()
-	for _, table := range manifest.Tables {
-		hash.Reset()
-		tf, err := os.Open(filepath.Join(b.Dir, table.FileName))
-		if err != nil {
-			return err
+	tables := manifest.Tables
+	if b.Table != "" {
+		tables = slices.DeleteFunc(tables, func(t ManifestTable) bool { return t.Name != b.Table })
+		if len(tables) == 0 {
+			return fmt.Errorf("table '%s' is not in the backup", b.Table)
 		}
-		_, err = io.Copy(hash, tf)
+	} else if b.TargetTable != "" {
+		return errors.New("target table requires the table to restore")
+	}
+
+	b.Log.Infof("going to restore %v", tables)
+
+	for _, table := range tables {
+		chain, err := b.pointInTime(chains[table.Name])
 		if err != nil {
 			return err
 		}
-		if hex.EncodeToString(hash.Sum(nil)) != table.MD5 {
-			return fmt.Errorf("table '%s' file '%s' corrupted (checksum mismatch)", table.Name, table.FileName)
+		// The full backup of the table is followed by the incremental backups in a single stream.
+		var files []io.Reader
+		for _, part := range chain {
+			err := verifyFile(ctx, part)
+			if err != nil {
+				return err
+			}
+			tf, err := part.storage.Open(ctx, part.FileName)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = tf.Close()
+			}()
+			fr, err := newFileReader(tf, b.Key)
+			if err != nil {
+				return fmt.Errorf("table '%s' file '%s': %w", part.Name, path.Join(part.storage.String(), part.FileName), err)
+			}
+			defer func() {
+				_ = fr.Close()
+			}()
+			files = append(files, fr)
 		}
 		b.Log.Infof("table '%s' checksum valid", table.Name)
-		_, err = tf.Seek(0, io.SeekStart)
-		if err != nil {
-			return err
-		}
 		stream, err := sc.Restore(ctx)
 		if err != nil {
 			return err
 		}
+		info := &regattapb.RestoreInfo{
+			Table:    []byte(table.Name),
+			Revision: b.Revision,
+		}
+		if b.TargetTable != "" {
+			info.Table = []byte(b.TargetTable)
+		}
+		if !b.Timestamp.IsZero() {
+			info.Timestamp = b.Timestamp.UnixMilli()
+		}
 		err = stream.Send(&regattapb.RestoreMessage{
 			Data: &regattapb.RestoreMessage_Info{
-				Info: &regattapb.RestoreInfo{
-					Table: []byte(table.Name),
-				},
+				Info: info,
 			},
 		})
 		if err != nil {
@@ -243,7 +339,7 @@ func (b *Backup) Restore() error {
 		}
 		b.Log.Infof("table '%s' stream started", table.Name)
 
-		_, err = io.Copy(&Writer{Sender: stream}, bufio.NewReaderSize(tf, defaultSnapshotChunkSize))
+		_, err = io.Copy(&Writer{Sender: stream}, bufio.NewReaderSize(io.MultiReader(files...), defaultSnapshotChunkSize))
 		if err != nil {
 			return err
 		}
@@ -253,23 +349,141 @@ func (b *Backup) Restore() error {
 		if err != nil {
 			return err
 		}
-		b.Log.Infof("table '%s' restored", table.Name)
+		b.Log.Infof("table '%s' restored as '%s'", table.Name, info.Table)
 	}
 
 	return nil
 }
 
-func checkDir(dir string) error {
-	if dir != "" {
-		stat, err := os.Stat(dir)
+// tableBackup a backup of the table along with the storage and the start of the backup.
+type tableBackup struct {
+	ManifestTable
+	storage Storage
+	started time.Time
+}
+
+// verifyFile reads the whole table backup file and checks it matches the checksum in the manifest.
+func verifyFile(ctx context.Context, t tableBackup) error {
+	tf, err := t.storage.Open(ctx, t.FileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tf.Close()
+	}()
+	hash, sum := sha256.New(), t.SHA256
+	if sum == "" {
+		hash, sum = md5.New(), t.MD5
+	}
+	_, err = io.Copy(hash, tf)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != sum {
+		return fmt.Errorf("table '%s' file '%s' corrupted (checksum mismatch)", t.Name, path.Join(t.storage.String(), t.FileName))
+	}
+	return nil
+}
+
+// loadChains loads the incremental backups and returns the backups of each table of the manifest to restore in order,
+// starting with the full backup. The incremental backups must follow each other without any gap.
+func (b *Backup) loadChains(ctx context.Context, st Storage, manifest Manifest) (map[string][]tableBackup, error) {
+	chains := make(map[string][]tableBackup, len(manifest.Tables))
+	for _, t := range manifest.Tables {
+		if t.SinceIndex != 0 {
+			return nil, fmt.Errorf("table '%s' backup in '%s' is incremental, full backup is required", t.Name, st)
+		}
+		chains[t.Name] = []tableBackup{{ManifestTable: t, storage: st, started: manifest.Started}}
+	}
+	for _, dir := range b.Incrementals {
+		incSt, err := b.Open(dir)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		inc, err := readManifest(ctx, incSt)
+		if err != nil {
+			return nil, err
+		}
+		backedUp := make(map[string]bool, len(inc.Tables))
+		for _, t := range inc.Tables {
+			backedUp[t.Name] = true
 		}
-		if !stat.IsDir() {
-			return fmt.Errorf("'%s' is not a directory", dir)
+		for name := range chains {
+			if !backedUp[name] {
+				return nil, fmt.Errorf("table '%s' is missing in the backup in '%s'", name, dir)
+			}
+		}
+		for _, t := range inc.Tables {
+			chain, ok := chains[t.Name]
+			if !ok {
+				b.Log.Infof("table '%s' of the incremental backup in '%s' is not in the full backup, skipping", t.Name, dir)
+				continue
+			}
+			// Full backup of the table starts the chain over.
+			if t.SinceIndex == 0 {
+				chains[t.Name] = []tableBackup{{ManifestTable: t, storage: incSt, started: inc.Started}}
+				continue
+			}
+			if last := chain[len(chain)-1]; last.Index != t.SinceIndex {
+				return nil, fmt.Errorf("table '%s' backup in '%s' does not follow the backup in '%s' (gap between index %d and %d)", t.Name, dir, last.storage, last.Index, t.SinceIndex)
+			}
+			chains[t.Name] = append(chain, tableBackup{ManifestTable: t, storage: incSt, started: inc.Started})
+		}
+	}
+	return chains, nil
+}
+
+// pointInTime returns the backups of the chain needed to restore the table to the revision and the timestamp if set.
+// The backup holds the commands applied since the previous backup, so it is needed if the previous backup precedes the target.
+func (b *Backup) pointInTime(chain []tableBackup) ([]tableBackup, error) {
+	full := chain[0]
+	if b.Revision != 0 && int64(full.Index) > b.Revision {
+		return nil, fmt.Errorf("table '%s' backup in '%s' was taken after the revision %d", full.Name, full.storage, b.Revision)
+	}
+	if !b.Timestamp.IsZero() && full.started.After(b.Timestamp) {
+		return nil, fmt.Errorf("table '%s' backup in '%s' was taken after %s", full.Name, full.storage, b.Timestamp.Format(time.RFC3339))
+	}
+	for i := 1; i < len(chain); i++ {
+		prev := chain[i-1]
+		if (b.Revision != 0 && int64(prev.Index) >= b.Revision) || (!b.Timestamp.IsZero() && prev.started.After(b.Timestamp)) {
+			return chain[:i], nil
+		}
+	}
+	return chain, nil
+}
+
+func readManifest(ctx context.Context, st Storage) (Manifest, error) {
+	manifest := Manifest{}
+	manFile, err := st.Open(ctx, manifestFileName)
+	if err != nil {
+		return manifest, err
+	}
+	defer func() {
+		_ = manFile.Close()
+	}()
+	err = json.NewDecoder(manFile).Decode(&manifest)
+	return manifest, err
+}
+
+// receive writes the backup stream into the writer and returns the index the backup was taken at.
+func receive(stream regattapb.Maintenance_BackupClient, w io.Writer) (uint64, error) {
+	var index uint64
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return index, nil
+		}
+		if err != nil {
+			return index, err
+		}
+		if chunk.Checksum != nil && *chunk.Checksum != snapshot.Checksum(chunk.Data) {
+			return index, fmt.Errorf("%w at offset %d", snapshot.ErrChecksumMismatch, chunk.Offset)
+		}
+		index = chunk.Index
+		if _, err := w.Write(chunk.Data); err != nil {
+			return index, err
 		}
 	}
-	return nil
 }
 
 type Writer struct {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	pvfs "github.com/cockroachdb/pebble/vfs"
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/regattaserver"
+	"github.com/jamf/regatta/storage/logreader"
 	"github.com/jamf/regatta/storage/table"
 	"github.com/lni/dragonboat/v4"
 	"github.com/lni/dragonboat/v4/config"
@@ -56,10 +58,12 @@ func TestBackup_Backup(t *testing.T) {
 				Finished: time.Unix(0, 0),
 				Tables: []ManifestTable{
 					{
-						Name:     "regatta-test",
-						Type:     "REPLICATED",
-						FileName: "regatta-test.bak",
-						MD5:      "d41d8cd98f00b204e9800998ecf8427e",
+						Name:        "regatta-test",
+						Type:        "REPLICATED",
+						FileName:    "regatta-test.bak",
+						SHA256:      "b1ded738ba5f099ad5dd2dc3980476724a1e3aeaeb543249d76aa82cabcae137",
+						Format:      1,
+						Compression: "zstd",
 					},
 				},
 			},
@@ -75,16 +79,20 @@ func TestBackup_Backup(t *testing.T) {
 				Finished: time.Unix(0, 0),
 				Tables: []ManifestTable{
 					{
-						Name:     "regatta-test",
-						Type:     "REPLICATED",
-						FileName: "regatta-test.bak",
-						MD5:      "d41d8cd98f00b204e9800998ecf8427e",
+						Name:        "regatta-test",
+						Type:        "REPLICATED",
+						FileName:    "regatta-test.bak",
+						SHA256:      "b1ded738ba5f099ad5dd2dc3980476724a1e3aeaeb543249d76aa82cabcae137",
+						Format:      1,
+						Compression: "zstd",
 					},
 					{
-						Name:     "regatta-test2",
-						Type:     "REPLICATED",
-						FileName: "regatta-test2.bak",
-						MD5:      "d41d8cd98f00b204e9800998ecf8427e",
+						Name:        "regatta-test2",
+						Type:        "REPLICATED",
+						FileName:    "regatta-test2.bak",
+						SHA256:      "b1ded738ba5f099ad5dd2dc3980476724a1e3aeaeb543249d76aa82cabcae137",
+						Format:      1,
+						Compression: "zstd",
 					},
 				},
 			},
@@ -110,16 +118,20 @@ func TestBackup_Backup(t *testing.T) {
 				Finished: time.Unix(0, 0),
 				Tables: []ManifestTable{
 					{
-						Name:     "regatta-test",
-						Type:     "REPLICATED",
-						FileName: "regatta-test.bak",
-						MD5:      "5cc50dc8f85f6ab733c9cff534a398dd",
+						Name:        "regatta-test",
+						Type:        "REPLICATED",
+						FileName:    "regatta-test.bak",
+						SHA256:      "841eac6c710d7a5a64d25079cb3cf5d547034d841c21dd479fe17c9ace36ce53",
+						Format:      1,
+						Compression: "zstd",
 					},
 					{
-						Name:     "regatta-test2",
-						Type:     "REPLICATED",
-						FileName: "regatta-test2.bak",
-						MD5:      "df74e3ebc3b31f884245cf0efb3c9b6e",
+						Name:        "regatta-test2",
+						Type:        "REPLICATED",
+						FileName:    "regatta-test2.bak",
+						SHA256:      "b29c6a36d5b49b229eb3f7177b664fb0f2425647e0e45dc24e1544719f7f6c2b",
+						Format:      1,
+						Compression: "zstd",
 					},
 				},
 			},
@@ -177,7 +189,7 @@ func TestBackup_Backup(t *testing.T) {
 				}
 			}
 
-			srv := startBackupServer(tm)
+			srv := startBackupServer(nh, tm)
 			conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 			r.NoError(err)
 
@@ -195,6 +207,13 @@ func TestBackup_Backup(t *testing.T) {
 				return
 			}
 			r.NoError(err)
+			for i := range got.Tables {
+				// Only the tables with any data applied have the index set.
+				if len(tt.tableData[got.Tables[i].Name]) > 0 {
+					r.NotZero(got.Tables[i].Index)
+				}
+				got.Tables[i].Index = 0
+			}
 			r.Equal(tt.want, got)
 		})
 	}
@@ -253,7 +272,7 @@ func TestBackup_Restore(t *testing.T) {
 			r.NoError(tm.WaitUntilReady())
 			defer tm.Close()
 
-			srv := startBackupServer(tm)
+			srv := startBackupServer(nh, tm)
 			conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 			r.NoError(err)
 
@@ -273,6 +292,240 @@ func TestBackup_Restore(t *testing.T) {
 	}
 }
 
+func TestBackup_Incremental(t *testing.T) {
+	r := require.New(t)
+
+	nh, nodes, err := startRaftNode()
+	r.NoError(err)
+	defer nh.Close()
+	tm := table.NewManager(nh, nodes, table.Config{
+		NodeID: 1,
+		Table:  table.TableConfig{HeartbeatRTT: 1, ElectionRTT: 5, FS: pvfs.NewMem(), MaxInMemLogSize: 1024 * 1024, BlockCacheSize: 1024, TableCacheSize: 1024},
+		Meta:   table.MetaConfig{HeartbeatRTT: 1, ElectionRTT: 5},
+	})
+	r.NoError(tm.Start())
+	r.NoError(tm.WaitUntilReady())
+	defer tm.Close()
+
+	r.NoError(tm.CreateTable("regatta-test"))
+	time.Sleep(1 * time.Second)
+	put := func(k, v string) {
+		tbl, err := tm.GetTable("regatta-test")
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = tbl.Put(ctx, &regattapb.PutRequest{Key: []byte(k), Value: []byte(v)})
+		r.NoError(err)
+	}
+	rangeAll := func() []*regattapb.KeyValue {
+		tbl, err := tm.GetTable("regatta-test")
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		res, err := tbl.Range(ctx, &regattapb.RangeRequest{Key: []byte{0}, RangeEnd: []byte{0}, Linearizable: true})
+		r.NoError(err)
+		return res.Kvs
+	}
+
+	srv := startBackupServer(nh, tm)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+
+	dirs := []string{t.TempDir(), t.TempDir(), t.TempDir()}
+	backup := func(dir, since string) Manifest {
+		b := &Backup{Conn: conn, Dir: dir, Since: since, clock: clock.NewMock()}
+		m, err := b.Backup()
+		r.NoError(err)
+		r.Len(m.Tables, 1)
+		return m
+	}
+
+	put("foo", "bar")
+	full := backup(dirs[0], "")
+	r.Zero(full.Tables[0].SinceIndex)
+
+	put("foo", "baz")
+	put("foo2", "bar2")
+	inc1 := backup(dirs[1], dirs[0])
+	r.Equal(full.Tables[0].Index, inc1.Tables[0].SinceIndex)
+	r.Greater(inc1.Tables[0].Index, full.Tables[0].Index)
+
+	// No changes since the previous backup.
+	inc2 := backup(dirs[2], dirs[1])
+	r.Equal(inc1.Tables[0].Index, inc2.Tables[0].SinceIndex)
+	want := rangeAll()
+
+	restore := func(incrementals ...string) error {
+		b := &Backup{Conn: conn, Dir: dirs[0], Incrementals: incrementals, clock: clock.NewMock()}
+		return b.Restore()
+	}
+	r.ErrorContains(restore(dirs[2]), "gap between index")
+	r.ErrorContains(restore(dirs[1], dirs[1]), "gap between index")
+
+	put("foo3", "bar3")
+	r.NoError(restore(dirs[1], dirs[2]))
+	// The restored keys keep the revisions of the backed up ones.
+	r.Equal(want, rangeAll())
+
+	r.NoError(restore())
+	got := rangeAll()
+	r.Len(got, 1)
+	r.Equal([]byte("bar"), got[0].Value)
+}
+
+func TestBackup_S3(t *testing.T) {
+	r := require.New(t)
+
+	nh, nodes, err := startRaftNode()
+	r.NoError(err)
+	defer nh.Close()
+	tm := table.NewManager(nh, nodes, table.Config{
+		NodeID: 1,
+		Table:  table.TableConfig{HeartbeatRTT: 1, ElectionRTT: 5, FS: pvfs.NewMem(), MaxInMemLogSize: 1024 * 1024, BlockCacheSize: 1024, TableCacheSize: 1024},
+		Meta:   table.MetaConfig{HeartbeatRTT: 1, ElectionRTT: 5},
+	})
+	r.NoError(tm.Start())
+	r.NoError(tm.WaitUntilReady())
+	defer tm.Close()
+
+	r.NoError(tm.CreateTable("regatta-test"))
+	time.Sleep(1 * time.Second)
+	put := func(k, v string) {
+		tbl, err := tm.GetTable("regatta-test")
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = tbl.Put(ctx, &regattapb.PutRequest{Key: []byte(k), Value: []byte(v)})
+		r.NoError(err)
+	}
+	rangeAll := func() []*regattapb.KeyValue {
+		tbl, err := tm.GetTable("regatta-test")
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		res, err := tbl.Range(ctx, &regattapb.RangeRequest{Key: []byte{0}, RangeEnd: []byte{0}, Linearizable: true})
+		r.NoError(err)
+		return res.Kvs
+	}
+
+	srv := startBackupServer(nh, tm)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+
+	s3 := newFakeS3()
+	s3Srv := httptest.NewServer(s3)
+	defer s3Srv.Close()
+	open := func(location string) (Storage, error) {
+		return OpenStorage(location, S3Config{
+			Endpoint:  strings.TrimPrefix(s3Srv.URL, "http://"),
+			Region:    "us-east-1",
+			AccessKey: "access",
+			SecretKey: "secret",
+			Insecure:  true,
+		})
+	}
+
+	key, err := NewPassphraseKey([]byte("passphrase"))
+	r.NoError(err)
+
+	put("foo", "bar")
+	m, err := (&Backup{Conn: conn, Dir: "s3://bucket/full", Open: open, Key: key, clock: clock.NewMock()}).Backup()
+	r.NoError(err)
+	r.Equal(EncryptionAES256GCM, m.Tables[0].Encryption)
+	r.NotEmpty(m.Tables[0].KeyID)
+	put("foo2", "bar2")
+	_, err = (&Backup{Conn: conn, Dir: "s3://bucket/inc", Since: "s3://bucket/full", Open: open, Key: key, clock: clock.NewMock()}).Backup()
+	r.NoError(err)
+	r.Contains(s3.objects, "full/manifest.json")
+	r.Contains(s3.objects, "inc/regatta-test.bak")
+	want := rangeAll()
+
+	put("foo3", "bar3")
+	b := &Backup{Conn: conn, Dir: "s3://bucket/full", Incrementals: []string{"s3://bucket/inc"}, Open: open, clock: clock.NewMock()}
+	r.ErrorContains(b.Restore(), "key is required")
+
+	// The passphrase is provided anew as it would be on restore.
+	b.Key, err = NewPassphraseKey([]byte("passphrase"))
+	r.NoError(err)
+	r.NoError(b.Restore())
+	r.Equal(want, rangeAll())
+
+	b = &Backup{Conn: conn, Dir: "s3://bucket/missing", Open: open, clock: clock.NewMock()}
+	r.Error(b.Restore())
+}
+
+func TestBackup_PointInTime(t *testing.T) {
+	r := require.New(t)
+
+	nh, nodes, err := startRaftNode()
+	r.NoError(err)
+	defer nh.Close()
+	tm := table.NewManager(nh, nodes, table.Config{
+		NodeID: 1,
+		Table:  table.TableConfig{HeartbeatRTT: 1, ElectionRTT: 5, FS: pvfs.NewMem(), MaxInMemLogSize: 1024 * 1024, BlockCacheSize: 1024, TableCacheSize: 1024},
+		Meta:   table.MetaConfig{HeartbeatRTT: 1, ElectionRTT: 5},
+	})
+	r.NoError(tm.Start())
+	r.NoError(tm.WaitUntilReady())
+	defer tm.Close()
+
+	r.NoError(tm.CreateTable("regatta-test"))
+	time.Sleep(1 * time.Second)
+	tbl, err := tm.GetTable("regatta-test")
+	r.NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	rangeAll := func(name string) []*regattapb.KeyValue {
+		tbl, err := tm.GetTable(name)
+		r.NoError(err)
+		res, err := tbl.Range(ctx, &regattapb.RangeRequest{Key: []byte{0}, RangeEnd: []byte{0}, Linearizable: true})
+		r.NoError(err)
+		return res.Kvs
+	}
+
+	_, err = tbl.Put(ctx, &regattapb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	r.NoError(err)
+
+	srv := startBackupServer(nh, tm)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	dirs := []string{t.TempDir(), t.TempDir()}
+	_, err = (&Backup{Conn: conn, Dir: dirs[0], clock: clock.NewMock()}).Backup()
+	r.NoError(err)
+
+	_, err = tbl.Put(ctx, &regattapb.PutRequest{Key: []byte("foo2"), Value: []byte("bar2")})
+	r.NoError(err)
+	want := rangeAll("regatta-test")
+	// Accidental delete of all the keys.
+	del, err := tbl.Delete(ctx, &regattapb.DeleteRangeRequest{Key: []byte{0}, RangeEnd: []byte{0}})
+	r.NoError(err)
+	inc, err := (&Backup{Conn: conn, Dir: dirs[1], Since: dirs[0], clock: clock.NewMock()}).Backup()
+	r.NoError(err)
+
+	restore := func(b *Backup) error {
+		b.Conn, b.Dir, b.Incrementals, b.clock = conn, dirs[0], dirs[1:], clock.NewMock()
+		return b.Restore()
+	}
+	r.ErrorContains(restore(&Backup{TargetTable: "regatta-test-restored"}), "target table requires the table")
+	r.ErrorContains(restore(&Backup{Table: "unknown"}), "table 'unknown' is not in the backup")
+	r.ErrorContains(restore(&Backup{Revision: 1}), "was taken after the revision 1")
+
+	r.NoError(restore(&Backup{Table: "regatta-test", TargetTable: "regatta-test-restored", Revision: int64(del.Header.Revision) - 1}))
+	r.Equal(want, rangeAll("regatta-test-restored"))
+	// The restored table is untouched.
+	r.Empty(rangeAll("regatta-test"))
+
+	r.NoError(restore(&Backup{Table: "regatta-test", TargetTable: "regatta-test-latest", Revision: int64(inc.Tables[0].Index)}))
+	r.Empty(rangeAll("regatta-test-latest"))
+
+	// Only the full backup precedes the time.
+	r.NoError(restore(&Backup{Table: "regatta-test", TargetTable: "regatta-test-time", Timestamp: time.Now().Add(-time.Hour)}))
+	got := rangeAll("regatta-test-time")
+	r.Len(got, 1)
+	r.Equal([]byte("foo"), got[0].Key)
+	r.ErrorContains(restore(&Backup{Table: "regatta-test", Timestamp: time.Unix(0, 0).Add(-time.Hour)}), "was taken after")
+}
+
 func TestBackup_ensureDefaults(t *testing.T) {
 	type fields struct {
 		Conn    *grpc.ClientConn
@@ -349,11 +602,11 @@ func startRaftNode() (*dragonboat.NodeHost, map[uint64]string, error) {
 	return nh, map[uint64]string{1: testNodeAddress}, nil
 }
 
-func startBackupServer(manager *table.Manager) *regattaserver.RegattaServer {
+func startBackupServer(nh *dragonboat.NodeHost, manager *table.Manager) *regattaserver.RegattaServer {
 	testNodeAddress := fmt.Sprintf("127.0.0.1:%d", getTestPort())
 	server := regattaserver.NewServer(testNodeAddress, false)
 	regattapb.RegisterMetadataServer(server, &regattaserver.MetadataServer{Tables: manager})
-	regattapb.RegisterMaintenanceServer(server, &regattaserver.BackupServer{Tables: manager})
+	regattapb.RegisterMaintenanceServer(server, &regattaserver.BackupServer{Tables: manager, LogReader: &logreader.Simple{LogQuerier: nh}})
 	go func() {
 		err := server.ListenAndServe()
 		if err != nil {
@@ -0,0 +1,368 @@
+// Copyright JAMF Software, LLC
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The backup file format version 1 consists of:
+//
+//	magic (4 bytes) | version (1 byte) | header length (4 bytes, big endian) | JSON header | payload
+//
+// The payload is the zstd compressed snapshot of the table with the snappy framing removed. If encrypted the compressed
+// payload is split into segments sealed by AES-GCM using a random data key, the data key is sealed by the backup key
+// and stored in the header. The whole header is authenticated as the additional data of each segment.
+// Files written before the format was introduced are plain snappy framed snapshots without any header.
+const (
+	formatVersion = 1
+	formatMagic   = "RGBK"
+
+	CompressionZstd     = "zstd"
+	EncryptionAES256GCM = "AES-256-GCM"
+
+	segmentSize = 64 * 1024
+	// segmentNonceSize the nonce prefix size, the nonce ends with the segment counter and the last segment flag.
+	segmentNonceSize = 7
+)
+
+var dataKeyLabel = []byte("regatta backup data key")
+
+// fileHeader the header of the backup file describing how the snapshot is encoded.
+type fileHeader struct {
+	Compression string `json:"compression"`
+	Encryption  string `json:"encryption,omitempty"`
+	KeyID       string `json:"key_id,omitempty"`
+	// Salt the salt the key was derived from the passphrase by if the key is a passphrase.
+	Salt []byte `json:"salt,omitempty"`
+	// DataKey the data key sealed by the key.
+	DataKey []byte `json:"data_key,omitempty"`
+	// Nonce the nonce prefix of the segments.
+	Nonce []byte `json:"nonce,omitempty"`
+}
+
+// fileWriter encodes the snapshot stream written into the backup file format.
+type fileWriter struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+// newFileWriter returns the writer encoding the snapshot stream into w, the file is encrypted if the key is set.
+// The writer must be closed to flush the file.
+func newFileWriter(w io.Writer, key *Key) (*fileWriter, fileHeader, error) {
+	h := fileHeader{Compression: CompressionZstd}
+	var dataKey []byte
+	if key != nil {
+		secret, salt, err := key.encryptionKey()
+		if err != nil {
+			return nil, h, err
+		}
+		dataKey = make([]byte, keySize)
+		if _, err := rand.Read(dataKey); err != nil {
+			return nil, h, err
+		}
+		sealed, err := seal(secret, dataKey, dataKeyLabel)
+		if err != nil {
+			return nil, h, err
+		}
+		h.Encryption, h.KeyID, h.Salt, h.DataKey = EncryptionAES256GCM, keyID(secret), salt, sealed
+		h.Nonce = make([]byte, segmentNonceSize)
+		if _, err := rand.Read(h.Nonce); err != nil {
+			return nil, h, err
+		}
+	}
+
+	prefix, err := encodeHeader(h)
+	if err != nil {
+		return nil, h, err
+	}
+	if _, err := w.Write(prefix); err != nil {
+		return nil, h, err
+	}
+
+	dst := w
+	var sw *segmentWriter
+	if dataKey != nil {
+		aead, err := newGCM(dataKey)
+		if err != nil {
+			return nil, h, err
+		}
+		sw = &segmentWriter{w: w, aead: aead, nonce: h.Nonce, ad: prefix}
+		dst = sw
+	}
+	zw, err := zstd.NewWriter(dst)
+	if err != nil {
+		return nil, h, err
+	}
+
+	pr, pw := io.Pipe()
+	fw := &fileWriter{pw: pw, done: make(chan error, 1)}
+	go func() {
+		_, err := io.Copy(zw, snappy.NewReader(pr))
+		if cerr := zw.Close(); err == nil {
+			err = cerr
+		}
+		if sw != nil && err == nil {
+			err = sw.Close()
+		}
+		_ = pr.CloseWithError(err)
+		fw.done <- err
+	}()
+	return fw, h, nil
+}
+
+func (f *fileWriter) Write(p []byte) (int, error) {
+	return f.pw.Write(p)
+}
+
+func (f *fileWriter) Close() error {
+	_ = f.pw.Close()
+	return <-f.done
+}
+
+// newFileReader returns the reader of the snapshot stream stored in the backup file, the file format is detected.
+func newFileReader(r io.Reader, key *Key) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(formatMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	// Files without the header hold the plain snapshot.
+	if string(magic) != formatMagic {
+		return io.NopCloser(br), nil
+	}
+
+	h, prefix, err := decodeHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if h.Compression != CompressionZstd {
+		return nil, fmt.Errorf("unsupported backup file compression '%s'", h.Compression)
+	}
+
+	var src io.Reader = br
+	switch h.Encryption {
+	case "":
+	case EncryptionAES256GCM:
+		if key == nil {
+			return nil, fmt.Errorf("backup file is encrypted with the key '%s', key is required", h.KeyID)
+		}
+		secret, err := key.decryptionKey(h)
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := open(secret, h.DataKey, dataKeyLabel)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt the backup file data key: %w", err)
+		}
+		aead, err := newGCM(dataKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(h.Nonce) != segmentNonceSize {
+			return nil, errors.New("invalid backup file nonce")
+		}
+		src = &segmentReader{r: br, aead: aead, nonce: h.Nonce, ad: prefix}
+	default:
+		return nil, fmt.Errorf("unsupported backup file encryption '%s'", h.Encryption)
+	}
+
+	zr, err := zstd.NewReader(src)
+	if err != nil {
+		return nil, err
+	}
+	// The snapshot is streamed to the server snappy framed.
+	pr, pw := io.Pipe()
+	go func() {
+		defer zr.Close()
+		sw := snappy.NewBufferedWriter(pw)
+		_, err := io.Copy(sw, zr)
+		if cerr := sw.Close(); err == nil {
+			err = cerr
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+func encodeHeader(h fileHeader) ([]byte, error) {
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(formatMagic)+5+len(hb)))
+	buf.WriteString(formatMagic)
+	buf.WriteByte(formatVersion)
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(hb)))
+	buf.Write(hb)
+	return buf.Bytes(), nil
+}
+
+// decodeHeader reads the header of the file and returns it along with the raw bytes read.
+func decodeHeader(r io.Reader) (fileHeader, []byte, error) {
+	h := fileHeader{}
+	prefix := make([]byte, len(formatMagic)+5)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return h, nil, fmt.Errorf("could not read the backup file header: %w", err)
+	}
+	if v := prefix[len(formatMagic)]; v != formatVersion {
+		return h, nil, fmt.Errorf("unsupported backup file format version %d", v)
+	}
+	size := binary.BigEndian.Uint32(prefix[len(formatMagic)+1:])
+	if size > math.MaxUint16 {
+		return h, nil, fmt.Errorf("backup file header too large (%d bytes)", size)
+	}
+	hb := make([]byte, size)
+	if _, err := io.ReadFull(r, hb); err != nil {
+		return h, nil, fmt.Errorf("could not read the backup file header: %w", err)
+	}
+	if err := json.Unmarshal(hb, &h); err != nil {
+		return h, nil, fmt.Errorf("invalid backup file header: %w", err)
+	}
+	return h, append(prefix, hb...), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext by the key using a random nonce prepended to the ciphertext.
+func seal(key, plaintext, ad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func open(key, ciphertext, ad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], ad)
+}
+
+// segmentNonce returns the nonce of the segment, the last segment is marked so that a truncated file is detected.
+func segmentNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[segmentNonceSize:], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// segmentWriter seals the data written in segments of segmentSize, the last segment is sealed on Close.
+type segmentWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	nonce   []byte
+	ad      []byte
+	buf     []byte
+	counter uint32
+}
+
+func (s *segmentWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// The full segment is sealed only once more data follow, the last segment is sealed on Close.
+		if len(s.buf) == segmentSize {
+			if err := s.flush(false); err != nil {
+				return 0, err
+			}
+		}
+		c := min(segmentSize-len(s.buf), len(p))
+		s.buf = append(s.buf, p[:c]...)
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (s *segmentWriter) Close() error {
+	return s.flush(true)
+}
+
+func (s *segmentWriter) flush(last bool) error {
+	if s.counter == math.MaxUint32 {
+		return errors.New("backup file too large")
+	}
+	_, err := s.w.Write(s.aead.Seal(nil, segmentNonce(s.nonce, s.counter, last), s.buf, s.ad))
+	s.counter++
+	s.buf = s.buf[:0]
+	return err
+}
+
+// segmentReader opens the segments sealed by the segmentWriter.
+type segmentReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	nonce   []byte
+	ad      []byte
+	buf     []byte
+	counter uint32
+	last    bool
+}
+
+func (s *segmentReader) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.last {
+			return 0, io.EOF
+		}
+		if err := s.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *segmentReader) next() error {
+	sealed := make([]byte, segmentSize+s.aead.Overhead())
+	n, err := io.ReadFull(s.r, sealed)
+	switch {
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("backup file truncated: %w", io.ErrUnexpectedEOF)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		s.last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := s.r.Peek(1); errors.Is(err, io.EOF) {
+			s.last = true
+		}
+	}
+	plaintext, err := s.aead.Open(sealed[:0], segmentNonce(s.nonce, s.counter, s.last), sealed[:n], s.ad)
+	if err != nil {
+		return fmt.Errorf("backup file segment %d could not be decrypted: %w", s.counter, err)
+	}
+	s.counter++
+	s.buf = plaintext
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright JAMF Software, LLC
+
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_RoundTrip(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, bytes.Repeat([]byte{1}, keySize), 0o600))
+	fileKey, err := LoadKeyFile(keyFile)
+	require.NoError(t, err)
+	passKey, err := NewPassphraseKey([]byte("passphrase"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  *Key
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "plain", data: []byte("snapshot data")},
+		{name: "key file", key: fileKey, data: []byte("snapshot data")},
+		{name: "passphrase", key: passKey, data: []byte("snapshot data")},
+		{name: "empty encrypted", key: fileKey, data: nil},
+		{name: "multiple segments", key: fileKey, data: randomBytes(t, 3*segmentSize+17)},
+		{name: "segment boundary", key: fileKey, data: randomBytes(t, segmentSize)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			file, h := writeFile(t, tt.key, tt.data)
+			r.Equal(CompressionZstd, h.Compression)
+			if tt.key != nil {
+				r.Equal(EncryptionAES256GCM, h.Encryption)
+				r.NotEmpty(h.KeyID)
+				r.NotContains(string(file), "snapshot data")
+			}
+			r.Equal(tt.data, readBackupFile(t, file, tt.key))
+		})
+	}
+}
+
+func TestFile_Legacy(t *testing.T) {
+	r := require.New(t)
+	legacy := snappyFrame(t, []byte("snapshot data"))
+	fr, err := newFileReader(bytes.NewReader(legacy), nil)
+	r.NoError(err)
+	got, err := io.ReadAll(fr)
+	r.NoError(err)
+	r.Equal(legacy, got)
+
+	fr, err = newFileReader(bytes.NewReader(nil), nil)
+	r.NoError(err)
+	got, err = io.ReadAll(fr)
+	r.NoError(err)
+	r.Empty(got)
+}
+
+func TestFile_Errors(t *testing.T) {
+	r := require.New(t)
+	key, err := NewPassphraseKey([]byte("passphrase"))
+	r.NoError(err)
+	data := randomBytes(t, 2*segmentSize)
+	file, _ := writeFile(t, key, data)
+
+	_, err = newFileReader(bytes.NewReader(file), nil)
+	r.ErrorContains(err, "key is required")
+
+	other, err := NewPassphraseKey([]byte("other"))
+	r.NoError(err)
+	_, err = newFileReader(bytes.NewReader(file), other)
+	r.ErrorContains(err, "is encrypted with the key")
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	r.NoError(os.WriteFile(keyFile, []byte(hex.EncodeToString(bytes.Repeat([]byte{1}, keySize))+"\n"), 0o600))
+	fileKey, err := LoadKeyFile(keyFile)
+	r.NoError(err)
+	_, err = newFileReader(bytes.NewReader(file), fileKey)
+	r.ErrorContains(err, "passphrase is required")
+
+	// Truncated to the segment boundary.
+	truncated := file[:len(file)-(len(file)-headerLen(t, file))%(segmentSize+16)]
+	fr, err := newFileReader(bytes.NewReader(truncated), key)
+	r.NoError(err)
+	_, err = io.ReadAll(fr)
+	r.Error(err)
+
+	tampered := bytes.Clone(file)
+	tampered[len(tampered)-1] ^= 1
+	fr, err = newFileReader(bytes.NewReader(tampered), key)
+	r.NoError(err)
+	_, err = io.ReadAll(fr)
+	r.ErrorContains(err, "could not be decrypted")
+
+	unsupported := bytes.Clone(file)
+	unsupported[len(formatMagic)] = formatVersion + 1
+	_, err = newFileReader(bytes.NewReader(unsupported), key)
+	r.ErrorContains(err, "unsupported backup file format version")
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	r := require.New(t)
+	secret := randomBytes(t, keySize)
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{
+		"raw":    secret,
+		"hex":    []byte(hex.EncodeToString(secret) + "\n"),
+		"base64": []byte(" " + base64.StdEncoding.EncodeToString(secret) + "\n"),
+	} {
+		p := filepath.Join(dir, name)
+		r.NoError(os.WriteFile(p, content, 0o600))
+		key, err := LoadKeyFile(p)
+		r.NoError(err, name)
+		r.Equal(secret, key.secret, name)
+	}
+
+	p := filepath.Join(dir, "short")
+	r.NoError(os.WriteFile(p, secret[:16], 0o600))
+	_, err := LoadKeyFile(p)
+	r.Error(err)
+
+	_, err = NewPassphraseKey(nil)
+	r.Error(err)
+}
+
+func writeFile(t *testing.T, key *Key, data []byte) ([]byte, fileHeader) {
+	r := require.New(t)
+	buf := &bytes.Buffer{}
+	fw, h, err := newFileWriter(buf, key)
+	r.NoError(err)
+	_, err = fw.Write(snappyFrame(t, data))
+	r.NoError(err)
+	r.NoError(fw.Close())
+	return buf.Bytes(), h
+}
+
+func readBackupFile(t *testing.T, file []byte, key *Key) []byte {
+	r := require.New(t)
+	fr, err := newFileReader(bytes.NewReader(file), key)
+	r.NoError(err)
+	defer func() {
+		_ = fr.Close()
+	}()
+	got, err := io.ReadAll(snappy.NewReader(fr))
+	r.NoError(err)
+	if len(got) == 0 {
+		return nil
+	}
+	return got
+}
+
+func headerLen(t *testing.T, file []byte) int {
+	_, prefix, err := decodeHeader(bytes.NewReader(file))
+	require.NoError(t, err)
+	return len(prefix)
+}
+
+func snappyFrame(t *testing.T, data []byte) []byte {
+	buf := &bytes.Buffer{}
+	w := snappy.NewBufferedWriter(buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
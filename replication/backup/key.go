@@ -0,0 +1,125 @@
+// Copyright JAMF Software, LLC
+
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keySize  = 32
+	saltSize = 16
+	// scrypt parameters recommended for the interactive logins as of 2017.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+var keyIDLabel = []byte("regatta backup key id")
+
+// Key the key encrypting the data keys of the backup files. It is either read from a key file
+// or derived from a passphrase, the passphrase derived key is salted by a random salt stored in the backup files.
+type Key struct {
+	secret     []byte
+	salt       []byte
+	passphrase []byte
+	// derived the keys derived from the passphrase by the salt of the backup files read.
+	derived map[string][]byte
+}
+
+// LoadKeyFile reads the 256-bit key from the file, the key is stored either as raw bytes or hex or base64 encoded.
+func LoadKeyFile(path string) (*Key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == keySize {
+		return &Key{secret: b}, nil
+	}
+	text := string(bytes.TrimSpace(b))
+	if secret, err := hex.DecodeString(text); err == nil && len(secret) == keySize {
+		return &Key{secret: secret}, nil
+	}
+	if secret, err := base64.StdEncoding.DecodeString(text); err == nil && len(secret) == keySize {
+		return &Key{secret: secret}, nil
+	}
+	return nil, fmt.Errorf("key file '%s' does not contain a %d-bit key", path, keySize*8)
+}
+
+// NewPassphraseKey returns the key derived from the passphrase.
+func NewPassphraseKey(passphrase []byte) (*Key, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase must not be empty")
+	}
+	return &Key{passphrase: passphrase, derived: make(map[string][]byte)}, nil
+}
+
+// encryptionKey returns the key new backup files are encrypted with along with the salt it was derived by if any.
+func (k *Key) encryptionKey() (secret []byte, salt []byte, err error) {
+	if k.passphrase == nil {
+		return k.secret, nil, nil
+	}
+	// The key is derived once so that all the files of the backup share the key ID.
+	if k.secret == nil {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, nil, err
+		}
+		secret, err := k.derive(salt)
+		if err != nil {
+			return nil, nil, err
+		}
+		k.secret, k.salt = secret, salt
+	}
+	return k.secret, k.salt, nil
+}
+
+// decryptionKey returns the key the backup file with the header was encrypted with.
+func (k *Key) decryptionKey(h fileHeader) ([]byte, error) {
+	secret := k.secret
+	switch {
+	case h.Salt != nil && k.passphrase == nil:
+		return nil, errors.New("backup file is encrypted with a passphrase, passphrase is required")
+	case h.Salt == nil && k.passphrase != nil:
+		return nil, errors.New("backup file is encrypted with a key file, key file is required")
+	case h.Salt != nil:
+		var err error
+		secret, err = k.derive(h.Salt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if id := keyID(secret); id != h.KeyID {
+		return nil, fmt.Errorf("backup file is encrypted with the key '%s', the key provided is '%s'", h.KeyID, id)
+	}
+	return secret, nil
+}
+
+func (k *Key) derive(salt []byte) ([]byte, error) {
+	if secret, ok := k.derived[string(salt)]; ok {
+		return secret, nil
+	}
+	secret, err := scrypt.Key(k.passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	k.derived[string(salt)] = secret
+	return secret, nil
+}
+
+// keyID returns the identifier of the key, the key could not be recovered from the identifier.
+func keyID(secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(keyIDLabel)
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
@@ -0,0 +1,138 @@
+// Copyright JAMF Software, LLC
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	defaultS3Endpoint = "s3.amazonaws.com"
+	defaultS3PartSize = 16 * 1024 * 1024
+)
+
+var errUploadAborted = errors.New("upload aborted")
+
+// S3Config the configuration of the S3-compatible object storage.
+type S3Config struct {
+	// Endpoint the host and the optional port of the object storage (AWS S3 if empty).
+	Endpoint string
+	// Region the region of the bucket, looked up if empty.
+	Region string
+	// AccessKey the access key, the credentials are read from the environment or the instance metadata if empty.
+	AccessKey string
+	// SecretKey the secret key.
+	SecretKey string
+	// Insecure disables TLS.
+	Insecure bool
+	// PartSize the size of the parts the files are uploaded in, the files are limited to 10000 parts.
+	PartSize uint64
+	// Transport the HTTP transport used, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// S3Storage stores the backup files as objects of the S3-compatible object storage.
+// The files are uploaded using a multipart upload so that the object is created only once the whole file is uploaded.
+type S3Storage struct {
+	client   *minio.Client
+	bucket   string
+	prefix   string
+	partSize uint64
+}
+
+// NewS3Storage returns the storage of the bucket, the file names are prefixed by the prefix.
+func NewS3Storage(bucket, prefix string, cfg S3Config) (*S3Storage, error) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultS3Endpoint
+	}
+	if cfg.PartSize == 0 {
+		cfg.PartSize = defaultS3PartSize
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+	creds := credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, "")
+	if cfg.AccessKey == "" {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+			&credentials.IAM{Client: &http.Client{Transport: cfg.Transport}},
+		})
+	}
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:     creds,
+		Secure:    !cfg.Insecure,
+		Region:    cfg.Region,
+		Transport: cfg.Transport,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Storage{client: client, bucket: bucket, prefix: prefix, partSize: cfg.PartSize}, nil
+}
+
+// Create starts the upload of the file, the data are uploaded in parts as they are written.
+func (s *S3Storage) Create(ctx context.Context, name string) (File, error) {
+	pr, pw := io.Pipe()
+	f := &s3File{pw: pw, done: make(chan error, 1)}
+	go func() {
+		// Upload of unknown size is always done as a multipart upload which is aborted if the reader fails.
+		_, err := s.client.PutObject(ctx, s.bucket, s.key(name), pr, -1, minio.PutObjectOptions{
+			PartSize:    s.partSize,
+			ContentType: "application/octet-stream",
+		})
+		_ = pr.CloseWithError(err)
+		f.done <- err
+	}()
+	return f, nil
+}
+
+func (s *S3Storage) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.key(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// The request is sent lazily, stat the object to report the missing file right away.
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s *S3Storage) String() string {
+	return s3Scheme + path.Join(s.bucket, s.prefix)
+}
+
+func (s *S3Storage) key(name string) string {
+	return path.Join(s.prefix, name)
+}
+
+type s3File struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func (f *s3File) Write(p []byte) (int, error) {
+	return f.pw.Write(p)
+}
+
+func (f *s3File) Commit() error {
+	_ = f.pw.Close()
+	return <-f.done
+}
+
+func (f *s3File) Abort() error {
+	_ = f.pw.CloseWithError(errUploadAborted)
+	if err := <-f.done; err != nil && !errors.Is(err, errUploadAborted) {
+		return err
+	}
+	return nil
+}
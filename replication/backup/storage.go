@@ -0,0 +1,111 @@
+// Copyright JAMF Software, LLC
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const s3Scheme = "s3://"
+
+// Storage a destination the backup files are written to and read from.
+type Storage interface {
+	// Create creates the named file, the file is visible only after it is committed.
+	Create(ctx context.Context, name string) (File, error)
+	// Open opens the named file for reading.
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// String returns the location of the storage.
+	String() string
+}
+
+// File a backup file being written.
+type File interface {
+	io.Writer
+	// Commit makes the written file visible under its name, replacing the file of the same name.
+	Commit() error
+	// Abort discards the written data, the file of the same name if any is left intact.
+	Abort() error
+}
+
+// OpenStorage opens the storage at the location. The location is either a local directory (current directory if empty)
+// or an S3 bucket with the optional key prefix in the s3://bucket/prefix form.
+func OpenStorage(location string, cfg S3Config) (Storage, error) {
+	if rest, ok := strings.CutPrefix(location, s3Scheme); ok {
+		bucket, prefix, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("'%s' is missing the bucket name", location)
+		}
+		return NewS3Storage(bucket, prefix, cfg)
+	}
+	return NewLocalStorage(location)
+}
+
+// LocalStorage stores the backup files in a local directory.
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage returns the storage of the existing directory, the current directory is used if the dir is empty.
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := checkDir(dir); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{dir: dir}, nil
+}
+
+// Create creates a temporary file in the directory that is renamed to the name on commit.
+func (l *LocalStorage) Create(_ context.Context, name string) (File, error) {
+	f, err := os.CreateTemp(l.dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &localFile{File: f, path: filepath.Join(l.dir, name)}, nil
+}
+
+func (l *LocalStorage) Open(_ context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(l.dir, name))
+}
+
+func (l *LocalStorage) String() string {
+	return l.dir
+}
+
+type localFile struct {
+	*os.File
+	path string
+}
+
+func (f *localFile) Commit() error {
+	if err := f.Sync(); err != nil {
+		_ = f.Abort()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), f.path)
+}
+
+func (f *localFile) Abort() error {
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+func checkDir(dir string) error {
+	if dir != "" {
+		stat, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !stat.IsDir() {
+			return fmt.Errorf("'%s' is not a directory", dir)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright JAMF Software, LLC
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStorage(t *testing.T) {
+	r := require.New(t)
+
+	st, err := OpenStorage(t.TempDir(), S3Config{})
+	r.NoError(err)
+	r.IsType(&LocalStorage{}, st)
+
+	_, err = OpenStorage(filepath.Join(t.TempDir(), "missing"), S3Config{})
+	r.Error(err)
+
+	st, err = OpenStorage("s3://bucket/backups/daily", S3Config{})
+	r.NoError(err)
+	r.Equal("s3://bucket/backups/daily", st.String())
+	r.Equal("backups/daily/manifest.json", st.(*S3Storage).key(manifestFileName))
+
+	st, err = OpenStorage("s3://bucket", S3Config{})
+	r.NoError(err)
+	r.Equal("manifest.json", st.(*S3Storage).key(manifestFileName))
+
+	_, err = OpenStorage("s3://", S3Config{})
+	r.Error(err)
+}
+
+func TestLocalStorage(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	st, err := NewLocalStorage(dir)
+	r.NoError(err)
+	testStorage(t, st)
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Len(entries, 1)
+	r.Equal("file", entries[0].Name())
+}
+
+func TestS3Storage(t *testing.T) {
+	r := require.New(t)
+	srv := newFakeS3()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	st, err := NewS3Storage("bucket", "prefix", S3Config{
+		Endpoint:  strings.TrimPrefix(ts.URL, "http://"),
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Insecure:  true,
+		PartSize:  5 * 1024 * 1024,
+	})
+	r.NoError(err)
+	testStorage(t, st)
+
+	// The file larger than the part size is uploaded in multiple parts.
+	f, err := st.Create(context.Background(), "large")
+	r.NoError(err)
+	data := bytes.Repeat([]byte("0123456789"), 1024*1024+1)
+	_, err = f.Write(data)
+	r.NoError(err)
+	r.NoError(f.Commit())
+	r.Equal(3, srv.parts["prefix/large"])
+
+	rc, err := st.Open(context.Background(), "large")
+	r.NoError(err)
+	got, err := io.ReadAll(rc)
+	r.NoError(err)
+	r.NoError(rc.Close())
+	r.Equal(data, got)
+
+	// No multipart upload is left behind.
+	r.Empty(srv.uploads)
+}
+
+func testStorage(t *testing.T, st Storage) {
+	r := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := st.Open(ctx, "file")
+	r.Error(err)
+
+	f, err := st.Create(ctx, "file")
+	r.NoError(err)
+	_, err = f.Write([]byte("data"))
+	r.NoError(err)
+
+	// The file is not visible before commit.
+	_, err = st.Open(ctx, "file")
+	r.Error(err)
+
+	r.NoError(f.Commit())
+	r.Equal("data", readFile(t, st, "file"))
+
+	// Aborted file leaves the previous file intact.
+	f, err = st.Create(ctx, "file")
+	r.NoError(err)
+	_, err = f.Write([]byte("aborted"))
+	r.NoError(err)
+	r.NoError(f.Abort())
+	r.Equal("data", readFile(t, st, "file"))
+
+	// Committed file replaces the previous file.
+	f, err = st.Create(ctx, "file")
+	r.NoError(err)
+	_, err = f.Write([]byte("replaced"))
+	r.NoError(err)
+	r.NoError(f.Commit())
+	r.Equal("replaced", readFile(t, st, "file"))
+}
+
+func readFile(t *testing.T, st Storage, name string) string {
+	r := require.New(t)
+	rc, err := st.Open(context.Background(), name)
+	r.NoError(err)
+	defer func() {
+		_ = rc.Close()
+	}()
+	b, err := io.ReadAll(rc)
+	r.NoError(err)
+	return string(b)
+}
+
+// fakeS3 an in-memory stand-in of the S3 API supporting the object reads and multipart uploads.
+type fakeS3 struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+	created map[string]time.Time
+	parts   map[string]int
+	uploads map[string]map[int][]byte
+	nextID  int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: make(map[string][]byte),
+		created: make(map[string]time.Time),
+		parts:   make(map[string]int),
+		uploads: make(map[string]map[int][]byte),
+	}
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	if bucket != "bucket" {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	q := req.URL.Query()
+	switch {
+	case req.Method == http.MethodPost && q.Has("uploads"):
+		s.nextID++
+		id := strconv.Itoa(s.nextID)
+		s.uploads[id] = make(map[int][]byte)
+		writeXML(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			Bucket   string
+			Key      string
+			UploadID string `xml:"UploadId"`
+		}{Bucket: bucket, Key: key, UploadID: id})
+	case req.Method == http.MethodPut && q.Has("uploadId"):
+		parts, ok := s.uploads[q.Get("uploadId")]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		body, err := readS3Body(req)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		parts[n] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, n))
+	case req.Method == http.MethodPost && q.Has("uploadId"):
+		parts, ok := s.uploads[q.Get("uploadId")]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		var nums []int
+		for n := range parts {
+			nums = append(nums, n)
+		}
+		sort.Ints(nums)
+		var obj []byte
+		for _, n := range nums {
+			obj = append(obj, parts[n]...)
+		}
+		s.objects[key] = obj
+		s.created[key] = time.Now()
+		s.parts[key] = len(nums)
+		delete(s.uploads, q.Get("uploadId"))
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			Bucket  string
+			Key     string
+			ETag    string
+		}{Bucket: bucket, Key: key, ETag: `"object"`})
+	case req.Method == http.MethodDelete && q.Has("uploadId"):
+		delete(s.uploads, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		obj, ok := s.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", `"object"`)
+		http.ServeContent(w, req, key, s.created[key], bytes.NewReader(obj))
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+// readS3Body reads the request body, decoding the chunks of the payload signed in the streaming mode.
+func readS3Body(req *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(req.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(req.Body)
+	}
+	var body []byte
+	br := bufio.NewReader(req.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		n, err := strconv.ParseInt(size, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return body, nil
+		}
+		chunk := make([]byte, n+2)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		body = append(body, chunk[:n]...)
+	}
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+	}{Code: code})
+}
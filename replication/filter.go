@@ -0,0 +1,250 @@
+// Copyright JAMF Software, LLC
+
+package replication
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/fsm"
+)
+
+// errTxnFiltered the txn compares the keys without the prefixes and writes the keys with them, the follower could not tell
+// which branch the leader took.
+var errTxnFiltered = errors.New("txn compares the keys outside of the replicated key prefixes")
+
+// Filter selects the tables and the keys replicated to the follower.
+type Filter struct {
+	// IncludeTables glob patterns of the tables to replicate, all the tables are replicated if empty.
+	IncludeTables []string
+	// ExcludeTables glob patterns of the tables not to replicate, takes precedence over IncludeTables.
+	ExcludeTables []string
+	// KeyPrefixes the prefixes of the keys to replicate per table, all the keys are replicated if the table has no prefixes.
+	KeyPrefixes map[string][][]byte
+}
+
+// Validate checks that the table patterns are well-formed.
+func (f Filter) Validate() error {
+	for _, patterns := range [][]string{f.IncludeTables, f.ExcludeTables} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid table pattern '%s': %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Table reports whether the table should be replicated.
+func (f Filter) Table(name string) bool {
+	if matchAny(f.ExcludeTables, name) {
+		return false
+	}
+	return len(f.IncludeTables) == 0 || matchAny(f.IncludeTables, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefix reports whether the key has any of the prefixes, any key matches empty prefixes.
+func hasPrefix(prefixes [][]byte, key []byte) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeHasPrefix reports whether all the keys of the range [key, rangeEnd) have any of the prefixes.
+func rangeHasPrefix(prefixes [][]byte, key, rangeEnd []byte) bool {
+	if len(rangeEnd) == 0 {
+		return hasPrefix(prefixes, key)
+	}
+	for _, prefix := range prefixes {
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		if end := prefixEnd(prefix); end == nil || bytes.Compare(rangeEnd, end) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixEnd returns the first key without the prefix that sorts after all the keys with the prefix, nil if there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// txnComparesFiltered reports whether any compare of the txn or the nested txns reads a key without any of the prefixes.
+func txnComparesFiltered(prefixes [][]byte, txn *regattapb.Txn) bool {
+	for _, c := range txn.GetCompare() {
+		if !rangeHasPrefix(prefixes, c.Key, c.RangeEnd) {
+			return true
+		}
+	}
+	for _, ops := range [][]*regattapb.RequestOp{txn.GetSuccess(), txn.GetFailure()} {
+		for _, op := range ops {
+			if r, ok := op.Request.(*regattapb.RequestOp_RequestTxn); ok && txnComparesFiltered(prefixes, r.RequestTxn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// txnWrites reports whether any branch of the txn or the nested txns writes any key.
+func txnWrites(txn *regattapb.Txn) bool {
+	for _, ops := range [][]*regattapb.RequestOp{txn.GetSuccess(), txn.GetFailure()} {
+		for _, op := range ops {
+			switch r := op.Request.(type) {
+			case *regattapb.RequestOp_RequestPut, *regattapb.RequestOp_RequestDeleteRange, *regattapb.RequestOp_RequestIncrement:
+				return true
+			case *regattapb.RequestOp_RequestTxn:
+				if txnWrites(r.RequestTxn) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// filterCommand removes the writes of the keys without any of the prefixes from the command, the command is modified in place.
+// Deletes are kept as they could only affect the keys present in the follower. DUMMY command is returned if no write remains.
+// INGEST commands are rebuilt out of the ingested keys with the prefixes.
+// Txns comparing any key without the prefixes could not be evaluated the same way the leader did as the key is missing
+// in the follower, errTxnFiltered is returned unless no write of the txn remains.
+func filterCommand(prefixes [][]byte, cmd *regattapb.Command) (*regattapb.Command, error) {
+	if len(prefixes) == 0 {
+		return cmd, nil
+	}
+	dummy := func() *regattapb.Command {
+		return &regattapb.Command{Table: cmd.Table, Type: regattapb.Command_DUMMY, LeaderIndex: cmd.LeaderIndex}
+	}
+	switch cmd.Type {
+	case regattapb.Command_PUT:
+		if !hasPrefix(prefixes, cmd.Kv.GetKey()) {
+			return dummy(), nil
+		}
+	case regattapb.Command_PUT_BATCH:
+		batch := cmd.Batch[:0]
+		for _, kv := range cmd.Batch {
+			if hasPrefix(prefixes, kv.Key) {
+				batch = append(batch, kv)
+			}
+		}
+		if len(batch) == 0 {
+			return dummy(), nil
+		}
+		cmd.Batch = batch
+	case regattapb.Command_INCREMENT:
+		if !hasPrefix(prefixes, cmd.Increment.GetKey()) {
+			return dummy(), nil
+		}
+	case regattapb.Command_TXN:
+		if cmd.Txn != nil {
+			cmd.Txn.Success = filterOps(prefixes, cmd.Txn.Success)
+			cmd.Txn.Failure = filterOps(prefixes, cmd.Txn.Failure)
+		}
+		if txnComparesFiltered(prefixes, cmd.Txn) {
+			if txnWrites(cmd.Txn) {
+				return nil, errTxnFiltered
+			}
+			return dummy(), nil
+		}
+	case regattapb.Command_INGEST:
+		filtered, err := fsm.FilterIngest(cmd, func(key []byte) bool { return hasPrefix(prefixes, key) })
+		if err != nil {
+			return nil, err
+		}
+		if filtered == nil {
+			return dummy(), nil
+		}
+		return filtered, nil
+	case regattapb.Command_SEQUENCE:
+		for i, c := range cmd.Sequence {
+			filtered, err := filterCommand(prefixes, c)
+			if err != nil {
+				return nil, err
+			}
+			cmd.Sequence[i] = filtered
+		}
+	}
+	return cmd, nil
+}
+
+func filterOps(prefixes [][]byte, ops []*regattapb.RequestOp) []*regattapb.RequestOp {
+	filtered := ops[:0]
+	for _, op := range ops {
+		switch r := op.Request.(type) {
+		case *regattapb.RequestOp_RequestPut:
+			if !hasPrefix(prefixes, r.RequestPut.Key) {
+				continue
+			}
+		case *regattapb.RequestOp_RequestIncrement:
+			if !hasPrefix(prefixes, r.RequestIncrement.Key) {
+				continue
+			}
+		case *regattapb.RequestOp_RequestTxn:
+			r.RequestTxn.Success = filterOps(prefixes, r.RequestTxn.Success)
+			r.RequestTxn.Failure = filterOps(prefixes, r.RequestTxn.Failure)
+		}
+		filtered = append(filtered, op)
+	}
+	return filtered
+}
+
+// filterReader filters the commands of the snapshot, each Read returns a single command.
+type filterReader struct {
+	r        io.Reader
+	prefixes [][]byte
+}
+
+func (f filterReader) Read(p []byte) (int, error) {
+	cmd := &regattapb.Command{}
+	for {
+		n, err := f.r.Read(p)
+		if err != nil || len(f.prefixes) == 0 {
+			return n, err
+		}
+		cmd.Reset()
+		if err := cmd.UnmarshalVT(p[:n]); err != nil {
+			return 0, err
+		}
+		// Only the commands dropped by the filter are skipped, the DUMMY commands of the snapshot carry the leader index.
+		filtered, err := filterCommand(f.prefixes, cmd)
+		if err != nil {
+			return 0, err
+		}
+		if filtered != cmd && filtered.Type == regattapb.Command_DUMMY {
+			continue
+		}
+		size := filtered.SizeVT()
+		if size > len(p) {
+			return 0, io.ErrShortBuffer
+		}
+		return filtered.MarshalToSizedBufferVT(p[:size])
+	}
+}
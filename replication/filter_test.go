@@ -0,0 +1,271 @@
+// Copyright JAMF Software, LLC
+
+package replication
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/fsm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Table(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		table  string
+		want   bool
+	}{
+		{name: "empty filter", table: "regatta-test", want: true},
+		{name: "included", filter: Filter{IncludeTables: []string{"regatta-*"}}, table: "regatta-test", want: true},
+		{name: "not included", filter: Filter{IncludeTables: []string{"regatta-*"}}, table: "test", want: false},
+		{name: "excluded", filter: Filter{ExcludeTables: []string{"*-test"}}, table: "regatta-test", want: false},
+		{name: "exclude takes precedence", filter: Filter{IncludeTables: []string{"regatta-*"}, ExcludeTables: []string{"regatta-test"}}, table: "regatta-test", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Table(tt.table))
+		})
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	r := require.New(t)
+	r.NoError(Filter{IncludeTables: []string{"regatta-*"}, ExcludeTables: []string{"test?"}}.Validate())
+	r.Error(Filter{IncludeTables: []string{"regatta-["}}.Validate())
+	r.Error(Filter{ExcludeTables: []string{"["}}.Validate())
+}
+
+func Test_filterCommand(t *testing.T) {
+	prefixes := [][]byte{[]byte("a/"), []byte("b/")}
+	idx := uint64(10)
+	put := func(key string) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte(key)}}}
+	}
+	del := func(key string) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: []byte(key)}}}
+	}
+	dummy := &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &idx}
+	tests := []struct {
+		name     string
+		prefixes [][]byte
+		cmd      *regattapb.Command
+		want     *regattapb.Command
+		wantErr  error
+	}{
+		{
+			name: "no prefixes",
+			cmd:  &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("c/key")}},
+			want: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("c/key")}},
+		},
+		{
+			name:     "put matching",
+			prefixes: prefixes,
+			cmd:      &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a/key")}},
+			want:     &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a/key")}},
+		},
+		{
+			name:     "put not matching",
+			prefixes: prefixes,
+			cmd:      &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT, LeaderIndex: &idx, Kv: &regattapb.KeyValue{Key: []byte("c/key")}},
+			want:     dummy,
+		},
+		{
+			name:     "increment not matching",
+			prefixes: prefixes,
+			cmd:      &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_INCREMENT, LeaderIndex: &idx, Increment: &regattapb.RequestOp_Increment{Key: []byte("c/key")}},
+			want:     dummy,
+		},
+		{
+			name:     "delete not matching",
+			prefixes: prefixes,
+			cmd:      &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: []byte("c/key")}},
+			want:     &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: []byte("c/key")}},
+		},
+		{
+			name:     "put batch",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT_BATCH, Batch: []*regattapb.KeyValue{
+				{Key: []byte("a/key")}, {Key: []byte("c/key")}, {Key: []byte("b/key")},
+			}},
+			want: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT_BATCH, Batch: []*regattapb.KeyValue{
+				{Key: []byte("a/key")}, {Key: []byte("b/key")},
+			}},
+		},
+		{
+			name:     "put batch not matching",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_PUT_BATCH, LeaderIndex: &idx, Batch: []*regattapb.KeyValue{
+				{Key: []byte("c/key")}, {Key: []byte("d/key")},
+			}},
+			want: dummy,
+		},
+		{
+			name:     "txn",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, Txn: &regattapb.Txn{
+				Success: []*regattapb.RequestOp{put("a/key"), put("c/key"), del("c/key")},
+				Failure: []*regattapb.RequestOp{put("c/key"), {Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Success: []*regattapb.RequestOp{put("b/key"), put("d/key")}}}}},
+			}},
+			want: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, Txn: &regattapb.Txn{
+				Success: []*regattapb.RequestOp{put("a/key"), del("c/key")},
+				Failure: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Success: []*regattapb.RequestOp{put("b/key")}}}}},
+			}},
+		},
+		{
+			name:     "txn compare not matching",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, LeaderIndex: &idx, Txn: &regattapb.Txn{
+				Compare: []*regattapb.Compare{{Key: []byte("c/key")}},
+				Success: []*regattapb.RequestOp{put("a/key")},
+			}},
+			wantErr: errTxnFiltered,
+		},
+		{
+			name:     "txn compare not matching without writes",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, LeaderIndex: &idx, Txn: &regattapb.Txn{
+				Compare: []*regattapb.Compare{{Key: []byte("c/key")}},
+				Success: []*regattapb.RequestOp{put("c/key")},
+				Failure: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: []byte("a/key")}}}},
+			}},
+			want: dummy,
+		},
+		{
+			name:     "txn compare range not matching",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, LeaderIndex: &idx, Txn: &regattapb.Txn{
+				Compare: []*regattapb.Compare{{Key: []byte("a/key"), RangeEnd: []byte("c/")}},
+				Failure: []*regattapb.RequestOp{del("a/key")},
+			}},
+			wantErr: errTxnFiltered,
+		},
+		{
+			name:     "nested txn compare not matching",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, LeaderIndex: &idx, Txn: &regattapb.Txn{
+				Compare: []*regattapb.Compare{{Key: []byte("a/key")}},
+				Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{
+					Compare: []*regattapb.Compare{{Key: []byte("d/key")}},
+					Success: []*regattapb.RequestOp{put("b/key")},
+				}}}},
+			}},
+			wantErr: errTxnFiltered,
+		},
+		{
+			name:     "txn compare matching",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, Txn: &regattapb.Txn{
+				Compare: []*regattapb.Compare{{Key: []byte("a/key")}, {Key: []byte("b/"), RangeEnd: []byte("b0")}},
+				Success: []*regattapb.RequestOp{put("a/key"), put("c/key")},
+			}},
+			want: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_TXN, Txn: &regattapb.Txn{
+				Compare: []*regattapb.Compare{{Key: []byte("a/key")}, {Key: []byte("b/"), RangeEnd: []byte("b0")}},
+				Success: []*regattapb.RequestOp{put("a/key")},
+			}},
+		},
+		{
+			name:     "sequence",
+			prefixes: prefixes,
+			cmd: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_SEQUENCE, Sequence: []*regattapb.Command{
+				{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a/key")}},
+				{Table: []byte("test"), Type: regattapb.Command_PUT, LeaderIndex: &idx, Kv: &regattapb.KeyValue{Key: []byte("c/key")}},
+			}},
+			want: &regattapb.Command{Table: []byte("test"), Type: regattapb.Command_SEQUENCE, Sequence: []*regattapb.Command{
+				{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a/key")}},
+				dummy,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterCommand(tt.prefixes, tt.cmd)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// commandReader returns a single marshalled command per Read.
+type commandReader struct {
+	cmds []*regattapb.Command
+}
+
+func (c *commandReader) Read(p []byte) (int, error) {
+	if len(c.cmds) == 0 {
+		return 0, io.EOF
+	}
+	cmd := c.cmds[0]
+	c.cmds = c.cmds[1:]
+	return cmd.MarshalToSizedBufferVT(p[:cmd.SizeVT()])
+}
+
+func Test_filterCommandIngest(t *testing.T) {
+	r := require.New(t)
+	idx := uint64(10)
+	b := &fsm.IngestBuilder{}
+	for _, k := range []string{"a/key", "c/key", "b/key"} {
+		r.NoError(b.Add(&regattapb.Command{Table: []byte("test"), LeaderIndex: &idx, Kv: &regattapb.KeyValue{Key: []byte(k), Value: []byte("value"), CreateRevision: 1, ModRevision: 5, Version: 1}}))
+	}
+	ingest, err := b.Build()
+	r.NoError(err)
+
+	got, err := filterCommand([][]byte{[]byte("a/"), []byte("b/")}, ingest)
+	r.NoError(err)
+	r.Equal(regattapb.Command_INGEST, got.Type)
+	r.Equal(int64(5), got.Revision)
+	r.Equal(&idx, got.LeaderIndex)
+	var keys []string
+	_, err = fsm.FilterIngest(got, func(key []byte) bool {
+		keys = append(keys, string(key))
+		return false
+	})
+	r.NoError(err)
+	r.Equal([]string{"a/key", "b/key"}, keys)
+
+	got, err = filterCommand([][]byte{[]byte("d/")}, ingest)
+	r.NoError(err)
+	r.Equal(&regattapb.Command{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &idx}, got)
+}
+
+func Test_filterReader(t *testing.T) {
+	r := require.New(t)
+	leaderIndex := uint64(42)
+	fr := filterReader{
+		r: &commandReader{cmds: []*regattapb.Command{
+			{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a/key"), Value: []byte("value")}},
+			{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("c/key"), Value: []byte("value")}},
+			{Table: []byte("test"), Type: regattapb.Command_PUT_BATCH, Batch: []*regattapb.KeyValue{
+				{Key: []byte("c/key2"), Value: []byte("value")}, {Key: []byte("a/key2"), Value: []byte("value")},
+			}},
+			{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &leaderIndex},
+		}},
+		prefixes: [][]byte{[]byte("a/")},
+	}
+
+	var got []*regattapb.Command
+	buf := make([]byte, 1024)
+	for {
+		n, err := fr.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		r.NoError(err)
+		cmd := &regattapb.Command{}
+		r.NoError(cmd.UnmarshalVT(bytes.Clone(buf[:n])))
+		got = append(got, cmd)
+	}
+	r.Equal([]*regattapb.Command{
+		{Table: []byte("test"), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a/key"), Value: []byte("value")}},
+		{Table: []byte("test"), Type: regattapb.Command_PUT_BATCH, Batch: []*regattapb.KeyValue{{Key: []byte("a/key2"), Value: []byte("value")}}},
+		// The leader index of the snapshot is kept.
+		{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &leaderIndex},
+	}, got)
+}
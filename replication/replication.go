@@ -5,6 +5,7 @@ package replication
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -26,11 +27,34 @@ type WorkerConfig struct {
 	SnapshotRPCTimeout  time.Duration
 	MaxRecoveryInFlight int64
 	MaxSnapshotRecv     uint64
+	// Streaming keeps the replication stream open, the leader pushes the new commands as they are applied.
+	// Workers fall back to polling against the leaders not supporting the streaming.
+	Streaming bool
+	// HeartbeatInterval the maximum interval between the messages of the open replication stream.
+	HeartbeatInterval time.Duration
 }
 
+// TableDeletion how the tables deleted in the leader cluster are handled by the follower.
+type TableDeletion uint8
+
+const (
+	// TableDeletionDisabled tables deleted in the leader cluster are kept in the follower.
+	TableDeletionDisabled TableDeletion = iota
+	// TableDeletionDryRun tables deleted in the leader cluster are only reported in the log.
+	TableDeletionDryRun
+	// TableDeletionEnabled tables deleted in the leader cluster are deleted in the follower.
+	TableDeletionEnabled
+)
+
 type Config struct {
 	ReconcileInterval time.Duration
-	Workers           WorkerConfig
+	// TableDeletion how the tables deleted in the leader cluster are handled.
+	TableDeletion TableDeletion
+	// TableDeletionGracePeriod how long the table must be missing in the leader cluster before it is deleted.
+	TableDeletionGracePeriod time.Duration
+	// Filter selects the replicated tables and keys.
+	Filter  Filter
+	Workers WorkerConfig
 }
 
 // NewManager constructs a new replication Manager out of tables.Manager, dragonboat.NodeHost and replication API grpc.ClientConn.
@@ -49,11 +73,21 @@ func NewManager(tm *table.Manager, nh *dragonboat.NodeHost, conn *grpc.ClientCon
 			Help: "Regatta replication has the worker table leased",
 		}, []string{"table"},
 	)
+	replicationFilteredTxnsCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "regatta_replication_filtered_txns_total",
+			Help: "Regatta replication txns the key prefixes filter could not replicate, the table is recovered from the leader snapshot instead",
+		}, []string{"table"},
+	)
 
 	return &Manager{
-		reconcileInterval: cfg.ReconcileInterval,
-		tm:                tm,
-		metadataClient:    regattapb.NewMetadataClient(conn),
+		reconcileInterval:   cfg.ReconcileInterval,
+		tableDeletion:       cfg.TableDeletion,
+		deletionGracePeriod: cfg.TableDeletionGracePeriod,
+		deletedTables:       make(map[string]*deletedTable),
+		filter:              cfg.Filter,
+		tm:                  tm,
+		metadataClient:      regattapb.NewMetadataClient(conn),
 		factory: &workerFactory{
 			pollInterval:      cfg.Workers.PollInterval,
 			leaseInterval:     cfg.Workers.LeaseInterval,
@@ -61,15 +95,17 @@ func NewManager(tm *table.Manager, nh *dragonboat.NodeHost, conn *grpc.ClientCon
 			snapshotTimeout:   cfg.Workers.SnapshotRPCTimeout,
 			maxSnapshotRecv:   cfg.Workers.MaxSnapshotRecv,
 			recoverySemaphore: semaphore.NewWeighted(cfg.Workers.MaxRecoveryInFlight),
+			keyPrefixes:       cfg.Filter.KeyPrefixes,
 			tm:                tm,
 			log:               replicationLog,
 			nh:                nh,
 			logClient:         regattapb.NewLogClient(conn),
 			snapshotClient:    regattapb.NewSnapshotClient(conn),
 			metrics: struct {
-				replicationIndex  *prometheus.GaugeVec
-				replicationLeased *prometheus.GaugeVec
-			}{replicationIndex: replicationIndexGauge, replicationLeased: replicationLeaseGauge},
+				replicationIndex        *prometheus.GaugeVec
+				replicationLeased       *prometheus.GaugeVec
+				replicationFilteredTxns *prometheus.CounterVec
+			}{replicationIndex: replicationIndexGauge, replicationLeased: replicationLeaseGauge, replicationFilteredTxns: replicationFilteredTxnsCounter},
 		},
 		workers: struct {
 			registry map[string]*worker
@@ -78,34 +114,85 @@ func NewManager(tm *table.Manager, nh *dragonboat.NodeHost, conn *grpc.ClientCon
 		}{
 			registry: make(map[string]*worker),
 		},
+		lagDesc: prometheus.NewDesc(
+			"regatta_replication_lag_seconds",
+			"Regatta replication lag, the age of the most recent leader state replicated to the table",
+			[]string{"table"}, nil,
+		),
 		log:    replicationLog.Named("manager"),
 		closer: make(chan struct{}),
 	}
 }
 
+// deletedTable a replicated table missing in the leader cluster.
+type deletedTable struct {
+	since    time.Time
+	reported bool
+}
+
 // Manager schedules replication workers.
 type Manager struct {
-	reconcileInterval time.Duration
-	tm                *table.Manager
-	metadataClient    regattapb.MetadataClient
-	factory           *workerFactory
-	workers           struct {
+	reconcileInterval   time.Duration
+	tableDeletion       TableDeletion
+	deletionGracePeriod time.Duration
+	deletedTables       map[string]*deletedTable
+	filter              Filter
+	tm                  *table.Manager
+	metadataClient      regattapb.MetadataClient
+	factory             *workerFactory
+	workers             struct {
 		registry map[string]*worker
 		mtx      sync.RWMutex
 		wg       sync.WaitGroup
 	}
-	log    *zap.SugaredLogger
-	closer chan struct{}
+	lagDesc *prometheus.Desc
+	log     *zap.SugaredLogger
+	closer  chan struct{}
 }
 
 func (m *Manager) Describe(descs chan<- *prometheus.Desc) {
 	m.factory.metrics.replicationIndex.Describe(descs)
 	m.factory.metrics.replicationLeased.Describe(descs)
+	m.factory.metrics.replicationFilteredTxns.Describe(descs)
+	descs <- m.lagDesc
 }
 
 func (m *Manager) Collect(metrics chan<- prometheus.Metric) {
 	m.factory.metrics.replicationIndex.Collect(metrics)
 	m.factory.metrics.replicationLeased.Collect(metrics)
+	m.factory.metrics.replicationFilteredTxns.Collect(metrics)
+
+	m.workers.mtx.RLock()
+	defer m.workers.mtx.RUnlock()
+	for name, w := range m.workers.registry {
+		if lag, ok := w.lag(); ok {
+			metrics <- prometheus.MustNewConstMetric(m.lagDesc, prometheus.GaugeValue, lag.Seconds(), name)
+		}
+	}
+}
+
+// Lag returns the replication lag of the table, the age of the most recent leader cluster state replicated to the table.
+// The lag is known only to the member replicating the table once it contacted the leader cluster.
+func (m *Manager) Lag(table string) (time.Duration, bool) {
+	m.workers.mtx.RLock()
+	defer m.workers.mtx.RUnlock()
+	w, ok := m.workers.registry[table]
+	if !ok {
+		return 0, false
+	}
+	return w.lag()
+}
+
+// Freshness returns the leader time of the most recent leader cluster state replicated to the table.
+// The freshness is known only to the member replicating the table once it contacted the leader cluster.
+func (m *Manager) Freshness(table string) (time.Time, bool) {
+	m.workers.mtx.RLock()
+	defer m.workers.mtx.RUnlock()
+	w, ok := m.workers.registry[table]
+	if !ok {
+		return time.Time{}, false
+	}
+	return w.freshness()
 }
 
 // Start starts the replication manager goroutine, Close will stop it.
@@ -143,10 +230,76 @@ func (m *Manager) reconcileTables() error {
 	if err != nil {
 		return err
 	}
+	leaderTables := make(map[string]struct{}, len(response.GetTables()))
 	for _, tabs := range response.GetTables() {
-		if err := m.tm.CreateTable(tabs.Name); err != nil && !errors.Is(err, serrors.ErrTableExists) {
+		// Tables excluded by the filter are neither created nor replicated.
+		if !m.filter.Table(tabs.Name) {
+			continue
+		}
+		leaderTables[tabs.Name] = struct{}{}
+		overrides, err := table.OverridesFromConfig(tabs.Config)
+		if err != nil {
+			return fmt.Errorf("invalid config of the table %s: %w", tabs.Name, err)
+		}
+		if err := m.tm.CreateTableWithOverrides(tabs.Name, overrides); err != nil && !errors.Is(err, serrors.ErrTableExists) {
+			return err
+		}
+	}
+
+	tbs, err := m.tm.GetTables()
+	if err != nil {
+		return err
+	}
+	for _, tbl := range tbs {
+		if _, ok := leaderTables[tbl.Name]; ok && tbl.Local {
+			m.log.Warnf("table %s exists in the leader cluster but is local to this cluster, skipping replication", tbl.Name)
+			continue
+		}
+		if _, ok := leaderTables[tbl.Name]; ok && !tbl.Replicated {
+			if err := m.tm.MarkReplicated(tbl.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return m.reconcileDeletedTables(tbs, leaderTables)
+}
+
+// reconcileDeletedTables deletes the replicated tables missing in the leader cluster for longer than the grace period.
+// Tables that were never replicated from the leader cluster or that are excluded by the filter are never deleted.
+func (m *Manager) reconcileDeletedTables(tbs []table.Table, leaderTables map[string]struct{}) error {
+	local := make(map[string]struct{}, len(tbs))
+	for _, tbl := range tbs {
+		local[tbl.Name] = struct{}{}
+		if _, ok := leaderTables[tbl.Name]; ok || !tbl.Replicated || !m.filter.Table(tbl.Name) || m.tableDeletion == TableDeletionDisabled {
+			delete(m.deletedTables, tbl.Name)
+			continue
+		}
+		deleted, ok := m.deletedTables[tbl.Name]
+		if !ok {
+			m.log.Infof("table %s is missing in the leader cluster", tbl.Name)
+			deleted = &deletedTable{since: time.Now()}
+			m.deletedTables[tbl.Name] = deleted
+		}
+		if time.Since(deleted.since) < m.deletionGracePeriod {
+			continue
+		}
+		if m.tableDeletion == TableDeletionDryRun {
+			if !deleted.reported {
+				m.log.Warnf("table %s is missing in the leader cluster for more than %s, it would be deleted (dry-run)", tbl.Name, m.deletionGracePeriod)
+				deleted.reported = true
+			}
+			continue
+		}
+		m.log.Infof("deleting table %s missing in the leader cluster for more than %s", tbl.Name, m.deletionGracePeriod)
+		if err := m.tm.DeleteTable(tbl.Name); err != nil && !errors.Is(err, serrors.ErrTableNotFound) {
 			return err
 		}
+		delete(m.deletedTables, tbl.Name)
+	}
+	for name := range m.deletedTables {
+		if _, ok := local[name]; !ok {
+			delete(m.deletedTables, name)
+		}
 	}
 	return nil
 }
@@ -157,25 +310,39 @@ func (m *Manager) reconcileWorkers() error {
 		return err
 	}
 
+	replicated := tbs[:0]
+	for _, tbl := range tbs {
+		if !tbl.Local && m.filter.Table(tbl.Name) {
+			replicated = append(replicated, tbl)
+		}
+	}
+	tbs = replicated
+
 	for _, tbl := range tbs {
 		if !m.hasWorker(tbl.Name) {
 			m.startWorker(m.factory.create(tbl.Name))
 		}
 	}
 
-	m.workers.mtx.RLock()
-	defer m.workers.mtx.RUnlock()
-	for name, worker := range m.workers.registry {
-		found := false
-		for _, tbl := range tbs {
-			if tbl.Name == name {
-				found = true
-				break
+	var stale []*worker
+	func() {
+		m.workers.mtx.RLock()
+		defer m.workers.mtx.RUnlock()
+		for name, worker := range m.workers.registry {
+			found := false
+			for _, tbl := range tbs {
+				if tbl.Name == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				stale = append(stale, worker)
 			}
 		}
-		if !found {
-			m.stopWorker(worker)
-		}
+	}()
+	for _, worker := range stale {
+		m.stopWorker(worker)
 	}
 	return nil
 }
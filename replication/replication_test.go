@@ -144,12 +144,17 @@ func TestManager_reconcileTables(t *testing.T) {
 	}, 10*time.Second, 200*time.Millisecond, "table not created in time")
 
 	t.Log("create another table")
-	r.NoError(leaderTM.CreateTable("test2"))
+	compression := table.CompressionZstd
+	overrides := &table.Overrides{Compression: &compression}
+	r.NoError(leaderTM.CreateTableWithOverrides("test2", overrides))
 	r.NoError(m.reconcileTables())
 	r.Eventually(func() bool {
 		_, err := followerTM.GetTable("test2")
 		return err == nil
 	}, 10*time.Second, 200*time.Millisecond, "table not created in time")
+	tab, err := followerTM.GetTable("test2")
+	r.NoError(err)
+	r.Equal(overrides, tab.Overrides)
 
 	t.Log("skip network errors")
 	r.NoError(conn.Close())
@@ -159,6 +164,146 @@ func TestManager_reconcileTables(t *testing.T) {
 	r.Len(tabs, 2)
 }
 
+func TestManager_reconcileDeletedTables(t *testing.T) {
+	r := require.New(t)
+	leaderTM, followerTM, leaderNH, followerNH, closer := prepareLeaderAndFollowerRaft(t)
+	defer closer()
+	srv := startReplicationServer(leaderTM, leaderNH)
+	defer srv.Shutdown()
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer conn.Close()
+
+	m := NewManager(followerTM, followerNH, conn, Config{TableDeletion: TableDeletionDryRun})
+
+	t.Log("create tables")
+	r.NoError(leaderTM.CreateTable("test"))
+	r.NoError(followerTM.CreateTable("local"))
+	r.NoError(m.reconcileTables())
+	tab, err := followerTM.GetTable("test")
+	r.NoError(err)
+	r.True(tab.Replicated)
+	tab, err = followerTM.GetTable("local")
+	r.NoError(err)
+	r.False(tab.Replicated)
+
+	t.Log("delete table in dry-run mode")
+	r.NoError(leaderTM.DeleteTable("test"))
+	r.NoError(m.reconcileTables())
+	_, err = followerTM.GetTable("test")
+	r.NoError(err)
+	r.True(m.deletedTables["test"].reported)
+
+	t.Log("delete table within the grace period")
+	m.tableDeletion = TableDeletionEnabled
+	m.deletionGracePeriod = time.Hour
+	r.NoError(m.reconcileTables())
+	_, err = followerTM.GetTable("test")
+	r.NoError(err)
+
+	t.Log("delete table after the grace period")
+	m.deletionGracePeriod = 0
+	r.NoError(m.reconcileTables())
+	tabs, err := followerTM.GetTables()
+	r.NoError(err)
+	r.Len(tabs, 1)
+	r.Equal("local", tabs[0].Name)
+	r.Empty(m.deletedTables)
+}
+
+func TestManager_localTables(t *testing.T) {
+	r := require.New(t)
+	leaderTM, followerTM, leaderNH, followerNH, closer := prepareLeaderAndFollowerRaft(t)
+	defer closer()
+	srv := startReplicationServer(leaderTM, leaderNH)
+	defer srv.Shutdown()
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer conn.Close()
+
+	m := NewManager(followerTM, followerNH, conn, Config{
+		Workers: WorkerConfig{
+			PollInterval:        10 * time.Millisecond,
+			LeaseInterval:       100 * time.Millisecond,
+			LogRPCTimeout:       100 * time.Millisecond,
+			SnapshotRPCTimeout:  100 * time.Millisecond,
+			MaxRecoveryInFlight: 1,
+		},
+	})
+
+	t.Log("create tables")
+	r.NoError(leaderTM.CreateTable("test"))
+	r.NoError(leaderTM.CreateTable("local"))
+	r.NoError(followerTM.CreateLocalTable("local", nil))
+	r.NoError(m.reconcileTables())
+
+	t.Log("local table is not replicated")
+	tab, err := followerTM.GetTable("local")
+	r.NoError(err)
+	r.True(tab.Local)
+	r.False(tab.Replicated)
+
+	r.NoError(m.reconcileWorkers())
+	r.True(m.hasWorker("test"))
+	r.False(m.hasWorker("local"))
+	for _, w := range m.workers.registry {
+		m.stopWorker(w)
+	}
+}
+
+func TestManager_filterTables(t *testing.T) {
+	r := require.New(t)
+	leaderTM, followerTM, leaderNH, followerNH, closer := prepareLeaderAndFollowerRaft(t)
+	defer closer()
+	srv := startReplicationServer(leaderTM, leaderNH)
+	defer srv.Shutdown()
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer conn.Close()
+
+	m := NewManager(followerTM, followerNH, conn, Config{
+		Filter: Filter{IncludeTables: []string{"regatta-*"}, ExcludeTables: []string{"regatta-excluded"}},
+		Workers: WorkerConfig{
+			PollInterval:        10 * time.Millisecond,
+			LeaseInterval:       100 * time.Millisecond,
+			LogRPCTimeout:       100 * time.Millisecond,
+			SnapshotRPCTimeout:  100 * time.Millisecond,
+			MaxRecoveryInFlight: 1,
+		},
+	})
+
+	t.Log("create tables")
+	r.NoError(leaderTM.CreateTable("regatta-test"))
+	r.NoError(leaderTM.CreateTable("regatta-excluded"))
+	r.NoError(leaderTM.CreateTable("test"))
+	r.NoError(m.reconcileTables())
+
+	tabs, err := followerTM.GetTables()
+	r.NoError(err)
+	r.Len(tabs, 1)
+	r.Equal("regatta-test", tabs[0].Name)
+
+	r.NoError(m.reconcileWorkers())
+	r.True(m.hasWorker("regatta-test"))
+	r.Len(m.workers.registry, 1)
+	for _, w := range m.workers.registry {
+		m.stopWorker(w)
+	}
+
+	t.Log("excluded tables replicated before are not deleted")
+	r.NoError(followerTM.CreateTable("regatta-excluded"))
+	r.NoError(followerTM.MarkReplicated("regatta-excluded"))
+	m.tableDeletion = TableDeletionEnabled
+	m.deletionGracePeriod = 0
+	r.NoError(m.reconcileTables())
+	_, err = followerTM.GetTable("regatta-excluded")
+	r.NoError(err)
+	r.Empty(m.deletedTables)
+}
+
 func TestWorker_recover(t *testing.T) {
 	r := require.New(t)
 	t.Log("start follower Raft")
@@ -272,19 +417,28 @@ func prepareLeaderAndFollowerRaft(t *testing.T) (leaderTM *table.Manager, follow
 }
 
 func startReplicationServer(manager *table.Manager, nh *dragonboat.NodeHost) *regattaserver.RegattaServer {
+	return startReplicationServerMode(manager, nh, false, nil)
+}
+
+// startFollowerReplicationServer starts the replication server of the follower cluster.
+func startFollowerReplicationServer(manager *table.Manager, nh *dragonboat.NodeHost, rs regattaserver.ReplicationService) *regattaserver.RegattaServer {
+	return startReplicationServerMode(manager, nh, true, rs)
+}
+
+func startReplicationServerMode(manager *table.Manager, nh *dragonboat.NodeHost, follower bool, rs regattaserver.ReplicationService) *regattaserver.RegattaServer {
 	testNodeAddress := fmt.Sprintf("127.0.0.1:%d", getTestPort())
 	server := regattaserver.NewServer(testNodeAddress, false)
 	regattapb.RegisterMetadataServer(server, &regattaserver.MetadataServer{Tables: manager})
-	regattapb.RegisterSnapshotServer(server, &regattaserver.SnapshotServer{Tables: manager})
-	regattapb.RegisterLogServer(
-		server,
-		regattaserver.NewLogServer(
-			manager,
-			&testLogReader{nh: nh},
-			zap.NewNop(),
-			1024,
-		),
+	regattapb.RegisterSnapshotServer(server, &regattaserver.SnapshotServer{Tables: manager, Follower: follower, Retention: time.Minute})
+	ls := regattaserver.NewLogServer(
+		manager,
+		&testLogReader{nh: nh},
+		zap.NewNop(),
+		1024,
 	)
+	ls.Follower = follower
+	ls.Replication = rs
+	regattapb.RegisterLogServer(server, ls)
 	go func() {
 		err := server.ListenAndServe()
 		if err != nil {
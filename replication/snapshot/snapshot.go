@@ -5,6 +5,8 @@ package snapshot
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
 
@@ -18,8 +20,52 @@ const DefaultSnapshotChunkSize = 1024 * 1024
 
 const snapshotFilenamePattern = "snapshot-*.bin"
 
+var (
+	// ErrChecksumMismatch the data of the received chunk does not match its checksum.
+	ErrChecksumMismatch = errors.New("snapshot chunk checksum mismatch")
+	// ErrUnexpectedOffset the received chunk does not continue the received data.
+	ErrUnexpectedOffset = errors.New("unexpected snapshot chunk offset")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Checksum computes the checksum of the chunk data.
+func Checksum(data []byte) uint32 {
+	return crc32.Checksum(data, castagnoli)
+}
+
+// verify checks the chunk data against the chunk checksum, chunks without checksum are not verified.
+func verify(chunk *regattapb.SnapshotChunk) error {
+	if chunk.Checksum != nil && *chunk.Checksum != Checksum(chunk.Data) {
+		return fmt.Errorf("%w at offset %d", ErrChecksumMismatch, chunk.Offset)
+	}
+	return nil
+}
+
 type Writer struct {
 	Sender regattapb.Snapshot_StreamServer
+	// ID of the streamed snapshot, the receiver could resume the interrupted stream if set.
+	ID string
+	// Offset of the next chunk in the snapshot.
+	Offset uint64
+	// Index the snapshot was created for.
+	Index uint64
+}
+
+func (g *Writer) send(p []byte) error {
+	sum := Checksum(p)
+	if err := g.Sender.Send(&regattapb.SnapshotChunk{
+		Data:     p,
+		Len:      uint64(len(p)),
+		Index:    g.Index,
+		Offset:   g.Offset,
+		Checksum: &sum,
+		Id:       g.ID,
+	}); err != nil {
+		return err
+	}
+	g.Offset += uint64(len(p))
+	return nil
 }
 
 func (g *Writer) ReadFrom(r io.Reader) (int64, error) {
@@ -29,10 +75,7 @@ func (g *Writer) ReadFrom(r io.Reader) (int64, error) {
 		n, err := r.Read(chunk)
 		if n > 0 {
 			count += int64(n)
-			if err := g.Sender.Send(&regattapb.SnapshotChunk{
-				Data: chunk[:n],
-				Len:  uint64(n),
-			}); err != nil {
+			if err := g.send(chunk[:n]); err != nil {
 				return count, err
 			}
 		}
@@ -47,14 +90,10 @@ func (g *Writer) ReadFrom(r io.Reader) (int64, error) {
 }
 
 func (g *Writer) Write(p []byte) (int, error) {
-	ln := len(p)
-	if err := g.Sender.Send(&regattapb.SnapshotChunk{
-		Data: p,
-		Len:  uint64(ln),
-	}); err != nil {
+	if err := g.send(p); err != nil {
 		return 0, err
 	}
-	return ln, nil
+	return len(p), nil
 }
 
 type Reader struct {
@@ -71,6 +110,9 @@ func (s Reader) Read(p []byte) (int, error) {
 	if len(p) < int(chunk.Len) {
 		return 0, io.ErrShortBuffer
 	}
+	if err := verify(chunk); err != nil {
+		return 0, err
+	}
 	if s.Limiter != nil {
 		s.Limiter.WaitN(s.Stream.Context(), int(chunk.Len))
 	}
@@ -90,6 +132,9 @@ func (s Reader) WriteTo(w io.Writer) (int64, error) {
 		if err != nil {
 			return n, err
 		}
+		if err := verify(chunk); err != nil {
+			return n, err
+		}
 		if s.Limiter != nil {
 			s.Limiter.WaitN(s.Stream.Context(), int(chunk.Len))
 		}
@@ -101,6 +146,101 @@ func (s Reader) WriteTo(w io.Writer) (int64, error) {
 	}
 }
 
+// Download is a snapshot received from the Snapshot service. The interrupted download could be resumed from the last
+// verified offset as long as the server holds the snapshot, it starts over once the server streams another snapshot.
+type Download struct {
+	// ID of the downloaded snapshot, the download is not resumable if empty.
+	ID string
+	// Offset of the verified data written to the file.
+	Offset uint64
+	// Limiter limits the rate of the received bytes if set.
+	Limiter *rate.Limiter
+	file    *snapshotFile
+}
+
+// NewDownload creates a new Download backed by a temporary file, call Download.Close to remove it.
+func NewDownload() (*Download, error) {
+	sf, err := NewTemp()
+	if err != nil {
+		return nil, err
+	}
+	return &Download{file: sf}, nil
+}
+
+// Request returns the request resuming the download of the table snapshot.
+func (d *Download) Request(table string) *regattapb.SnapshotRequest {
+	return &regattapb.SnapshotRequest{Table: []byte(table), Id: d.ID, Offset: d.Offset}
+}
+
+// Receive writes the chunks of the stream to the file until the stream ends. Every chunk is verified against its
+// checksum and the expected offset before it is written.
+func (d *Download) Receive(stream regattapb.Snapshot_StreamClient) error {
+	// Drop the data written after the last verified offset by the previous attempt.
+	if err := d.truncate(d.Offset); err != nil {
+		return err
+	}
+	chunk := regattapb.SnapshotChunkFromVTPool()
+	defer chunk.ReturnToVTPool()
+	for first := true; ; first = false {
+		chunk.ResetVT()
+		err := stream.RecvMsg(chunk)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if first && (chunk.Id == "" || chunk.Id != d.ID) {
+			// The server streams another snapshot from the beginning.
+			if err := d.truncate(0); err != nil {
+				return err
+			}
+			d.ID = chunk.Id
+		}
+		if chunk.Id != d.ID || (chunk.Id != "" && chunk.Offset != d.Offset) {
+			return fmt.Errorf("%w: got %d of snapshot '%s', expected %d of snapshot '%s'", ErrUnexpectedOffset, chunk.Offset, chunk.Id, d.Offset, d.ID)
+		}
+		if err := verify(chunk); err != nil {
+			return err
+		}
+		if d.Limiter != nil {
+			_ = d.Limiter.WaitN(stream.Context(), int(chunk.Len))
+		}
+		n, err := d.file.File.Write(chunk.Data)
+		if err != nil {
+			return err
+		}
+		d.Offset += uint64(n)
+	}
+}
+
+// Reader returns the reader of the commands of the downloaded snapshot.
+func (d *Download) Reader() (io.Reader, error) {
+	if err := d.file.Sync(); err != nil {
+		return nil, err
+	}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return d.file, nil
+}
+
+// Close closes and removes the file of the download.
+func (d *Download) Close() error {
+	return errors.Join(d.file.Close(), os.Remove(d.file.Path()))
+}
+
+func (d *Download) truncate(offset uint64) error {
+	if err := d.file.Truncate(int64(offset)); err != nil {
+		return err
+	}
+	if _, err := d.file.Seek(int64(offset), io.SeekStart); err != nil {
+		return err
+	}
+	d.Offset = offset
+	return nil
+}
+
 func OpenFile(path string) (*snapshotFile, error) {
 	f, err := os.Open(path)
 	if err != nil {
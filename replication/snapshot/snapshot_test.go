@@ -5,6 +5,7 @@ package snapshot
 import (
 	"bufio"
 	"context"
+	"errors"
 	"io"
 	"net"
 	"os"
@@ -97,6 +98,178 @@ func Test_snapshotFile_ReadWrite(t *testing.T) {
 	}
 }
 
+func TestDownload(t *testing.T) {
+	r := require.New(t)
+	data, keys := testSnapshotData(t, 100)
+	chunks := testChunks(data, "snap", 64)
+	r.Greater(len(chunks), 4)
+
+	t.Run("resume", func(t *testing.T) {
+		r := require.New(t)
+		d, err := NewDownload()
+		r.NoError(err)
+		defer d.Close()
+
+		r.ErrorContains(d.Receive(&chunkStream{chunks: chunks[:2], err: errors.New("interrupted")}), "interrupted")
+		r.Equal("snap", d.ID)
+		r.Equal(uint64(128), d.Offset)
+		req := d.Request("table")
+		r.Equal("snap", req.Id)
+		r.Equal(uint64(128), req.Offset)
+
+		r.NoError(d.Receive(&chunkStream{chunks: chunks[2:]}))
+		r.Equal(keys, downloadedKeys(t, d))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		r := require.New(t)
+		d, err := NewDownload()
+		r.NoError(err)
+		defer d.Close()
+
+		sum := Checksum(chunks[1].Data) + 1
+		corrupted := &regattapb.SnapshotChunk{Data: chunks[1].Data, Len: chunks[1].Len, Offset: chunks[1].Offset, Checksum: &sum, Id: chunks[1].Id}
+		r.ErrorIs(d.Receive(&chunkStream{chunks: []*regattapb.SnapshotChunk{chunks[0], corrupted}}), ErrChecksumMismatch)
+		r.Equal(uint64(64), d.Offset)
+
+		r.NoError(d.Receive(&chunkStream{chunks: chunks[1:]}))
+		r.Equal(keys, downloadedKeys(t, d))
+	})
+
+	t.Run("unexpected offset", func(t *testing.T) {
+		r := require.New(t)
+		d, err := NewDownload()
+		r.NoError(err)
+		defer d.Close()
+
+		r.ErrorIs(d.Receive(&chunkStream{chunks: []*regattapb.SnapshotChunk{chunks[0], chunks[2]}}), ErrUnexpectedOffset)
+		r.Equal(uint64(64), d.Offset)
+	})
+
+	t.Run("another snapshot", func(t *testing.T) {
+		r := require.New(t)
+		d, err := NewDownload()
+		r.NoError(err)
+		defer d.Close()
+
+		r.Error(d.Receive(&chunkStream{chunks: chunks[:3], err: errors.New("interrupted")}))
+		r.NoError(d.Receive(&chunkStream{chunks: testChunks(data, "other", 64)}))
+		r.Equal("other", d.ID)
+		r.Equal(keys, downloadedKeys(t, d))
+	})
+
+	t.Run("not resumable", func(t *testing.T) {
+		r := require.New(t)
+		d, err := NewDownload()
+		r.NoError(err)
+		defer d.Close()
+
+		legacy := testChunks(data, "", 64)
+		for _, c := range legacy {
+			c.Offset = 0
+			c.Checksum = nil
+		}
+		r.Error(d.Receive(&chunkStream{chunks: legacy[:3], err: errors.New("interrupted")}))
+		r.Empty(d.Request("table").Id)
+		r.NoError(d.Receive(&chunkStream{chunks: legacy}))
+		r.Equal(keys, downloadedKeys(t, d))
+	})
+}
+
+func TestReaderChecksum(t *testing.T) {
+	r := require.New(t)
+	data, _ := testSnapshotData(t, 10)
+	chunks := testChunks(data, "", 64)
+	sum := Checksum(chunks[1].Data) + 1
+	chunks[1].Checksum = &sum
+
+	_, err := io.Copy(io.Discard, &Reader{Stream: &chunkStream{chunks: chunks}})
+	r.ErrorIs(err, ErrChecksumMismatch)
+}
+
+// testSnapshotData returns the raw snapshot file with the given number of commands and the keys of the commands.
+func testSnapshotData(t *testing.T, count int) ([]byte, []string) {
+	r := require.New(t)
+	sf, err := NewTemp()
+	r.NoError(err)
+	defer func() {
+		_ = sf.Close()
+		_ = os.Remove(sf.Path())
+	}()
+	var keys []string
+	for i := 0; i < count; i++ {
+		key := "key" + strconv.Itoa(i)
+		bts, err := (&regattapb.Command{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte(key), Value: []byte(util.RandString(32))}}).MarshalVT()
+		r.NoError(err)
+		_, err = sf.Write(bts)
+		r.NoError(err)
+		keys = append(keys, key)
+	}
+	r.NoError(sf.Sync())
+	data, err := os.ReadFile(sf.Path())
+	r.NoError(err)
+	return data, keys
+}
+
+// testChunks splits the data into the chunks the way the Writer does.
+func testChunks(data []byte, id string, size int) []*regattapb.SnapshotChunk {
+	var chunks []*regattapb.SnapshotChunk
+	for offset := 0; offset < len(data); offset += size {
+		p := data[offset:min(offset+size, len(data))]
+		sum := Checksum(p)
+		chunks = append(chunks, &regattapb.SnapshotChunk{Data: p, Len: uint64(len(p)), Offset: uint64(offset), Checksum: &sum, Id: id})
+	}
+	return chunks
+}
+
+func downloadedKeys(t *testing.T, d *Download) []string {
+	r := require.New(t)
+	rd, err := d.Reader()
+	r.NoError(err)
+	var keys []string
+	buff := make([]byte, 1024)
+	for {
+		n, err := rd.Read(buff)
+		if err == io.EOF {
+			return keys
+		}
+		r.NoError(err)
+		cmd := &regattapb.Command{}
+		r.NoError(cmd.UnmarshalVT(buff[:n]))
+		keys = append(keys, string(cmd.Kv.Key))
+	}
+}
+
+// chunkStream is a snapshot stream of the given chunks ending with the err, io.EOF if nil.
+type chunkStream struct {
+	grpc.ClientStream
+	chunks []*regattapb.SnapshotChunk
+	err    error
+}
+
+func (s *chunkStream) Recv() (*regattapb.SnapshotChunk, error) {
+	chunk := &regattapb.SnapshotChunk{}
+	return chunk, s.RecvMsg(chunk)
+}
+
+func (s *chunkStream) RecvMsg(m any) error {
+	if len(s.chunks) == 0 {
+		if s.err != nil {
+			return s.err
+		}
+		return io.EOF
+	}
+	c := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	chunk := m.(*regattapb.SnapshotChunk)
+	chunk.Data, chunk.Len, chunk.Offset, chunk.Checksum, chunk.Id = c.Data, c.Len, c.Offset, c.Checksum, c.Id
+	return nil
+}
+
+func (s *chunkStream) Context() context.Context {
+	return context.Background()
+}
+
 func TestReaderWriter(t *testing.T) {
 	lis := bufconn.Listen(10 * 1024 * 1024)
 	srv := grpc.NewServer()
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
-	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,6 +27,9 @@ import (
 // TODO make configurable.
 const desiredProposalSize = 256 * 1024
 
+// defaultHeartbeatInterval the heartbeat interval of the replication stream if not configured.
+const defaultHeartbeatInterval = time.Second
+
 type replicateResult int
 
 const (
@@ -44,15 +46,19 @@ type workerFactory struct {
 	logTimeout        time.Duration
 	snapshotTimeout   time.Duration
 	maxSnapshotRecv   uint64
+	streaming         bool
+	heartbeatInterval time.Duration
 	recoverySemaphore *semaphore.Weighted
+	keyPrefixes       map[string][][]byte
 	tm                *table.Manager
 	log               *zap.SugaredLogger
 	nh                *dragonboat.NodeHost
 	logClient         regattapb.LogClient
 	snapshotClient    regattapb.SnapshotClient
 	metrics           struct {
-		replicationIndex  *prometheus.GaugeVec
-		replicationLeased *prometheus.GaugeVec
+		replicationIndex        *prometheus.GaugeVec
+		replicationLeased       *prometheus.GaugeVec
+		replicationFilteredTxns *prometheus.CounterVec
 	}
 }
 
@@ -60,16 +66,19 @@ func (f *workerFactory) create(table string) *worker {
 	return &worker{
 		workerFactory: f,
 		table:         table,
+		prefixes:      f.keyPrefixes[table],
 		closer:        make(chan struct{}),
 		log:           f.log.Named(table),
 		metrics: struct {
 			replicationLeaderIndex   prometheus.Gauge
 			replicationFollowerIndex prometheus.Gauge
 			replicationLeased        prometheus.Gauge
+			replicationFilteredTxns  prometheus.Counter
 		}{
 			replicationLeaderIndex:   f.metrics.replicationIndex.WithLabelValues("leader", table),
 			replicationFollowerIndex: f.metrics.replicationIndex.WithLabelValues("follower", table),
 			replicationLeased:        f.metrics.replicationLeased.WithLabelValues(table),
+			replicationFilteredTxns:  f.metrics.replicationFilteredTxns.WithLabelValues(table),
 		},
 	}
 }
@@ -77,14 +86,21 @@ func (f *workerFactory) create(table string) *worker {
 // worker connects to the log replication service and synchronizes the local state.
 type worker struct {
 	*workerFactory
-	table   string
-	closer  chan struct{}
-	log     *zap.SugaredLogger
-	leased  atomic.Bool
-	metrics struct {
+	table string
+	// prefixes of the keys to replicate, all the keys are replicated if empty.
+	prefixes [][]byte
+	closer   chan struct{}
+	log      *zap.SugaredLogger
+	leased   atomic.Bool
+	// fresh is the leader timestamp in unix milliseconds of the most recent leader state applied to the table.
+	fresh atomic.Int64
+	// download is the snapshot download of the interrupted recovery, accessed by the replication routine only.
+	download *snapshot.Download
+	metrics  struct {
 		replicationLeaderIndex   prometheus.Gauge
 		replicationFollowerIndex prometheus.Gauge
 		replicationLeased        prometheus.Gauge
+		replicationFilteredTxns  prometheus.Counter
 	}
 	wg sync.WaitGroup
 }
@@ -153,6 +169,13 @@ func (w *worker) Start() {
 					continue
 				}
 				result, err := w.do(idx, sess)
+				if errors.Is(err, errTxnFiltered) {
+					// The follower could not tell the branch of the txn the leader took, the filtered leader snapshot
+					// holds the keys the leader wrote.
+					w.log.Errorf("recovering from snapshot, the key prefixes filter could not replicate the txn: %v", err)
+					w.metrics.replicationFilteredTxns.Inc()
+					result, err = resultLeaderAhead, nil
+				}
 				if err != nil {
 					if errors.Is(err, context.DeadlineExceeded) {
 						w.log.Warnf("unable to read leader log in time: %v", err)
@@ -161,6 +184,10 @@ func (w *worker) Start() {
 					}
 					continue
 				}
+				if result != resultLeaderAhead {
+					// The table no longer needs the recovery.
+					w.discardDownload()
+				}
 				switch result {
 				case resultLeaderBehind:
 					w.log.Errorf("the leader log is behind ... backing off")
@@ -193,6 +220,7 @@ func (w *worker) Start() {
 func (w *worker) Close() {
 	close(w.closer)
 	w.wg.Wait()
+	w.discardDownload()
 
 	ok, err := w.tm.ReturnTable(w.table)
 	if err != nil {
@@ -204,6 +232,9 @@ func (w *worker) Close() {
 }
 
 func (w *worker) do(leaderIndex uint64, session *client.Session) (replicateResult, error) {
+	if w.streaming {
+		return w.stream(leaderIndex, session)
+	}
 	replicateRequest := &regattapb.ReplicateRequest{
 		LeaderIndex: leaderIndex + 1,
 		Table:       []byte(w.table),
@@ -215,6 +246,7 @@ func (w *worker) do(leaderIndex uint64, session *client.Session) (replicateResul
 		return resultUnknown, fmt.Errorf("could not open log stream: %w", err)
 	}
 	var applied uint64
+	current := leaderIndex
 	for {
 		replicateRes, err := stream.Recv()
 		if err == io.EOF {
@@ -234,6 +266,8 @@ func (w *worker) do(leaderIndex uint64, session *client.Session) (replicateResul
 			if err != nil {
 				return resultUnknown, fmt.Errorf("could not propose: %w", err)
 			}
+			current = max(current, applied)
+			w.observe(replicateRes, current)
 		case *regattapb.ReplicateResponse_ErrorResponse:
 			switch res.ErrorResponse.Error {
 			case regattapb.ReplicateError_LEADER_BEHIND:
@@ -248,6 +282,7 @@ func (w *worker) do(leaderIndex uint64, session *client.Session) (replicateResul
 				)
 			}
 		default:
+			w.observe(replicateRes, current)
 			if applied != 0 && applied < replicateRes.LeaderIndex {
 				return resultFollowerLagging, nil
 			}
@@ -256,6 +291,119 @@ func (w *worker) do(leaderIndex uint64, session *client.Session) (replicateResul
 	}
 }
 
+// stream keeps the replication stream open until the worker is closed, the lease is lost or the leader stops sending
+// the heartbeats. The leaders not supporting the streaming end the stream once they sent the commands present in the log.
+func (w *worker) stream(leaderIndex uint64, session *client.Session) (replicateResult, error) {
+	replicateRequest := &regattapb.ReplicateRequest{
+		LeaderIndex:       leaderIndex + 1,
+		Table:             []byte(w.table),
+		Stream:            true,
+		HeartbeatInterval: w.heartbeatInterval.Milliseconds(),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-w.closer:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	// The stream is considered dead if no message arrives within the heartbeat timeout.
+	timeout := 3 * w.heartbeatInterval
+	if timeout == 0 {
+		timeout = 3 * defaultHeartbeatInterval
+	}
+	watchdog := time.AfterFunc(timeout, cancel)
+	defer watchdog.Stop()
+
+	stream, err := w.logClient.Replicate(ctx, replicateRequest, grpc.WaitForReady(true))
+	if err != nil {
+		return resultUnknown, fmt.Errorf("could not open log stream: %w", err)
+	}
+	var applied, leader uint64
+	current := leaderIndex
+	for {
+		replicateRes, err := stream.Recv()
+		if err == io.EOF {
+			if applied != 0 && applied < leader {
+				return resultFollowerLagging, nil
+			}
+			return resultFollowerTailing, nil
+		}
+		if err != nil {
+			return resultUnknown, fmt.Errorf("error reading replication stream: %w", err)
+		}
+		// The proposals are limited by the log timeout instead.
+		watchdog.Stop()
+
+		if replicateRes.LeaderIndex != 0 {
+			leader = replicateRes.LeaderIndex
+			w.metrics.replicationLeaderIndex.Set(float64(replicateRes.LeaderIndex))
+		}
+
+		switch res := replicateRes.Response.(type) {
+		case *regattapb.ReplicateResponse_CommandsResponse:
+			applied, err = w.proposeBatch(ctx, res.CommandsResponse.GetCommands(), session)
+			if err != nil {
+				return resultUnknown, fmt.Errorf("could not propose: %w", err)
+			}
+			current = max(current, applied)
+			w.observe(replicateRes, current)
+		case *regattapb.ReplicateResponse_ErrorResponse:
+			switch res.ErrorResponse.Error {
+			case regattapb.ReplicateError_LEADER_BEHIND:
+				return resultLeaderBehind, nil
+			case regattapb.ReplicateError_USE_SNAPSHOT:
+				return resultLeaderAhead, nil
+			default:
+				return resultUnknown, fmt.Errorf(
+					"unknown replicate error response '%s' with id %d",
+					res.ErrorResponse.Error.String(),
+					res.ErrorResponse.Error,
+				)
+			}
+		default:
+			w.observe(replicateRes, current)
+		}
+
+		if !w.leased.Load() {
+			w.log.Info("table lease lost, closing replication stream")
+			return resultUnknown, nil
+		}
+		watchdog.Reset(timeout)
+	}
+}
+
+// observe records the leader timestamp of the response once the follower applied its leader index.
+func (w *worker) observe(res *regattapb.ReplicateResponse, applied uint64) {
+	if res.LeaderTimestamp == 0 || applied < res.LeaderIndex {
+		return
+	}
+	if res.LeaderTimestamp > w.fresh.Load() {
+		w.fresh.Store(res.LeaderTimestamp)
+	}
+}
+
+// lag returns the age of the most recent leader state applied to the table, it is known only while the table is leased.
+func (w *worker) lag() (time.Duration, bool) {
+	at, ok := w.freshness()
+	if !ok {
+		return 0, false
+	}
+	// The clocks of the leader and the follower could be skewed.
+	return max(time.Since(at), 0), true
+}
+
+// freshness returns the leader time of the most recent leader state applied to the table, it is known only while the table is leased.
+func (w *worker) freshness() (time.Time, bool) {
+	fresh := w.fresh.Load()
+	if fresh == 0 || !w.leased.Load() {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(fresh), true
+}
+
 func (w *worker) tableState() (uint64, *client.Session, error) {
 	t, err := w.tm.GetTable(w.table)
 	if err != nil {
@@ -289,7 +437,9 @@ func (w *worker) proposeBatch(ctx context.Context, commands []*regattapb.Replica
 		if err != nil {
 			return fmt.Errorf("could not marshal command: %w", err)
 		}
-		if _, err := w.nh.SyncPropose(ctx, session, buff[:n]); err != nil {
+		pctx, cancel := context.WithTimeout(ctx, w.logTimeout)
+		defer cancel()
+		if _, err := w.nh.SyncPropose(pctx, session, buff[:n]); err != nil {
 			return fmt.Errorf("could not propose sequence: %w", err)
 		}
 		w.metrics.replicationFollowerIndex.Set(float64(*seq.LeaderIndex))
@@ -299,9 +449,21 @@ func (w *worker) proposeBatch(ctx context.Context, commands []*regattapb.Replica
 	var lastApplied uint64
 	seq.Type = regattapb.Command_SEQUENCE
 	for i, c := range commands {
-		seq.Sequence = append(seq.Sequence, c.Command)
+		cmd, err := filterCommand(w.prefixes, c.Command)
+		if err != nil {
+			return lastApplied, fmt.Errorf("could not filter command: %w", err)
+		}
+		// The ingested keys are applied in an entry of their own, never together with other writes.
+		ingest := cmd.Type == regattapb.Command_INGEST
+		if ingest && len(seq.Sequence) > 0 {
+			if err := propose(); err != nil {
+				return lastApplied, err
+			}
+			lastApplied = commands[i-1].LeaderIndex
+		}
+		seq.Sequence = append(seq.Sequence, cmd)
 		seq.LeaderIndex = &c.LeaderIndex
-		if seq.SizeVT() >= desiredProposalSize || i == len(commands)-1 {
+		if ingest || seq.SizeVT() >= desiredProposalSize || i == len(commands)-1 {
 			if err := propose(); err != nil {
 				return lastApplied, err
 			}
@@ -312,50 +474,55 @@ func (w *worker) proposeBatch(ctx context.Context, commands []*regattapb.Replica
 	return lastApplied, nil
 }
 
+// recover restores the table from the leader snapshot. The interrupted snapshot download is kept and the next
+// recovery resumes it from the last verified offset.
 func (w *worker) recover() error {
-	w.log.Info("recovering from snapshot")
-	ctx, cancel := context.WithTimeout(context.Background(), w.snapshotTimeout)
-	defer cancel()
-	stream, err := w.snapshotClient.Stream(ctx, &regattapb.SnapshotRequest{Table: []byte(w.table)})
-	if err != nil {
-		return err
-	}
-
-	sf, err := snapshot.NewTemp()
-	if err != nil {
-		return err
-	}
-	defer func() {
-		err := sf.Close()
+	if w.download == nil {
+		w.log.Info("recovering from snapshot")
+		d, err := snapshot.NewDownload()
 		if err != nil {
-			return
+			return err
 		}
-		_ = os.Remove(sf.Path())
-	}()
-
-	r := &snapshot.Reader{Stream: stream}
-	if w.maxSnapshotRecv != 0 {
-		r.Limiter = rate.NewLimiter(rate.Limit(w.maxSnapshotRecv), int(w.maxSnapshotRecv))
+		if w.maxSnapshotRecv != 0 {
+			d.Limiter = rate.NewLimiter(rate.Limit(w.maxSnapshotRecv), int(w.maxSnapshotRecv))
+		}
+		w.download = d
+	} else {
+		w.log.Infof("resuming snapshot download at offset %d", w.download.Offset)
 	}
 
-	_, err = io.Copy(sf.File, r)
+	ctx, cancel := context.WithTimeout(context.Background(), w.snapshotTimeout)
+	defer cancel()
+	stream, err := w.snapshotClient.Stream(ctx, w.download.Request(w.table))
 	if err != nil {
 		return err
 	}
-
-	err = sf.Sync()
-	if err != nil {
-		return err
+	if err := w.download.Receive(stream); err != nil {
+		return fmt.Errorf("snapshot download interrupted at offset %d: %w", w.download.Offset, err)
 	}
-	_, err = sf.Seek(0, io.SeekStart)
+	// The complete download is restored at most once.
+	defer w.discardDownload()
+
+	r, err := w.download.Reader()
 	if err != nil {
 		return err
 	}
 	w.log.Info("snapshot stream saved, loading table")
-	err = w.tm.Restore(w.table, sf)
+	err = w.tm.Restore(w.table, filterReader{r: r, prefixes: w.prefixes})
 	if err != nil {
 		return err
 	}
 	w.log.Info("table recovered")
 	return nil
 }
+
+// discardDownload removes the snapshot download if there is any.
+func (w *worker) discardDownload() {
+	if w.download == nil {
+		return
+	}
+	if err := w.download.Close(); err != nil {
+		w.log.Warnf("unable to remove snapshot download: %v", err)
+	}
+	w.download = nil
+}
@@ -4,12 +4,17 @@ package replication
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/replication/snapshot"
 	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/util"
+	"github.com/lni/dragonboat/v4"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -54,8 +59,9 @@ func Test_worker_do(t *testing.T) {
 		pollInterval:  500 * time.Millisecond,
 		leaseInterval: 500 * time.Millisecond,
 		metrics: struct {
-			replicationIndex  *prometheus.GaugeVec
-			replicationLeased *prometheus.GaugeVec
+			replicationIndex        *prometheus.GaugeVec
+			replicationLeased       *prometheus.GaugeVec
+			replicationFilteredTxns *prometheus.CounterVec
 		}{
 			replicationIndex: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
@@ -69,6 +75,12 @@ func Test_worker_do(t *testing.T) {
 					Help: "Regatta replication has the worker table leased",
 				}, []string{"table"},
 			),
+			replicationFilteredTxns: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "regatta_replication_filtered_txns_total",
+					Help: "Regatta replication txns the key prefixes filter could not replicate, the table is recovered from the leader snapshot instead",
+				}, []string{"table"},
+			),
 		},
 	}
 	w := f.create("test")
@@ -144,6 +156,266 @@ func Test_worker_do(t *testing.T) {
 	}, 5*time.Second, 500*time.Millisecond)
 }
 
+func Test_worker_cascade(t *testing.T) {
+	r := require.New(t)
+	leaderTM, followerTM, leaderNH, followerNH, closer := prepareLeaderAndFollowerRaft(t)
+	defer closer()
+	srv := startReplicationServer(leaderTM, leaderNH)
+	defer srv.Shutdown()
+	followerSrv := startFollowerReplicationServer(followerTM, followerNH, nil)
+	defer followerSrv.Shutdown()
+
+	t.Log("start edge Raft")
+	edgeNH, edgeAddresses, err := startRaftNode()
+	r.NoError(err)
+	defer edgeNH.Close()
+	edgeTM := table.NewManager(edgeNH, edgeAddresses, tableManagerTestConfig())
+	r.NoError(edgeTM.Start())
+	r.NoError(edgeTM.WaitUntilReady())
+	defer edgeTM.Close()
+
+	t.Log("create tables")
+	r.NoError(leaderTM.CreateTable("test"))
+	r.NoError(followerTM.CreateTable("test"))
+	r.NoError(edgeTM.CreateTable("test"))
+	var at table.ActiveTable
+	r.Eventually(func() bool {
+		at, err = leaderTM.GetTable("test")
+		return err == nil
+	}, 5*time.Second, 500*time.Millisecond, "table not created in time")
+	r.NoError(fillData(100, at))
+
+	t.Log("create workers")
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer conn.Close()
+	followerConn, err := grpc.Dial(followerSrv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer followerConn.Close()
+	follower := testWorkerFactory(followerTM, followerNH, conn).create("test")
+	edge := testWorkerFactory(edgeTM, edgeNH, followerConn).create("test")
+
+	replicate := func(w *worker) replicateResult {
+		idx, sess, err := w.tableState()
+		r.NoError(err)
+		res, err := w.do(idx, sess)
+		r.NoError(err)
+		return res
+	}
+	count := func(tm *table.Manager) int64 {
+		tbl, err := tm.GetTable("test")
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		res, err := tbl.Range(ctx, &regattapb.RangeRequest{Table: []byte("test"), Key: []byte{0}, RangeEnd: []byte{0}, Linearizable: true, CountOnly: true})
+		r.NoError(err)
+		return res.Count
+	}
+	leaderIndex := func(w *worker) uint64 {
+		idx, _, err := w.tableState()
+		r.NoError(err)
+		return idx
+	}
+
+	t.Log("replicate from the follower")
+	replicate(follower)
+	replicate(edge)
+	r.Equal(int64(100), count(edgeTM))
+	r.Equal(leaderIndex(follower), leaderIndex(edge))
+
+	t.Log("replicate new data from the follower")
+	r.NoError(fillData(200, at))
+	replicate(follower)
+	replicate(edge)
+	r.Equal(int64(200), count(edgeTM))
+	r.Equal(leaderIndex(follower), leaderIndex(edge))
+
+	t.Log("recover the follower from the snapshot")
+	r.NoError(follower.recover())
+	r.Equal(int64(200), count(followerTM))
+
+	t.Log("edge must use the snapshot after the follower recovery")
+	edgeTable, err := edgeTM.GetTable("test")
+	r.NoError(err)
+	func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		r.NoError(edgeTable.Reset(ctx))
+	}()
+	r.Equal(resultLeaderAhead, replicate(edge))
+	r.NoError(edge.recover())
+	r.Equal(int64(200), count(edgeTM))
+	r.Equal(leaderIndex(follower), leaderIndex(edge))
+
+	t.Log("replicate new data after the recovery")
+	r.NoError(fillData(300, at))
+	replicate(follower)
+	replicate(edge)
+	r.Equal(int64(300), count(edgeTM))
+	r.Equal(leaderIndex(follower), leaderIndex(edge))
+}
+
+func Test_worker_stream(t *testing.T) {
+	r := require.New(t)
+	leaderTM, followerTM, leaderNH, followerNH, closer := prepareLeaderAndFollowerRaft(t)
+	defer closer()
+	srv := startReplicationServer(leaderTM, leaderNH)
+	defer srv.Shutdown()
+	followerRS := &workerReplication{}
+	followerSrv := startFollowerReplicationServer(followerTM, followerNH, followerRS)
+	defer followerSrv.Shutdown()
+
+	t.Log("start edge Raft")
+	edgeNH, edgeAddresses, err := startRaftNode()
+	r.NoError(err)
+	defer edgeNH.Close()
+	edgeTM := table.NewManager(edgeNH, edgeAddresses, tableManagerTestConfig())
+	r.NoError(edgeTM.Start())
+	r.NoError(edgeTM.WaitUntilReady())
+	defer edgeTM.Close()
+
+	t.Log("create tables")
+	r.NoError(leaderTM.CreateTable("test"))
+	r.NoError(followerTM.CreateTable("test"))
+	r.NoError(edgeTM.CreateTable("test"))
+	var at table.ActiveTable
+	r.Eventually(func() bool {
+		at, err = leaderTM.GetTable("test")
+		return err == nil
+	}, 5*time.Second, 500*time.Millisecond, "table not created in time")
+	r.NoError(fillData(100, at))
+
+	t.Log("open streams")
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer conn.Close()
+	followerConn, err := grpc.Dial(followerSrv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer followerConn.Close()
+	open := func(f *workerFactory) (*worker, chan error) {
+		f.streaming = true
+		f.heartbeatInterval = 50 * time.Millisecond
+		w := f.create("test")
+		w.leased.Store(true)
+		idx, sess, err := w.tableState()
+		r.NoError(err)
+		done := make(chan error, 1)
+		go func() {
+			_, err := w.do(idx, sess)
+			done <- err
+		}()
+		return w, done
+	}
+	follower, followerDone := open(testWorkerFactory(followerTM, followerNH, conn))
+	followerRS.w.Store(follower)
+	edge, edgeDone := open(testWorkerFactory(edgeTM, edgeNH, followerConn))
+
+	count := func(tm *table.Manager) int64 {
+		tbl, err := tm.GetTable("test")
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		res, err := tbl.Range(ctx, &regattapb.RangeRequest{Table: []byte("test"), Key: []byte{0}, RangeEnd: []byte{0}, CountOnly: true})
+		r.NoError(err)
+		return res.Count
+	}
+	r.Eventually(func() bool { return count(edgeTM) == 100 }, 5*time.Second, 50*time.Millisecond)
+
+	t.Log("new data are pushed through the open streams")
+	r.NoError(fillData(200, at))
+	r.Eventually(func() bool { return count(edgeTM) == 200 }, 5*time.Second, 50*time.Millisecond)
+	t.Log("streams are kept open by the heartbeats")
+	time.Sleep(300 * time.Millisecond)
+	r.Empty(followerDone)
+	r.Empty(edgeDone)
+
+	t.Log("lag is known from the leader timestamps forwarded by the follower")
+	lag, ok := follower.lag()
+	r.True(ok)
+	r.Less(lag, time.Second)
+	r.Eventually(func() bool {
+		_, ok := edge.lag()
+		return ok
+	}, 5*time.Second, 50*time.Millisecond)
+	lag, _ = edge.lag()
+	r.Less(lag, time.Second)
+
+	t.Log("close the streams")
+	close(edge.closer)
+	close(follower.closer)
+	r.Error(<-edgeDone)
+	r.Error(<-followerDone)
+}
+
+func Test_worker_stream_fallback(t *testing.T) {
+	r := require.New(t)
+	conn := testServer(t, func(server *grpc.Server) {
+		regattapb.RegisterLogServer(server, testReplicationServer{
+			repResp: []*regattapb.ReplicateResponse{{LeaderIndex: 10}},
+		})
+	})
+	f := testWorkerFactory(nil, nil, conn)
+	f.streaming = true
+	w := f.create("test")
+	w.leased.Store(true)
+	res, err := w.do(10, nil)
+	r.NoError(err)
+	r.Equal(resultFollowerTailing, res)
+}
+
+func Test_worker_lag(t *testing.T) {
+	r := require.New(t)
+	w := testWorkerFactory(nil, nil, nil).create("test")
+	w.leased.Store(true)
+	_, ok := w.lag()
+	r.False(ok, "lag is unknown before the first response")
+
+	now := time.Now()
+	w.observe(&regattapb.ReplicateResponse{LeaderIndex: 10, LeaderTimestamp: now.Add(-time.Minute).UnixMilli()}, 5)
+	_, ok = w.lag()
+	r.False(ok, "lag is unknown until the leader index is applied")
+
+	w.observe(&regattapb.ReplicateResponse{LeaderIndex: 10, LeaderTimestamp: now.Add(-time.Minute).UnixMilli()}, 10)
+	lag, ok := w.lag()
+	r.True(ok)
+	r.GreaterOrEqual(lag, time.Minute)
+
+	w.observe(&regattapb.ReplicateResponse{LeaderIndex: 5, LeaderTimestamp: now.Add(-2 * time.Minute).UnixMilli()}, 10)
+	lag, _ = w.lag()
+	r.Less(lag, 2*time.Minute, "older leader state does not increase the lag")
+
+	w.observe(&regattapb.ReplicateResponse{LeaderIndex: 10, LeaderTimestamp: now.Add(time.Minute).UnixMilli()}, 10)
+	lag, _ = w.lag()
+	r.Zero(lag, "leader clock ahead of the follower")
+
+	w.leased.Store(false)
+	_, ok = w.lag()
+	r.False(ok, "lag is unknown if the table is not leased")
+}
+
+func testWorkerFactory(tm *table.Manager, nh *dragonboat.NodeHost, conn *grpc.ClientConn) *workerFactory {
+	return &workerFactory{
+		logTimeout:      time.Minute,
+		snapshotTimeout: time.Minute,
+		tm:              tm,
+		logClient:       regattapb.NewLogClient(conn),
+		snapshotClient:  regattapb.NewSnapshotClient(conn),
+		nh:              nh,
+		log:             zap.NewNop().Sugar(),
+		pollInterval:    500 * time.Millisecond,
+		leaseInterval:   500 * time.Millisecond,
+		metrics: struct {
+			replicationIndex        *prometheus.GaugeVec
+			replicationLeased       *prometheus.GaugeVec
+			replicationFilteredTxns *prometheus.CounterVec
+		}{
+			replicationIndex:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "regatta_replication_index"}, []string{"role", "table"}),
+			replicationLeased:       prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "regatta_replication_leased"}, []string{"table"}),
+			replicationFilteredTxns: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "regatta_replication_filtered_txns_total"}, []string{"table"}),
+		},
+	}
+}
+
 func fillData(keyCount int, at table.ActiveTable) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
@@ -205,3 +477,105 @@ func Test_worker_recover(t *testing.T) {
 	r.NoError(err)
 	r.Equal("test2", tab.Name)
 }
+
+func Test_worker_recover_resume(t *testing.T) {
+	r := require.New(t)
+	leaderTM, followerTM, leaderNH, _, closer := prepareLeaderAndFollowerRaft(t)
+	defer closer()
+	srv := startReplicationServer(leaderTM, leaderNH)
+	defer srv.Shutdown()
+
+	t.Log("create table")
+	r.NoError(leaderTM.CreateTable("test"))
+	var at table.ActiveTable
+	r.Eventually(func() bool {
+		var err error
+		at, err = leaderTM.GetTable("test")
+		return err == nil
+	}, 5*time.Second, 500*time.Millisecond, "table not created in time")
+
+	t.Log("load data spanning multiple snapshot chunks")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	for i := 0; i < 30; i++ {
+		_, err := at.Put(ctx, &regattapb.PutRequest{
+			Key:   []byte(fmt.Sprintf("foo-%d", i)),
+			Value: []byte(util.RandString(100 * 1024)),
+		})
+		r.NoError(err)
+	}
+
+	t.Log("create worker")
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	client := &interruptedSnapshotClient{SnapshotClient: regattapb.NewSnapshotClient(conn), chunks: 1}
+	w := testWorkerFactory(followerTM, nil, conn).create("test")
+	w.snapshotClient = client
+
+	t.Log("interrupt the snapshot download")
+	r.Error(w.recover())
+	r.NotNil(w.download)
+	r.Equal(uint64(snapshot.DefaultSnapshotChunkSize), w.download.Offset)
+
+	t.Log("resume the snapshot download")
+	r.NoError(w.recover())
+	r.Nil(w.download)
+	r.Len(client.requests, 2)
+	r.Empty(client.requests[0].Id)
+	r.NotEmpty(client.requests[1].Id)
+	r.Equal(uint64(snapshot.DefaultSnapshotChunkSize), client.requests[1].Offset)
+
+	tab, err := followerTM.GetTable("test")
+	r.NoError(err)
+	res, err := tab.Range(ctx, &regattapb.RangeRequest{Key: []byte("foo-"), RangeEnd: []byte("foo."), CountOnly: true})
+	r.NoError(err)
+	r.Equal(int64(30), res.Count)
+}
+
+// interruptedSnapshotClient interrupts the first snapshot stream after the given number of chunks.
+type interruptedSnapshotClient struct {
+	regattapb.SnapshotClient
+	chunks   int
+	requests []*regattapb.SnapshotRequest
+}
+
+func (c *interruptedSnapshotClient) Stream(ctx context.Context, in *regattapb.SnapshotRequest, opts ...grpc.CallOption) (regattapb.Snapshot_StreamClient, error) {
+	c.requests = append(c.requests, &regattapb.SnapshotRequest{Table: in.Table, Id: in.Id, Offset: in.Offset})
+	s, err := c.SnapshotClient.Stream(ctx, in, opts...)
+	if err != nil || len(c.requests) > 1 {
+		return s, err
+	}
+	return &interruptedStream{Snapshot_StreamClient: s, chunks: c.chunks}, nil
+}
+
+type interruptedStream struct {
+	regattapb.Snapshot_StreamClient
+	chunks int
+}
+
+func (s *interruptedStream) RecvMsg(m any) error {
+	if s.chunks == 0 {
+		return errors.New("stream interrupted")
+	}
+	s.chunks--
+	return s.Snapshot_StreamClient.RecvMsg(m)
+}
+
+// workerReplication reports the freshness of the table replicated by the worker.
+type workerReplication struct {
+	w atomic.Pointer[worker]
+}
+
+func (r *workerReplication) Lag(string) (time.Duration, bool) {
+	if w := r.w.Load(); w != nil {
+		return w.lag()
+	}
+	return 0, false
+}
+
+func (r *workerReplication) Freshness(string) (time.Time, bool) {
+	if w := r.w.Load(); w != nil {
+		return w.freshness()
+	}
+	return time.Time{}, false
+}
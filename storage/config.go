@@ -100,6 +100,8 @@ type Config struct {
 	LogDBImplementation LogDBImplementation
 	// LogCacheSize specifies the size of the log cache.
 	LogCacheSize int
+	// WatchHistorySize number of the most recent events retained per table for resuming the watches.
+	WatchHistorySize int
 	// FS is the filesystem to use for log store, useful for testing,
 	// uses the real vfs.Default if nil.
 	FS vfs.FS
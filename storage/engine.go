@@ -8,8 +8,10 @@ import (
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/cluster"
+	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/logreader"
 	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/storage/watch"
 	"github.com/lni/dragonboat/v4"
 	"github.com/lni/dragonboat/v4/config"
 	"github.com/lni/dragonboat/v4/plugin/tan"
@@ -17,11 +19,16 @@ import (
 	protobuf "google.golang.org/protobuf/proto"
 )
 
-const defaultQueryTimeout = 5 * time.Second
+const (
+	defaultQueryTimeout = 5 * time.Second
+	// revisionPollInterval how often the replicated revision is checked while waiting for it.
+	revisionPollInterval = 10 * time.Millisecond
+)
 
 func New(cfg Config) (*Engine, error) {
 	e := &Engine{
-		cfg: cfg,
+		cfg:    cfg,
+		events: watch.NewHub(cfg.WatchHistorySize, watch.DefaultBufferSize),
 	}
 	nh, err := createNodeHost(cfg, e, e)
 	if err != nil {
@@ -32,9 +39,10 @@ func New(cfg Config) (*Engine, error) {
 		nh,
 		cfg.InitialMembers,
 		table.Config{
-			NodeID: cfg.NodeID,
-			Table:  table.TableConfig(cfg.Table),
-			Meta:   table.MetaConfig(cfg.Meta),
+			NodeID:    cfg.NodeID,
+			Table:     table.TableConfig(cfg.Table),
+			Meta:      table.MetaConfig(cfg.Meta),
+			EventSink: e.events,
 		},
 	)
 	if cfg.LogCacheSize > 0 {
@@ -56,6 +64,7 @@ type Engine struct {
 	*dragonboat.NodeHost
 	*table.Manager
 	cfg       Config
+	events    *watch.Hub
 	LogReader logreader.Interface
 	Cluster   *cluster.Cluster
 }
@@ -84,6 +93,28 @@ func (e *Engine) Range(ctx context.Context, req *regattapb.RangeRequest) (*regat
 	return rng, nil
 }
 
+// Cursor streams the results of the Range query to the consumer, the stream is interrupted once the ctx is done.
+func (e *Engine) Cursor(ctx context.Context, req *regattapb.RangeRequest, consumer func(*regattapb.RangeResponse) error) error {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return err
+	}
+	rctx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		dctx, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
+		defer cancel()
+		rctx = dctx
+	}
+	err = t.Cursor(rctx, ctx.Done(), req, func(response *regattapb.RangeResponse) error {
+		response.Header = e.getHeader(nil, t.ClusterID)
+		return consumer(response)
+	})
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
 func (e *Engine) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
 	t, err := e.Manager.GetTable(string(req.Table))
 	if err != nil {
@@ -110,6 +141,61 @@ func (e *Engine) Delete(ctx context.Context, req *regattapb.DeleteRangeRequest)
 	return del, nil
 }
 
+func (e *Engine) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	inc, err := withDefaultTimeout(ctx, req, t.Increment)
+	if err != nil {
+		return nil, err
+	}
+	inc.Header = e.getHeader(inc.Header, t.ClusterID)
+	return inc, nil
+}
+
+// PutBatch puts all the keys of the request in a single proposal, the request must fit into the in-memory Raft log.
+func (e *Engine) PutBatch(ctx context.Context, req *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	if err := e.checkBatchSize(t, req.SizeVT()); err != nil {
+		return nil, err
+	}
+	put, err := withDefaultTimeout(ctx, req, t.PutBatch)
+	if err != nil {
+		return nil, err
+	}
+	put.Header = e.getHeader(put.Header, t.ClusterID)
+	return put, nil
+}
+
+// DeleteBatch deletes all the keys of the request in a single proposal, the request must fit into the in-memory Raft log.
+func (e *Engine) DeleteBatch(ctx context.Context, req *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	if err := e.checkBatchSize(t, req.SizeVT()); err != nil {
+		return nil, err
+	}
+	del, err := withDefaultTimeout(ctx, req, t.DeleteBatch)
+	if err != nil {
+		return nil, err
+	}
+	del.Header = e.getHeader(del.Header, t.ClusterID)
+	return del, nil
+}
+
+// checkBatchSize ensures the batch of the size fits into the in-memory Raft log of the table, otherwise it could never be proposed.
+func (e *Engine) checkBatchSize(t table.ActiveTable, size int) error {
+	if limit := e.Manager.MaxInMemLogSize(t.Table); limit != 0 && uint64(size) > limit {
+		return serrors.ErrBatchSizeExceeded
+	}
+	return nil
+}
+
 func (e *Engine) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
 	t, err := e.Manager.GetTable(string(req.Table))
 	if err != nil {
@@ -123,6 +209,95 @@ func (e *Engine) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb
 	return tx, nil
 }
 
+func (e *Engine) LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	grant, err := withDefaultTimeout(ctx, req, t.LeaseGrant)
+	if err != nil {
+		return nil, err
+	}
+	grant.Header = e.getHeader(grant.Header, t.ClusterID)
+	return grant, nil
+}
+
+func (e *Engine) LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	revoke, err := withDefaultTimeout(ctx, req, t.LeaseRevoke)
+	if err != nil {
+		return nil, err
+	}
+	revoke.Header = e.getHeader(revoke.Header, t.ClusterID)
+	return revoke, nil
+}
+
+func (e *Engine) LeaseKeepAlive(ctx context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	ka, err := withDefaultTimeout(ctx, req, t.LeaseKeepAlive)
+	if err != nil {
+		return nil, err
+	}
+	ka.Header = e.getHeader(ka.Header, t.ClusterID)
+	return ka, nil
+}
+
+func (e *Engine) LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := withDefaultTimeout(ctx, req, t.LeaseTimeToLive)
+	if err != nil {
+		return nil, err
+	}
+	ttl.Header = e.getHeader(nil, t.ClusterID)
+	return ttl, nil
+}
+
+// Watch registers a new watch.Watcher for the changes of the keys requested, the watcher must be closed by the caller.
+func (e *Engine) Watch(_ context.Context, req *regattapb.WatchRequest) (*watch.Watcher, *regattapb.ResponseHeader, error) {
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, nil, err
+	}
+	w, err := e.events.Watch(string(req.Table), req.Key, req.RangeEnd, req.StartRevision, req.PrevKv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return w, e.getHeader(nil, t.ClusterID), nil
+}
+
+// WaitForRevision blocks until the table has replicated at least the revision of the leader cluster or the ctx is done.
+func (e *Engine) WaitForRevision(ctx context.Context, tableName []byte, revision uint64) error {
+	t, err := e.Manager.GetTable(string(tableName))
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(revisionPollInterval)
+	defer ticker.Stop()
+	for {
+		rev, err := t.Revision(ctx, false)
+		if err != nil {
+			return err
+		}
+		if rev.Index >= revision {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 func (e *Engine) getHeader(header *regattapb.ResponseHeader, shardID uint64) *regattapb.ResponseHeader {
 	if header == nil {
 		header = &regattapb.ResponseHeader{}
@@ -15,6 +15,7 @@ import (
 	pvfs "github.com/cockroachdb/pebble/vfs"
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/cluster"
+	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/logreader"
 	"github.com/jamf/regatta/storage/table"
 	lvfs "github.com/lni/vfs"
@@ -189,7 +190,7 @@ func TestEngine_Range(t *testing.T) {
 					ShardId:   10001,
 				},
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte("key"), Value: []byte("value")},
+					{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 				},
 				Count: 1,
 			},
@@ -215,7 +216,7 @@ func TestEngine_Range(t *testing.T) {
 					ShardId:   10001,
 				},
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte("key"), Value: []byte("value")},
+					{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 				},
 				Count: 1,
 			},
@@ -238,6 +239,102 @@ func TestEngine_Range(t *testing.T) {
 	}
 }
 
+func TestEngine_Cursor(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+
+	consume := func(responses *[]*regattapb.RangeResponse) func(*regattapb.RangeResponse) error {
+		return func(response *regattapb.RangeResponse) error {
+			*responses = append(*responses, response)
+			return nil
+		}
+	}
+
+	t.Log("cursor over missing table")
+	var responses []*regattapb.RangeResponse
+	err := e.Cursor(context.Background(), &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key")}, consume(&responses))
+	r.ErrorIs(err, serrors.ErrTableNotFound)
+
+	createTable(t, e)
+	for _, k := range []string{"key1", "key2", "key3"} {
+		_, err := e.Put(context.Background(), &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte(k), Value: []byte("value")})
+		r.NoError(err)
+	}
+
+	t.Log("cursor over all keys")
+	for _, linearizable := range []bool{false, true} {
+		responses = nil
+		err = e.Cursor(context.Background(), &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), RangeEnd: []byte("kez"), Linearizable: linearizable}, consume(&responses))
+		r.NoError(err)
+		r.Len(responses, 1)
+		r.Equal(int64(3), responses[0].Count)
+		r.False(responses[0].More)
+		r.Equal(uint64(10001), responses[0].Header.ShardId)
+		r.Equal(uint64(1), responses[0].Header.ReplicaId)
+	}
+
+	t.Log("cursor canceled")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = e.Cursor(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), RangeEnd: []byte("kez")}, consume(&responses))
+	r.ErrorIs(err, context.Canceled)
+}
+
+func TestEngine_Lease(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+
+	t.Log("grant lease in missing table")
+	_, err := e.LeaseGrant(context.Background(), &regattapb.LeaseGrantRequest{Table: []byte(testTableName), Ttl: 10})
+	r.ErrorIs(err, serrors.ErrTableNotFound)
+
+	createTable(t, e)
+
+	t.Log("grant lease")
+	grant, err := e.LeaseGrant(context.Background(), &regattapb.LeaseGrantRequest{Table: []byte(testTableName), Ttl: 10})
+	r.NoError(err)
+	r.Equal(int64(10), grant.Ttl)
+	r.Equal(uint64(10001), grant.Header.ShardId)
+
+	t.Log("grant existing lease")
+	_, err = e.LeaseGrant(context.Background(), &regattapb.LeaseGrantRequest{Table: []byte(testTableName), Ttl: 10, Id: grant.Id})
+	r.ErrorIs(err, serrors.ErrLeaseExists)
+
+	t.Log("put with lease")
+	_, err = e.Put(context.Background(), &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value"), Lease: grant.Id})
+	r.NoError(err)
+	_, err = e.Put(context.Background(), &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value"), Lease: grant.Id + 1})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+
+	t.Log("keep lease alive")
+	ka, err := e.LeaseKeepAlive(context.Background(), &regattapb.LeaseKeepAliveRequest{Table: []byte(testTableName), Id: grant.Id})
+	r.NoError(err)
+	r.Equal(grant.Id, ka.Id)
+	r.Equal(int64(10), ka.Ttl)
+
+	t.Log("lease time to live")
+	ttl, err := e.LeaseTimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), Id: grant.Id, Keys: true})
+	r.NoError(err)
+	r.Equal(int64(10), ttl.GrantedTtl)
+	r.LessOrEqual(ttl.Ttl, int64(10))
+	r.Equal([][]byte{[]byte("key")}, ttl.Keys)
+
+	t.Log("revoke lease")
+	_, err = e.LeaseRevoke(context.Background(), &regattapb.LeaseRevokeRequest{Table: []byte(testTableName), Id: grant.Id})
+	r.NoError(err)
+	rng, err := e.Range(context.Background(), &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true})
+	r.NoError(err)
+	r.Empty(rng.Kvs)
+	_, err = e.LeaseTimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), Id: grant.Id})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+}
+
 func TestEngine_Put(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -331,8 +428,11 @@ func TestEngine_Put(t *testing.T) {
 					Revision:  4,
 				},
 				PrevKv: &regattapb.KeyValue{
-					Key:   []byte("key"),
-					Value: []byte("value"),
+					Key:            []byte("key"),
+					Value:          []byte("value"),
+					CreateRevision: 3,
+					ModRevision:    3,
+					Version:        1,
 				},
 			},
 			wantErr: require.NoError,
@@ -354,6 +454,162 @@ func TestEngine_Put(t *testing.T) {
 	}
 }
 
+func TestEngine_Increment(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+
+	maximum := int64(3)
+	req := &regattapb.IncrementRequest{Table: []byte(testTableName), Key: []byte("counter"), Delta: 2, Initial: 1, Max: &maximum}
+	got, err := e.Increment(context.Background(), req)
+	r.NoError(err)
+	r.Equal(&regattapb.IncrementResponse{Header: &regattapb.ResponseHeader{ShardId: 10001, ReplicaId: 1, Revision: 3}, Value: 3, Applied: true}, got)
+
+	got, err = e.Increment(context.Background(), req)
+	r.NoError(err)
+	r.Equal(int64(3), got.Value)
+	r.False(got.Applied)
+
+	rng, err := e.Range(context.Background(), &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("counter"), Linearizable: true})
+	r.NoError(err)
+	r.Equal([]byte{0, 0, 0, 0, 0, 0, 0, 3}, rng.Kvs[0].Value)
+	r.Equal(int64(1), rng.Kvs[0].Version)
+}
+
+func TestEngine_WaitForRevision(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+
+	r.ErrorIs(e.WaitForRevision(context.Background(), []byte(testTableName), 1), serrors.ErrTableNotFound)
+
+	createTable(t, e)
+	r.NoError(e.WaitForRevision(context.Background(), []byte(testTableName), 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r.ErrorIs(e.WaitForRevision(ctx, []byte(testTableName), 1), context.DeadlineExceeded)
+}
+
+func TestEngine_PutBatch(t *testing.T) {
+	r := require.New(t)
+	cfg := newTestConfig()
+	cfg.Table.MaxInMemLogSize = 1024
+	e := newTestEngine(cfg)
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+
+	_, err := e.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte(testTableName),
+		Kvs:   []*regattapb.KeyValue{{Key: []byte("key_1"), Value: []byte("value")}},
+	})
+	r.ErrorIs(err, serrors.ErrTableNotFound)
+
+	createTable(t, e)
+	got, err := e.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte(testTableName),
+		Kvs: []*regattapb.KeyValue{
+			{Key: []byte("key_1"), Value: []byte("value")},
+			// MVCC metadata of the request is ignored.
+			{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 10},
+		},
+	})
+	r.NoError(err)
+	r.Equal(&regattapb.PutBatchResponse{Header: &regattapb.ResponseHeader{ShardId: 10001, ReplicaId: 1, Revision: 3}}, got)
+
+	rng, err := e.Range(context.Background(), &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), RangeEnd: []byte("kez"), Linearizable: true})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
+		{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
+	}, rng.Kvs)
+
+	_, err = e.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte(testTableName),
+		Kvs:   []*regattapb.KeyValue{{Key: []byte("key_1"), Value: make([]byte, 2048)}},
+	})
+	r.ErrorIs(err, serrors.ErrBatchSizeExceeded)
+
+	_, err = e.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte(testTableName),
+		Kvs:   []*regattapb.KeyValue{{Key: []byte("key_1"), Value: []byte("value")}, {Value: []byte("value")}},
+	})
+	r.ErrorIs(err, serrors.ErrEmptyKey)
+}
+
+func TestEngine_PutBatchOverrides(t *testing.T) {
+	r := require.New(t)
+	cfg := newTestConfig()
+	cfg.Table.MaxInMemLogSize = 1024
+	e := newTestEngine(cfg)
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+
+	logSize := uint64(64 * 1024)
+	r.NoError(e.CreateTableWithOverrides(testTableName, &table.Overrides{MaxInMemLogSize: &logSize}))
+	r.Eventually(func() bool {
+		tab, err := e.GetTable(testTableName)
+		if err != nil {
+			return false
+		}
+		c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = tab.LocalIndex(c, true)
+		return err == nil
+	}, 1*time.Second, 10*time.Millisecond)
+
+	// The batch over the global limit fits into the in-memory Raft log of the table.
+	_, err := e.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte(testTableName),
+		Kvs:   []*regattapb.KeyValue{{Key: []byte("key_1"), Value: make([]byte, 2048)}},
+	})
+	r.NoError(err)
+
+	_, err = e.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{
+		Table: []byte(testTableName),
+		Keys:  [][]byte{make([]byte, 128*1024)},
+	})
+	r.ErrorIs(err, serrors.ErrBatchSizeExceeded)
+}
+
+func TestEngine_DeleteBatch(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+
+	_, err := e.PutBatch(context.Background(), &regattapb.PutBatchRequest{
+		Table: []byte(testTableName),
+		Kvs: []*regattapb.KeyValue{
+			{Key: []byte("key_1"), Value: []byte("value")},
+			{Key: []byte("key_2"), Value: []byte("value")},
+			{Key: []byte("key_3"), Value: []byte("value")},
+		},
+	})
+	r.NoError(err)
+
+	got, err := e.DeleteBatch(context.Background(), &regattapb.DeleteBatchRequest{
+		Table: []byte(testTableName),
+		Keys:  [][]byte{[]byte("key_1"), []byte("key_3")},
+	})
+	r.NoError(err)
+	r.Equal(&regattapb.DeleteBatchResponse{Header: &regattapb.ResponseHeader{ShardId: 10001, ReplicaId: 1, Revision: 4}}, got)
+
+	rng, err := e.Range(context.Background(), &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), RangeEnd: []byte("kez"), Linearizable: true, KeysOnly: true})
+	r.NoError(err)
+	r.Len(rng.Kvs, 1)
+	r.Equal([]byte("key_2"), rng.Kvs[0].Key)
+}
+
 func TestEngine_Delete(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -450,7 +706,7 @@ func TestEngine_Delete(t *testing.T) {
 				},
 				Deleted: 1,
 				PrevKvs: []*regattapb.KeyValue{
-					{Key: []byte("key"), Value: []byte("value")},
+					{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 				},
 			},
 			wantErr: require.NoError,
@@ -606,7 +862,7 @@ func TestEngine_Txn(t *testing.T) {
 				Succeeded: false,
 				Responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponsePut{
 					ResponsePut: &regattapb.ResponseOp_Put{
-						PrevKv: &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+						PrevKv: &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 					},
 				}}},
 			},
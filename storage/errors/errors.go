@@ -17,6 +17,8 @@ var (
 	ErrKeyLengthExceeded = errors.New("key length exceeded max allowed value")
 	// ErrValueLengthExceeded value length exceeded max allowed value.
 	ErrValueLengthExceeded = errors.New("value length exceeded max allowed value")
+	// ErrBatchSizeExceeded batch size exceeded max allowed value.
+	ErrBatchSizeExceeded = errors.New("batch size exceeded max allowed value")
 	// ErrUnknownQueryType unknown type sent to the Lookup method.
 	ErrUnknownQueryType = errors.New("unknown query type")
 	// ErrInvalidNodeID invalid (negative or 0) node ID.
@@ -29,6 +31,18 @@ var (
 	ErrNoResultFound = errors.New("FSM returned no results")
 	// ErrUnknownResultType FSM returned a result of the wrong type.
 	ErrUnknownResultType = errors.New("unknown result type")
+	// ErrLeaseNotFound returned when the lease is not found.
+	ErrLeaseNotFound = errors.New("lease not found")
+	// ErrLeaseExists returned when the lease with the same ID already exists.
+	ErrLeaseExists = errors.New("lease already exists")
+	// ErrTxnTooDeep returned when the transaction nesting exceeds the maximum depth.
+	ErrTxnTooDeep = errors.New("txn nesting too deep")
+	// ErrCompacted returned when the requested revision has been compacted.
+	ErrCompacted = errors.New("required revision has been compacted")
+	// ErrNotCounter returned when the incremented key holds a value that is not a counter.
+	ErrNotCounter = errors.New("value is not a counter")
+	// ErrFutureRevision returned when the requested revision is newer than the current revision.
+	ErrFutureRevision = errors.New("required revision is a future revision")
 
 	ErrTableExists             = errors.New("table already exists")
 	ErrManagerClosed           = errors.New("manager closed")
@@ -3,7 +3,13 @@
 package table
 
 import (
+	"errors"
+	"time"
+
+	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/table/fsm"
 )
 
@@ -14,6 +20,8 @@ type Config struct {
 	Table TableConfig
 	// Meta is a configuration for metadata inmemory state machine.
 	Meta MetaConfig
+	// EventSink receives the changes of the keys applied to the tables, the changes are not collected if nil.
+	EventSink fsm.EventSink
 }
 
 type SnapshotRecoveryType fsm.SnapshotRecoveryType
@@ -104,6 +112,175 @@ type TableConfig struct {
 	TableCacheSize int
 	// RecoveryType the in-cluster snapshot recovery type.
 	RecoveryType SnapshotRecoveryType
+	// LeaseExpiryInterval how often the expired leases are revoked, the leases are not revoked if 0.
+	LeaseExpiryInterval time.Duration
+	// HistoryRetention the number of the most recent revisions the history of the keys is retained for, the older history
+	// is compacted every HistoryCompactionInterval. The whole history is retained if 0.
+	HistoryRetention uint64
+	// HistoryCompactionInterval how often the history of the keys is compacted to the HistoryRetention, the history
+	// is not compacted automatically if 0.
+	HistoryCompactionInterval time.Duration
+}
+
+// Compression the block compression of the table storage.
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionSnappy Compression = "snappy"
+	CompressionZstd   Compression = "zstd"
+)
+
+// Overrides per-table overrides of the TableConfig stored along with the table, unset fields fall back to the TableConfig values.
+type Overrides struct {
+	// SnapshotEntries overrides TableConfig.SnapshotEntries.
+	SnapshotEntries *uint64 `json:"snapshot_entries,omitempty"`
+	// CompactionOverhead overrides TableConfig.CompactionOverhead.
+	CompactionOverhead *uint64 `json:"compaction_overhead,omitempty"`
+	// MaxInMemLogSize overrides TableConfig.MaxInMemLogSize.
+	MaxInMemLogSize *uint64 `json:"max_in_mem_log_size,omitempty"`
+	// RecoveryType overrides TableConfig.RecoveryType.
+	RecoveryType *SnapshotRecoveryType `json:"recovery_type,omitempty"`
+	// Compression the block compression of the table storage, snappy if unset.
+	Compression *Compression `json:"compression,omitempty"`
+	// BloomFilterBitsPerKey the bits per key of the bloom filters of the table storage, bloom filters are not created if 0.
+	BloomFilterBitsPerKey *uint32 `json:"bloom_filter_bits_per_key,omitempty"`
+	// MemTableSize the size of a single memtable of the table storage in bytes.
+	MemTableSize *uint64 `json:"memtable_size,omitempty"`
+	// MaxValueLen the maximum length of a value in bytes, MaxValueLen if unset.
+	MaxValueLen *uint64 `json:"max_value_len,omitempty"`
+	// HistoryRetention overrides TableConfig.HistoryRetention.
+	HistoryRetention *uint64 `json:"history_retention,omitempty"`
+}
+
+// withOverrides returns the copy of the config with the overrides applied.
+func (c TableConfig) withOverrides(o *Overrides) TableConfig {
+	if o == nil {
+		return c
+	}
+	if o.SnapshotEntries != nil {
+		c.SnapshotEntries = *o.SnapshotEntries
+	}
+	if o.CompactionOverhead != nil {
+		c.CompactionOverhead = *o.CompactionOverhead
+	}
+	if o.MaxInMemLogSize != nil {
+		c.MaxInMemLogSize = *o.MaxInMemLogSize
+	}
+	if o.RecoveryType != nil {
+		c.RecoveryType = *o.RecoveryType
+	}
+	if o.HistoryRetention != nil {
+		c.HistoryRetention = *o.HistoryRetention
+	}
+	return c
+}
+
+// dbOptions returns the pebble options of the table storage.
+func (o *Overrides) dbOptions() []rp.Option {
+	if o == nil {
+		return nil
+	}
+	var opts []rp.Option
+	if o.Compression != nil {
+		switch *o.Compression {
+		case CompressionNone:
+			opts = append(opts, rp.WithCompression(pebble.NoCompression))
+		case CompressionZstd:
+			opts = append(opts, rp.WithCompression(pebble.ZstdCompression))
+		default:
+			opts = append(opts, rp.WithCompression(pebble.SnappyCompression))
+		}
+	}
+	if o.BloomFilterBitsPerKey != nil {
+		opts = append(opts, rp.WithBloomFilter(int(*o.BloomFilterBitsPerKey)))
+	}
+	if o.MemTableSize != nil {
+		opts = append(opts, rp.WithMemTableSize(int(*o.MemTableSize)))
+	}
+	return opts
+}
+
+// OverridesFromConfig converts the table config of the API to the table overrides.
+func OverridesFromConfig(cfg *regattapb.TableConfig) (*Overrides, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if cfg.MemtableSize != nil && *cfg.MemtableSize == 0 {
+		return nil, errors.New("memtable_size must be a positive number")
+	}
+	if cfg.MaxValueSize != nil && *cfg.MaxValueSize == 0 {
+		return nil, errors.New("max_value_size must be a positive number")
+	}
+	o := &Overrides{
+		SnapshotEntries:       cfg.SnapshotEntries,
+		CompactionOverhead:    cfg.CompactionOverhead,
+		MaxInMemLogSize:       cfg.MaxInMemLogSize,
+		BloomFilterBitsPerKey: cfg.BloomFilterBitsPerKey,
+		MemTableSize:          cfg.MemtableSize,
+		MaxValueLen:           cfg.MaxValueSize,
+		HistoryRetention:      cfg.HistoryRetention,
+	}
+	switch cfg.RecoveryType {
+	case regattapb.TableConfig_RECOVERY_DEFAULT:
+	case regattapb.TableConfig_SNAPSHOT:
+		o.RecoveryType = ptr(RecoveryTypeSnapshot)
+	case regattapb.TableConfig_CHECKPOINT:
+		o.RecoveryType = ptr(RecoveryTypeCheckpoint)
+	default:
+		return nil, errors.New("unknown recovery_type")
+	}
+	switch cfg.Compression {
+	case regattapb.TableConfig_COMPRESSION_DEFAULT:
+	case regattapb.TableConfig_NONE:
+		o.Compression = ptr(CompressionNone)
+	case regattapb.TableConfig_SNAPPY:
+		o.Compression = ptr(CompressionSnappy)
+	case regattapb.TableConfig_ZSTD:
+		o.Compression = ptr(CompressionZstd)
+	default:
+		return nil, errors.New("unknown compression")
+	}
+	return o, nil
+}
+
+// Config converts the table overrides to the table config of the API.
+func (o *Overrides) Config() *regattapb.TableConfig {
+	if o == nil {
+		return nil
+	}
+	cfg := &regattapb.TableConfig{
+		SnapshotEntries:       o.SnapshotEntries,
+		CompactionOverhead:    o.CompactionOverhead,
+		MaxInMemLogSize:       o.MaxInMemLogSize,
+		BloomFilterBitsPerKey: o.BloomFilterBitsPerKey,
+		MemtableSize:          o.MemTableSize,
+		MaxValueSize:          o.MaxValueLen,
+		HistoryRetention:      o.HistoryRetention,
+	}
+	if o.RecoveryType != nil {
+		switch *o.RecoveryType {
+		case RecoveryTypeSnapshot:
+			cfg.RecoveryType = regattapb.TableConfig_SNAPSHOT
+		case RecoveryTypeCheckpoint:
+			cfg.RecoveryType = regattapb.TableConfig_CHECKPOINT
+		}
+	}
+	if o.Compression != nil {
+		switch *o.Compression {
+		case CompressionNone:
+			cfg.Compression = regattapb.TableConfig_NONE
+		case CompressionSnappy:
+			cfg.Compression = regattapb.TableConfig_SNAPPY
+		case CompressionZstd:
+			cfg.Compression = regattapb.TableConfig_ZSTD
+		}
+	}
+	return cfg
+}
+
+func ptr[T any](v T) *T {
+	return &v
 }
 
 type MetaConfig struct {
@@ -0,0 +1,74 @@
+// Copyright JAMF Software, LLC
+
+package table
+
+import (
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableConfig_withOverrides(t *testing.T) {
+	r := require.New(t)
+	cfg := TableConfig{SnapshotEntries: 10, CompactionOverhead: 5, MaxInMemLogSize: 1024, RecoveryType: RecoveryTypeCheckpoint, ElectionRTT: 10}
+	r.Equal(cfg, cfg.withOverrides(nil))
+
+	snapshotEntries := uint64(0)
+	maxInMemLogSize := uint64(2048)
+	recoveryType := RecoveryTypeSnapshot
+	historyRetention := uint64(100)
+	got := cfg.withOverrides(&Overrides{SnapshotEntries: &snapshotEntries, MaxInMemLogSize: &maxInMemLogSize, RecoveryType: &recoveryType, HistoryRetention: &historyRetention})
+	r.Equal(TableConfig{SnapshotEntries: 0, CompactionOverhead: 5, MaxInMemLogSize: 2048, RecoveryType: RecoveryTypeSnapshot, ElectionRTT: 10, HistoryRetention: 100}, got)
+}
+
+func TestOverrides_dbOptions(t *testing.T) {
+	r := require.New(t)
+	var o *Overrides
+	r.Empty(o.dbOptions())
+
+	compression := CompressionNone
+	bits := uint32(5)
+	memTableSize := uint64(1024 * 1024)
+	o = &Overrides{Compression: &compression, BloomFilterBitsPerKey: &bits, MemTableSize: &memTableSize}
+	r.Len(o.dbOptions(), 3)
+}
+
+func TestOverridesFromConfig(t *testing.T) {
+	r := require.New(t)
+	snapshotEntries := uint64(100)
+	bits := uint32(0)
+	maxValueSize := uint64(1024)
+	historyRetention := uint64(0)
+	cfg := &regattapb.TableConfig{
+		SnapshotEntries:       &snapshotEntries,
+		RecoveryType:          regattapb.TableConfig_CHECKPOINT,
+		Compression:           regattapb.TableConfig_NONE,
+		BloomFilterBitsPerKey: &bits,
+		MaxValueSize:          &maxValueSize,
+		HistoryRetention:      &historyRetention,
+	}
+	o, err := OverridesFromConfig(cfg)
+	r.NoError(err)
+	r.Equal(RecoveryTypeCheckpoint, *o.RecoveryType)
+	r.Equal(CompressionNone, *o.Compression)
+	r.Equal(cfg, o.Config())
+
+	// The config survives the round trip through the wire format.
+	bts, err := cfg.MarshalVT()
+	r.NoError(err)
+	decoded := &regattapb.TableConfig{}
+	r.NoError(decoded.UnmarshalVT(bts))
+	r.Equal(historyRetention, *decoded.HistoryRetention)
+
+	o, err = OverridesFromConfig(nil)
+	r.NoError(err)
+	r.Nil(o)
+	r.Nil(o.Config())
+
+	zero := uint64(0)
+	_, err = OverridesFromConfig(&regattapb.TableConfig{MemtableSize: &zero})
+	r.Error(err)
+	_, err = OverridesFromConfig(&regattapb.TableConfig{Compression: regattapb.TableConfig_Compression(10)})
+	r.Error(err)
+}
@@ -4,8 +4,10 @@ package fsm
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
 	"github.com/jamf/regatta/regattapb"
 	sm "github.com/lni/dragonboat/v4/statemachine"
 	pb "google.golang.org/protobuf/proto"
@@ -16,6 +18,22 @@ type updateContext struct {
 	db          *pebble.DB
 	index       uint64
 	leaderIndex *uint64
+	// trackEvents whether the changes of the keys should be collected into events.
+	trackEvents bool
+	events      []*regattapb.Event
+	// fs and dirname of the state machine the ingested files are staged in.
+	fs      vfs.FS
+	dirname string
+	// reloaded whether the keys were changed bypassing the events, the events must be reset.
+	reloaded bool
+	// appliedIndex and appliedLeaderIndex the indexes of the last entry of the update applied whole, zero if none.
+	appliedIndex       uint64
+	appliedLeaderIndex *uint64
+	// entryStart the number of the writes in the batch before the currently applied entry.
+	entryStart uint32
+	// revisionOffset and leaderRevisionOffset the offsets of the revisions from the local and the leader index.
+	revisionOffset       uint64
+	leaderRevisionOffset uint64
 }
 
 func (c *updateContext) EnsureIndexed() error {
@@ -34,6 +52,22 @@ func (c *updateContext) EnsureIndexed() error {
 	return nil
 }
 
+// Revision returns the revision of the currently applied command, commands replicated from the leader cluster
+// keep the revision assigned by the leader cluster.
+func (c *updateContext) Revision() int64 {
+	if c.leaderIndex != nil {
+		return int64(*c.leaderIndex + c.leaderRevisionOffset)
+	}
+	return int64(c.index + c.revisionOffset)
+}
+
+// addEvent appends the event if the events are tracked.
+func (c *updateContext) addEvent(typ regattapb.Event_EventType, kv, prev *regattapb.KeyValue) {
+	if c.trackEvents {
+		c.events = append(c.events, &regattapb.Event{Type: typ, Kv: kv, PrevKv: prev})
+	}
+}
+
 func (c *updateContext) Commit() error {
 	// Set leader index if present in the proposal
 	if c.leaderIndex != nil {
@@ -49,9 +83,92 @@ func (c *updateContext) Commit() error {
 	if err := c.batch.Set(sysLocalIndex, idx, nil); err != nil {
 		return err
 	}
+	if err := c.batch.Set(sysRevision, binary.LittleEndian.AppendUint64(nil, uint64(c.Revision())), nil); err != nil {
+		return err
+	}
 	return c.batch.Commit(pebble.NoSync)
 }
 
+// commitPending commits the writes of the entries applied whole so far and starts a new batch. Nothing is committed
+// and false is returned if the currently applied entry has already written to the batch, its writes could not be
+// committed without its index.
+func (c *updateContext) commitPending() (bool, error) {
+	if c.batch.Count() != c.entryStart {
+		return false, nil
+	}
+	if c.batch.Empty() {
+		return true, nil
+	}
+	if c.appliedIndex != 0 {
+		pending := &updateContext{
+			batch:                c.batch,
+			index:                c.appliedIndex,
+			leaderIndex:          c.appliedLeaderIndex,
+			revisionOffset:       c.revisionOffset,
+			leaderRevisionOffset: c.leaderRevisionOffset,
+		}
+		if err := pending.Commit(); err != nil {
+			return false, err
+		}
+	} else if err := c.batch.Commit(pebble.NoSync); err != nil {
+		return false, err
+	}
+	if err := c.batch.Close(); err != nil {
+		return false, err
+	}
+	c.batch = c.db.NewBatch()
+	c.entryStart = 0
+	return true, nil
+}
+
+// ingest ingests the SST files into the DB, the ingested keys are placed above all the committed ones.
+func (c *updateContext) ingest(ssts [][]byte) error {
+	files := make([]string, 0, len(ssts))
+	defer func() {
+		for _, f := range files {
+			_ = c.fs.Remove(f)
+		}
+	}()
+	for i, sst := range ssts {
+		name := c.fs.PathJoin(c.dirname, fmt.Sprintf("ingest-%d-%d.sst", c.index, i))
+		f, err := c.fs.Create(name)
+		if err != nil {
+			return err
+		}
+		files = append(files, name)
+		if _, err := f.Write(sst); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	if err := c.db.Ingest(files); err != nil {
+		return err
+	}
+	c.reloaded = true
+	return nil
+}
+
+// writeIngested writes the key values of the SST files into the batch, the keys are committed together
+// with the rest of the currently applied entry.
+func (c *updateContext) writeIngested(ssts [][]byte) error {
+	for _, sst := range ssts {
+		if err := iterIngested(sst, func(k, v []byte) error {
+			return c.batch.Set(k, v, nil)
+		}); err != nil {
+			return err
+		}
+	}
+	c.reloaded = true
+	return nil
+}
+
 func (c *updateContext) Close() error {
 	if err := c.batch.Close(); err != nil {
 		return err
@@ -83,8 +200,20 @@ func wrapCommand(cmd *regattapb.Command) command {
 		return commandTxn{cmd}
 	case regattapb.Command_SEQUENCE:
 		return commandSequence{cmd}
+	case regattapb.Command_LEASE_GRANT:
+		return commandLeaseGrant{cmd}
+	case regattapb.Command_LEASE_REVOKE:
+		return commandLeaseRevoke{cmd}
+	case regattapb.Command_LEASE_KEEPALIVE:
+		return commandLeaseKeepAlive{cmd}
+	case regattapb.Command_COMPACT:
+		return commandCompact{cmd}
+	case regattapb.Command_INCREMENT:
+		return commandIncrement{cmd}
+	case regattapb.Command_INGEST:
+		return commandIngest{cmd}
 	case regattapb.Command_DUMMY:
-		return commandDummy{}
+		return commandDummy{cmd}
 	}
 	panic("unknown command type")
 }
@@ -101,6 +230,10 @@ func wrapRequestOp(req pb.Message) *regattapb.RequestOp {
 		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestPut{RequestPut: op}}
 	case *regattapb.RequestOp_DeleteRange:
 		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: op}}
+	case *regattapb.Txn:
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: op}}
+	case *regattapb.RequestOp_Increment:
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: op}}
 	}
 	return nil
 }
@@ -113,6 +246,10 @@ func wrapResponseOp(req pb.Message) *regattapb.ResponseOp {
 		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: op}}
 	case *regattapb.ResponseOp_DeleteRange:
 		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: op}}
+	case *regattapb.ResponseOp_Txn:
+		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseTxn{ResponseTxn: op}}
+	case *regattapb.ResponseOp_Increment:
+		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseIncrement{ResponseIncrement: op}}
 	}
 	return nil
 }
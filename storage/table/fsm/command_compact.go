@@ -0,0 +1,184 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+// maxCompactRecords the maximum number of the history records scanned by a single COMPACT command, the compaction
+// of the larger tables continues in the following COMPACT commands.
+const maxCompactRecords = 10000
+
+type commandCompact struct {
+	*regattapb.Command
+}
+
+func (c commandCompact) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	compacted, err := readLocalIndex(ctx.batch, sysCompactRevision)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	cursor, err := readCompactCursor(ctx.batch)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	// The compaction to the compact revision continues from the cursor until the whole history is compacted.
+	if c.Revision < int64(compacted) || (c.Revision == int64(compacted) && cursor == nil) {
+		return ResultCompacted, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	if c.Revision > ctx.Revision() {
+		return ResultFutureRevision, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	if c.Revision > int64(compacted) {
+		cursor = nil
+		if err := writeCompactRevision(ctx.batch, c.Revision); err != nil {
+			return ResultFailure, nil, err
+		}
+	}
+	next, err := compactHistory(ctx.batch, c.Revision, cursor, maxCompactRecords)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if next != nil {
+		if err := ctx.batch.Set(sysCompactCursor, next, nil); err != nil {
+			return ResultFailure, nil, err
+		}
+		return ResultCompactPending, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	if err := ctx.batch.Delete(sysCompactCursor, nil); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+}
+
+// readCompactCursor returns the key the pending compaction continues from, nil if no compaction is pending.
+func readCompactCursor(reader pebble.Reader) ([]byte, error) {
+	v, closer, err := reader.Get(sysCompactCursor)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	return bytes.Clone(v), nil
+}
+
+// compactHistory discards the history records no longer needed to read the table at the revision rev or any newer revision.
+// The records are scanned from the start key (from the first record if nil) until the limit of the scanned records is reached,
+// the key the compaction continues from is returned, nil once the whole history is compacted.
+// The obsolete records of a key are adjacent, they are discarded by a single range deletion.
+func compactHistory(batch *pebble.Batch, rev int64, start []byte, limit int) ([]byte, error) {
+	lower := key.AppendV2Prefix(nil, key.TypeUser, nil)
+	if start != nil {
+		lower = start
+	}
+	iter := batch.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: key.AppendV2Prefix(nil, key.TypeUser+1, nil),
+	})
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	type deletion struct {
+		start, end []byte
+	}
+	var (
+		obsolete []deletion
+		next     []byte
+		scanned  int
+		// group is the user key of the currently iterated records.
+		group []byte
+		// first and newest are the oldest and the newest records of the group at a revision <= rev.
+		first, newest   []byte
+		count           int
+		newestTombstone bool
+	)
+	flush := func() error {
+		if newest == nil {
+			return nil
+		}
+		defer func() {
+			first, newest, count = nil, nil, 0
+		}()
+		drop := newestTombstone
+		if !drop {
+			latest, err := readUserValue(batch, group)
+			if err != nil {
+				return err
+			}
+			drop = latest != nil && latest.ModRevision <= rev
+		}
+		end := newest
+		if drop {
+			end = append(bytes.Clone(newest), 0)
+		} else {
+			count--
+		}
+		switch {
+		case count == 1:
+			obsolete = append(obsolete, deletion{start: first})
+		case count > 1:
+			obsolete = append(obsolete, deletion{start: first, end: end})
+		}
+		return nil
+	}
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, err := key.DecodeBytes(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(k.Key, group) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			if scanned >= limit {
+				next = key.AppendV2Prefix(nil, key.TypeUser, k.Key)
+				break
+			}
+			group = bytes.Clone(k.Key)
+		}
+		scanned++
+		if k.Revision > rev {
+			continue
+		}
+		if first == nil {
+			first = bytes.Clone(iter.Key())
+		}
+		count++
+		newest = bytes.Clone(iter.Key())
+		newestTombstone = len(iter.Value()) == 0
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	if next == nil {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range obsolete {
+		var err error
+		if d.end == nil {
+			err = batch.Delete(d.start, nil)
+		} else {
+			err = batch.DeleteRange(d.start, d.end, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return next, nil
+}
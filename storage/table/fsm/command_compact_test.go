@@ -0,0 +1,141 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/key"
+	"github.com/stretchr/testify/require"
+)
+
+func countHistory(t *testing.T, c *updateContext) int {
+	iter := c.db.NewIter(historyIterOptions(nil, wildcard))
+	defer func() { _ = iter.Close() }()
+	n := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		n++
+	}
+	require.NoError(t, iter.Error())
+	return n
+}
+
+func TestCommandCompact_handle(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 6}
+	defer func() { _ = c.Close() }()
+	// key_1@1, key_1@2, key_1@3 (tombstone), key_2@2, key_2@4 (tombstone).
+	r.Equal(5, countHistory(t, c))
+
+	res, _, err := commandCompact{&regattapb.Command{Type: regattapb.Command_COMPACT, Revision: 7}}.handle(c)
+	r.NoError(err)
+	r.Equal(ResultFutureRevision, res)
+
+	res, _, err = commandCompact{&regattapb.Command{Type: regattapb.Command_COMPACT, Revision: 2}}.handle(c)
+	r.NoError(err)
+	r.Equal(ResultSuccess, res)
+	r.NoError(c.Commit())
+	// key_1@1 is superseded at the revision 2.
+	r.Equal(4, countHistory(t, c))
+
+	_, err = lookup(db, &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 1})
+	r.ErrorIs(err, serrors.ErrCompacted)
+	got, err := lookup(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{historyKV("key_1", "value_2", 1, 2, 2), historyKV("key_2", "value_1", 2, 2, 1)}, got.Kvs)
+
+	c.batch = db.NewBatch()
+	c.index = 7
+	res, _, err = commandCompact{&regattapb.Command{Type: regattapb.Command_COMPACT, Revision: 2}}.handle(c)
+	r.NoError(err)
+	r.Equal(ResultCompacted, res)
+
+	res, _, err = commandCompact{&regattapb.Command{Type: regattapb.Command_COMPACT, Revision: 4}}.handle(c)
+	r.NoError(err)
+	r.Equal(ResultSuccess, res)
+	r.NoError(c.Commit())
+	// Both keys were deleted before the revision 4 and key_1 was recreated, no history is needed anymore.
+	r.Equal(0, countHistory(t, c))
+
+	got, err = lookup(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 4})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{historyKV("key_1", "value_3", 4, 4, 1)}, got.Kvs)
+}
+
+func TestCommandPutBatch_RestoreCompactRevision(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 6}
+	defer func() { _ = c.Close() }()
+	_, _, err := commandPutBatch{&regattapb.Command{
+		Type: regattapb.Command_PUT_BATCH,
+		Batch: []*regattapb.KeyValue{
+			{Key: []byte("restored_1"), Value: []byte("value"), CreateRevision: 2, ModRevision: 3, Version: 2},
+			{Key: []byte("restored_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+		},
+	}}.handle(c)
+	r.NoError(err)
+	r.NoError(c.Commit())
+
+	compacted, err := readLocalIndex(db, sysCompactRevision)
+	r.NoError(err)
+	r.Equal(uint64(3), compacted)
+}
+
+func TestCompactHistory_steps(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewIndexedBatch(), db: db, index: 6}
+	defer func() { _ = c.Close() }()
+
+	// key_1@1, key_1@2 and key_1@3 (tombstone) are scanned by the first step.
+	next, err := compactHistory(c.batch, 4, nil, 2)
+	r.NoError(err)
+	r.Equal(key.AppendV2Prefix(nil, key.TypeUser, []byte("key_2")), next)
+	r.NoError(c.Commit())
+	r.Equal(2, countHistory(t, c))
+
+	c.batch = db.NewIndexedBatch()
+	next, err = compactHistory(c.batch, 4, next, 2)
+	r.NoError(err)
+	r.Nil(next)
+	r.NoError(c.Commit())
+	r.Equal(0, countHistory(t, c))
+}
+
+func TestCommandCompact_handleCursor(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 6}
+	defer func() { _ = c.Close() }()
+	// The compaction to the revision 4 is pending, continuing from key_2.
+	r.NoError(writeCompactRevision(c.batch, 4))
+	r.NoError(c.batch.Set(sysCompactCursor, key.AppendV2Prefix(nil, key.TypeUser, []byte("key_2")), nil))
+
+	res, _, err := commandCompact{&regattapb.Command{Type: regattapb.Command_COMPACT, Revision: 4}}.handle(c)
+	r.NoError(err)
+	r.Equal(ResultSuccess, res)
+	r.NoError(c.Commit())
+	// Only the history of key_1 is left.
+	r.Equal(3, countHistory(t, c))
+	cursor, err := readCompactCursor(db)
+	r.NoError(err)
+	r.Nil(cursor)
+
+	c.batch = db.NewBatch()
+	c.index = 7
+	res, _, err = commandCompact{&regattapb.Command{Type: regattapb.Command_COMPACT, Revision: 4}}.handle(c)
+	r.NoError(err)
+	r.Equal(ResultCompacted, res)
+}
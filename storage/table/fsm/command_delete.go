@@ -4,10 +4,10 @@ package fsm
 
 import (
 	"bytes"
-	"errors"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
 )
 
 type commandDelete struct {
@@ -25,7 +25,7 @@ func (c commandDelete) handle(ctx *updateContext) (UpdateResult, *regattapb.Comm
 		return ResultFailure, nil, err
 	}
 	return ResultSuccess, &regattapb.CommandResult{
-		Revision:  ctx.index,
+		Revision:  uint64(ctx.Revision()),
 		Responses: []*regattapb.ResponseOp{wrapResponseOp(resp)},
 	}, nil
 }
@@ -67,21 +67,35 @@ func handleDelete(ctx *updateContext, del *regattapb.RequestOp_DeleteRange) (*re
 			end = upperBoundBuf.Bytes()
 		}
 
+		if err := deleteHistory(ctx, keyBuf.Bytes(), end); err != nil {
+			return nil, err
+		}
 		if err := ctx.batch.DeleteRange(keyBuf.Bytes(), end, nil); err != nil {
 			return nil, err
 		}
 	} else {
-		if del.PrevKv || del.Count {
-			if err := ctx.EnsureIndexed(); err != nil {
+		if err := ctx.EnsureIndexed(); err != nil {
+			return nil, err
+		}
+		prev, err := readValue(ctx.batch, keyBuf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if prev != nil {
+			if err := writeHistory(ctx.batch, del.Key, *prev); err != nil {
 				return nil, err
 			}
-			rng, err := singleLookup(ctx.batch, &regattapb.RequestOp_Range{Key: del.Key, CountOnly: del.Count && !del.PrevKv})
-			if err != nil && !errors.Is(err, pebble.ErrNotFound) {
+			if err := writeTombstone(ctx.batch, del.Key, ctx.Revision()); err != nil {
 				return nil, err
 			}
-			if !errors.Is(err, pebble.ErrNotFound) {
+			if del.PrevKv || del.Count {
 				resp.Deleted = 1
-				resp.PrevKvs = rng.Kvs
+			}
+			if del.PrevKv {
+				resp.PrevKvs = []*regattapb.KeyValue{keyValue(del.Key, *prev, false)}
+			}
+			if ctx.trackEvents {
+				ctx.addEvent(regattapb.Event_DELETE, &regattapb.KeyValue{Key: del.Key, ModRevision: ctx.Revision()}, keyValue(del.Key, *prev, false))
 			}
 		}
 		if err := ctx.batch.Delete(keyBuf.Bytes(), nil); err != nil {
@@ -91,6 +105,39 @@ func handleDelete(ctx *updateContext, del *regattapb.RequestOp_DeleteRange) (*re
 	return resp, nil
 }
 
+// deleteHistory stores the history of all the keys in the encoded range [start, end) being deleted
+// and adds the DELETE events for them.
+func deleteHistory(ctx *updateContext, start, end []byte) error {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return err
+	}
+	iter := ctx.batch.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	defer func() {
+		_ = iter.Close()
+	}()
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, err := key.DecodeBytes(iter.Key())
+		if err != nil {
+			return err
+		}
+		value, err := key.DecodeValue(iter.Value())
+		if err != nil {
+			return err
+		}
+		if err := writeHistory(ctx.batch, k.Key, value); err != nil {
+			return err
+		}
+		if err := writeTombstone(ctx.batch, k.Key, ctx.Revision()); err != nil {
+			return err
+		}
+		if ctx.trackEvents {
+			prev := keyValue(bytes.Clone(k.Key), value, false)
+			ctx.addEvent(regattapb.Event_DELETE, &regattapb.KeyValue{Key: prev.Key, ModRevision: ctx.Revision()}, prev)
+		}
+	}
+	return iter.Error()
+}
+
 type commandDeleteBatch struct {
 	*regattapb.Command
 }
@@ -111,7 +158,7 @@ func (c commandDeleteBatch) handle(ctx *updateContext) (UpdateResult, *regattapb
 		res = append(res, wrapResponseOp(put))
 	}
 	return ResultSuccess, &regattapb.CommandResult{
-		Revision:  ctx.index,
+		Revision:  uint64(ctx.Revision()),
 		Responses: res,
 	}, nil
 }
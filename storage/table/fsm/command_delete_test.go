@@ -43,7 +43,7 @@ func Test_handleDelete(t *testing.T) {
 		PrevKv: true,
 	})
 	r.NoError(err)
-	r.Equal(&regattapb.ResponseOp_DeleteRange{Deleted: 1, PrevKvs: []*regattapb.KeyValue{{Key: []byte("key_1"), Value: []byte("value_1")}}}, res)
+	r.Equal(&regattapb.ResponseOp_DeleteRange{Deleted: 1, PrevKvs: []*regattapb.KeyValue{{Key: []byte("key_1"), Value: []byte("value_1"), CreateRevision: 1, ModRevision: 1, Version: 1}}}, res)
 	r.NoError(c.Commit())
 
 	// Assert that there are no more user keys left.
@@ -74,12 +74,12 @@ func Test_handleDeleteBatch(t *testing.T) {
 	defer func() { _ = c.Close() }()
 
 	// Make the PUT_BATCH.
-	_, err = handlePutBatch(c, []*regattapb.RequestOp_Put{
+	_, _, err = commandPutBatch{&regattapb.Command{Batch: []*regattapb.KeyValue{
 		{Key: []byte("key_1"), Value: []byte("value")},
 		{Key: []byte("key_2"), Value: []byte("value")},
 		{Key: []byte("key_3"), Value: []byte("value")},
 		{Key: []byte("key_4"), Value: []byte("value")},
-	})
+	}}}.handle(c)
 	r.NoError(err)
 	r.NoError(c.Commit())
 
@@ -124,12 +124,12 @@ func Test_handleDeleteRange(t *testing.T) {
 	defer func() { _ = c.Close() }()
 
 	// Make the PUT_BATCH.
-	_, err = handlePutBatch(c, []*regattapb.RequestOp_Put{
+	_, _, err = commandPutBatch{&regattapb.Command{Batch: []*regattapb.KeyValue{
 		{Key: []byte("key_1"), Value: []byte("value")},
 		{Key: []byte("key_2"), Value: []byte("value")},
 		{Key: []byte("key_3"), Value: []byte("value")},
 		{Key: []byte("key_4"), Value: []byte("value")},
-	})
+	}}}.handle(c)
 	r.NoError(err)
 	r.NoError(c.Commit())
 
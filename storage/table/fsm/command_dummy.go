@@ -6,8 +6,16 @@ import (
 	"github.com/jamf/regatta/regattapb"
 )
 
-type commandDummy struct{}
+type commandDummy struct {
+	*regattapb.Command
+}
 
 func (c commandDummy) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
-	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index}, nil
+	// The DUMMY command closing the restored snapshot carries the revision at the leader index of the snapshot.
+	if c.Revision != 0 && c.LeaderIndex != nil {
+		if err := raiseLeaderRevision(ctx, *c.LeaderIndex, c.Revision); err != nil {
+			return ResultFailure, nil, err
+		}
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
 }
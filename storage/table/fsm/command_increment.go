@@ -0,0 +1,128 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+// counterLen the length of the value of the counter, a big-endian encoded signed integer.
+const counterLen = 8
+
+// errNotCounter the incremented key holds a value that is not a counter.
+var errNotCounter = errors.New("value is not a counter")
+
+type commandIncrement struct {
+	*regattapb.Command
+}
+
+func (c commandIncrement) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	resp, err := handleIncrement(ctx, c.Increment)
+	if errors.Is(err, errNotCounter) {
+		return ResultNotCounter, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	result := ResultSuccess
+	if !resp.Applied {
+		result = ResultFailure
+	}
+	return result, &regattapb.CommandResult{
+		Revision:  uint64(ctx.Revision()),
+		Responses: []*regattapb.ResponseOp{wrapResponseOp(resp)},
+	}, nil
+}
+
+// handleIncrement adds the delta to the counter stored in the key. The delta is not added if the result would be out of the bounds,
+// the response is not applied and carries the current value in such a case. errNotCounter is returned if the key holds
+// a value that is not a counter.
+func handleIncrement(ctx *updateContext, inc *regattapb.RequestOp_Increment) (*regattapb.ResponseOp_Increment, error) {
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	if err := encodeUserKey(keyBuf, inc.Key); err != nil {
+		return nil, err
+	}
+	if err := ctx.EnsureIndexed(); err != nil {
+		return nil, err
+	}
+	prev, err := readValue(ctx.batch, keyBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	current := inc.Initial
+	if prev != nil {
+		if len(prev.Data) != counterLen {
+			return nil, errNotCounter
+		}
+		current = int64(binary.BigEndian.Uint64(prev.Data))
+	}
+	next := current + inc.Delta
+	overflow := (inc.Delta > 0 && next < current) || (inc.Delta < 0 && next > current)
+	if overflow || (inc.Min != nil && next < *inc.Min) || (inc.Max != nil && next > *inc.Max) {
+		return &regattapb.ResponseOp_Increment{Value: current}, nil
+	}
+
+	rev := ctx.Revision()
+	val := key.Value{CreateRevision: rev, ModRevision: rev, Version: 1, Data: binary.BigEndian.AppendUint64(nil, uint64(next))}
+	var prevKv *regattapb.KeyValue
+	if prev != nil {
+		val.CreateRevision = prev.CreateRevision
+		val.Version = prev.Version + 1
+		// The counter stays attached to its lease.
+		val.Lease = prev.Lease
+		if err := writeHistory(ctx.batch, inc.Key, *prev); err != nil {
+			return nil, err
+		}
+		if ctx.trackEvents {
+			prevKv = keyValue(inc.Key, *prev, false)
+		}
+	}
+	if err := ctx.batch.Set(keyBuf.Bytes(), key.EncodeValue(nil, val), nil); err != nil {
+		return nil, err
+	}
+	if ctx.trackEvents {
+		ctx.addEvent(regattapb.Event_PUT, keyValue(inc.Key, val, false), prevKv)
+	}
+	return &regattapb.ResponseOp_Increment{Value: next, Applied: true}, nil
+}
+
+// checkCounters ensures all the keys the increment operations refer to hold a counter or do not exist.
+// Both branches of the nested transactions are checked as the branch taken is not known upfront.
+func checkCounters(reader pebble.Reader, ops []*regattapb.RequestOp) error {
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	for _, op := range ops {
+		if txn := op.GetRequestTxn(); txn != nil {
+			if err := checkCounters(reader, txn.Success); err != nil {
+				return err
+			}
+			if err := checkCounters(reader, txn.Failure); err != nil {
+				return err
+			}
+			continue
+		}
+		inc := op.GetRequestIncrement()
+		if inc == nil {
+			continue
+		}
+		keyBuf.Reset()
+		if err := encodeUserKey(keyBuf, inc.Key); err != nil {
+			return err
+		}
+		prev, err := readValue(reader, keyBuf.Bytes())
+		if err != nil {
+			return err
+		}
+		if prev != nil && len(prev.Data) != counterLen {
+			return errNotCounter
+		}
+	}
+	return nil
+}
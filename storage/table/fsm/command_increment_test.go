@@ -0,0 +1,169 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/stretchr/testify/require"
+)
+
+func counter(v int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(v))
+}
+
+func Test_handleIncrement(t *testing.T) {
+	ptr := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name    string
+		prev    []byte
+		inc     *regattapb.RequestOp_Increment
+		want    *regattapb.ResponseOp_Increment
+		wantErr error
+		wantVal []byte
+	}{
+		{
+			name:    "missing key",
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 1},
+			want:    &regattapb.ResponseOp_Increment{Value: 1, Applied: true},
+			wantVal: counter(1),
+		},
+		{
+			name:    "missing key with initial value",
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: -1, Initial: 10},
+			want:    &regattapb.ResponseOp_Increment{Value: 9, Applied: true},
+			wantVal: counter(9),
+		},
+		{
+			name:    "existing key",
+			prev:    counter(41),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 1, Initial: 10},
+			want:    &regattapb.ResponseOp_Increment{Value: 42, Applied: true},
+			wantVal: counter(42),
+		},
+		{
+			name:    "within bounds",
+			prev:    counter(5),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: -5, Min: ptr(0), Max: ptr(10)},
+			want:    &regattapb.ResponseOp_Increment{Value: 0, Applied: true},
+			wantVal: counter(0),
+		},
+		{
+			name:    "below lower bound",
+			prev:    counter(5),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: -6, Min: ptr(0)},
+			want:    &regattapb.ResponseOp_Increment{Value: 5},
+			wantVal: counter(5),
+		},
+		{
+			name:    "above upper bound",
+			prev:    counter(5),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 6, Max: ptr(10)},
+			want:    &regattapb.ResponseOp_Increment{Value: 5},
+			wantVal: counter(5),
+		},
+		{
+			name:    "overflow",
+			prev:    counter(math.MaxInt64),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 1},
+			want:    &regattapb.ResponseOp_Increment{Value: math.MaxInt64},
+			wantVal: counter(math.MaxInt64),
+		},
+		{
+			name:    "not an integer",
+			prev:    []byte("value"),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 1},
+			wantErr: errNotCounter,
+			wantVal: []byte("value"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+			r.NoError(err)
+			defer db.Close()
+
+			c := &updateContext{batch: db.NewBatch(), db: db, index: 1}
+			defer func() { _ = c.Close() }()
+			if tt.prev != nil {
+				_, err := handlePut(c, &regattapb.RequestOp_Put{Key: []byte("counter"), Value: tt.prev})
+				r.NoError(err)
+				r.NoError(c.Commit())
+				c.batch = db.NewBatch()
+				c.index = 2
+			}
+
+			got, err := handleIncrement(c, tt.inc)
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+			} else {
+				r.NoError(err)
+				r.Equal(tt.want, got)
+			}
+			r.NoError(c.Commit())
+
+			res, err := singleLookup(db, &regattapb.RequestOp_Range{Key: []byte("counter")})
+			r.NoError(err)
+			r.Equal(tt.wantVal, res.Kvs[0].Value)
+			if tt.want.GetApplied() {
+				r.Equal(int64(c.index), res.Kvs[0].ModRevision)
+			}
+		})
+	}
+}
+
+func Test_handleTxnIncrement(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 1}
+	defer func() { _ = c.Close() }()
+
+	inc := wrapRequestOp(&regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 2})
+	// The counter does not exist, the failure branch creates it.
+	succ, res, err := handleTxn(c, []*regattapb.Compare{{Key: []byte("counter"), Target: regattapb.Compare_VERSION, Result: regattapb.Compare_GREATER}}, nil, []*regattapb.RequestOp{inc})
+	r.NoError(err)
+	r.False(succ)
+	r.Equal([]*regattapb.ResponseOp{wrapResponseOp(&regattapb.ResponseOp_Increment{Value: 2, Applied: true})}, res)
+
+	// Increments of the same counter within a single transaction are cumulative.
+	succ, res, err = handleTxn(c, []*regattapb.Compare{{Key: []byte("counter"), Target: regattapb.Compare_VERSION, Result: regattapb.Compare_GREATER}}, []*regattapb.RequestOp{inc, inc}, nil)
+	r.NoError(err)
+	r.True(succ)
+	r.Equal([]*regattapb.ResponseOp{
+		wrapResponseOp(&regattapb.ResponseOp_Increment{Value: 4, Applied: true}),
+		wrapResponseOp(&regattapb.ResponseOp_Increment{Value: 6, Applied: true}),
+	}, res)
+}
+
+func Test_handleTxnIncrementNotCounter(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 1}
+	defer func() { _ = c.Close() }()
+	_, err = handlePut(c, &regattapb.RequestOp_Put{Key: []byte("value"), Value: []byte("value")})
+	r.NoError(err)
+
+	// The transaction is not applied at all if any of the incremented keys does not hold a counter.
+	put := wrapRequestOp(&regattapb.RequestOp_Put{Key: []byte("key"), Value: []byte("value")})
+	inc := wrapRequestOp(&regattapb.RequestOp_Increment{Key: []byte("value"), Delta: 1})
+	nested := &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Failure: []*regattapb.RequestOp{inc}}}}
+	_, _, err = handleTxn(c, nil, []*regattapb.RequestOp{put, nested}, nil)
+	r.ErrorIs(err, errNotCounter)
+	r.NoError(c.Commit())
+
+	res, err := singleLookup(db, &regattapb.RequestOp_Range{Key: []byte("key")})
+	r.NoError(err)
+	r.Empty(res.Kvs)
+}
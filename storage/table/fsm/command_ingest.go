@@ -0,0 +1,211 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+type commandIngest struct {
+	*regattapb.Command
+}
+
+func (c commandIngest) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	// The ingested keys are placed above the committed ones, the writes of the entries applied before are committed first.
+	// The keys are written into the batch instead if the preceding commands of the same sequence have already written,
+	// the entry must be committed as a whole.
+	ok, err := ctx.commitPending()
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if ok {
+		err = ctx.ingest(c.Ingest)
+	} else {
+		err = ctx.writeIngested(c.Ingest)
+	}
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	// The history of the ingested keys is not known, the table could not be read before the ingested revisions.
+	if c.Revision != 0 {
+		if err := raiseCompactRevision(ctx, c.Revision); err != nil {
+			return ResultFailure, nil, err
+		}
+		if err := raiseRevision(ctx, c.Revision); err != nil {
+			return ResultFailure, nil, err
+		}
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+}
+
+type ingestEntry struct {
+	key   []byte
+	value []byte
+}
+
+// IngestBuilder builds the INGEST commands out of the PUT commands restored from a snapshot or a backup. The restored
+// key values are stored as they are, the PUT commands must carry the MVCC metadata of the key values.
+type IngestBuilder struct {
+	table       []byte
+	leaderIndex *uint64
+	keys        []ingestEntry
+	leases      [][]byte
+	size        int
+	revision    int64
+}
+
+// Add adds the key value of the PUT command to the built command.
+func (b *IngestBuilder) Add(cmd *regattapb.Command) error {
+	kv := cmd.Kv
+	if kv.ModRevision == 0 {
+		return fmt.Errorf("key '%s' is missing the MVCC metadata", kv.Key)
+	}
+	keyBuf := &bytes.Buffer{}
+	if err := encodeUserKey(keyBuf, kv.Key); err != nil {
+		return err
+	}
+	val := key.EncodeValue(nil, key.Value{CreateRevision: kv.CreateRevision, ModRevision: kv.ModRevision, Version: kv.Version, Lease: kv.Lease, Data: kv.Value})
+	b.keys = append(b.keys, ingestEntry{key: keyBuf.Bytes(), value: val})
+	if kv.Lease != 0 {
+		b.leases = append(b.leases, leaseKeyIndex(kv.Lease, kv.Key))
+	}
+	b.size += keyBuf.Len() + len(val)
+	b.revision = max(b.revision, kv.ModRevision)
+	b.table = cmd.Table
+	b.leaderIndex = cmd.LeaderIndex
+	return nil
+}
+
+// Size returns the estimated size of the built command.
+func (b *IngestBuilder) Size() int {
+	return b.size
+}
+
+// Build returns the INGEST command of the added key values and resets the builder, nil is returned if nothing was added.
+func (b *IngestBuilder) Build() (*regattapb.Command, error) {
+	if len(b.keys) == 0 {
+		return nil, nil
+	}
+	defer b.reset()
+
+	// The SST keys must be sorted and unique, the latest added value of the key wins.
+	slices.SortStableFunc(b.keys, func(a, b ingestEntry) int { return bytes.Compare(a.key, b.key) })
+	keys := &memFile{}
+	w := sstable.NewWriter(keys, rp.WriterOptions(6))
+	for i, e := range b.keys {
+		if i+1 < len(b.keys) && bytes.Equal(e.key, b.keys[i+1].key) {
+			continue
+		}
+		if err := w.Set(e.key, e.value); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	cmd := &regattapb.Command{
+		Table:       b.table,
+		Type:        regattapb.Command_INGEST,
+		LeaderIndex: b.leaderIndex,
+		Revision:    b.revision,
+		Ingest:      [][]byte{keys.Bytes()},
+	}
+
+	// The index of the keys attached to the leases does not overlap with the user keys.
+	if len(b.leases) > 0 {
+		slices.SortFunc(b.leases, bytes.Compare)
+		leases := &memFile{}
+		w := sstable.NewWriter(leases, rp.WriterOptions(6))
+		for _, k := range slices.CompactFunc(b.leases, bytes.Equal) {
+			if err := w.Set(k, nil); err != nil {
+				return nil, err
+			}
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		cmd.Ingest = append(cmd.Ingest, leases.Bytes())
+	}
+	return cmd, nil
+}
+
+// FilterIngest rebuilds the INGEST command out of the ingested key values the keep function accepts,
+// nil is returned if no key value is kept.
+func FilterIngest(cmd *regattapb.Command, keep func(key []byte) bool) (*regattapb.Command, error) {
+	b := &IngestBuilder{}
+	for _, sst := range cmd.Ingest {
+		err := iterIngested(sst, func(k, v []byte) error {
+			// The keys attached to the leases are rebuilt out of the kept key values.
+			uk, err := key.DecodeBytes(k)
+			if err != nil || uk.KeyType != key.TypeUser || !keep(uk.Key) {
+				return nil
+			}
+			val, err := key.DecodeValue(v)
+			if err != nil {
+				return err
+			}
+			return b.Add(&regattapb.Command{
+				Table:       cmd.Table,
+				LeaderIndex: cmd.LeaderIndex,
+				Kv: &regattapb.KeyValue{
+					Key:            bytes.Clone(uk.Key),
+					Value:          bytes.Clone(val.Data),
+					CreateRevision: val.CreateRevision,
+					ModRevision:    val.ModRevision,
+					Version:        val.Version,
+					Lease:          val.Lease,
+				},
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	filtered, err := b.Build()
+	if filtered != nil {
+		// The history of all the ingested keys is unknown, not only of the kept ones.
+		filtered.Revision = cmd.Revision
+	}
+	return filtered, err
+}
+
+// iterIngested calls the fn for every key value of the SST file.
+func iterIngested(sst []byte, fn func(k, v []byte) error) error {
+	r, err := sstable.NewReader(vfs.NewMemFile(sst), sstable.ReaderOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	iter, err := r.NewIter(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+	for k, v := iter.First(); k != nil; k, v = iter.Next() {
+		if err := fn(k.UserKey, v); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (b *IngestBuilder) reset() {
+	b.table = nil
+	b.leaderIndex = nil
+	b.keys = nil
+	b.leases = nil
+	b.size = 0
+	b.revision = 0
+}
@@ -0,0 +1,193 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestBuilder_Build(t *testing.T) {
+	r := require.New(t)
+	b := &IngestBuilder{}
+
+	cmd, err := b.Build()
+	r.NoError(err)
+	r.Nil(cmd)
+
+	r.Error(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value")}}))
+
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 3, Version: 2}}))
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 2, ModRevision: 2, Version: 1, Lease: 7}}))
+	r.Positive(b.Size())
+
+	cmd, err = b.Build()
+	r.NoError(err)
+	r.Equal(regattapb.Command_INGEST, cmd.Type)
+	r.Equal([]byte(testTable), cmd.Table)
+	r.Equal(int64(3), cmd.Revision)
+	// The user keys and the keys attached to the leases.
+	r.Len(cmd.Ingest, 2)
+
+	// The builder is reset.
+	r.Zero(b.Size())
+	cmd, err = b.Build()
+	r.NoError(err)
+	r.Nil(cmd)
+}
+
+func TestCommandIngest_handle(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() { _ = p.Close() }()
+
+	b := &IngestBuilder{}
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("ingested"), CreateRevision: 1, ModRevision: 4, Version: 2}}))
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("ingested"), CreateRevision: 3, ModRevision: 3, Version: 1, Lease: 7}}))
+	ingest, err := b.Build()
+	r.NoError(err)
+
+	// The ingested keys override the keys written by the preceding entries of the same update.
+	_, err = p.Update([]sm.Entry{
+		{Index: 1, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("put")}})},
+		{Index: 2, Cmd: mustMarshallProto(ingest)},
+		{Index: 3, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("key_3"), Value: []byte("put")}})},
+	})
+	r.NoError(err)
+
+	idx, err := p.Lookup(LocalIndexRequest{})
+	r.NoError(err)
+	r.Equal(uint64(3), idx.(*IndexResponse).Index)
+
+	db := p.pebble.Load()
+	got, err := lookup(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte("ingested"), CreateRevision: 1, ModRevision: 4, Version: 2},
+		{Key: []byte("key_2"), Value: []byte("ingested"), CreateRevision: 3, ModRevision: 3, Version: 1, Lease: 7},
+		{Key: []byte("key_3"), Value: []byte("put"), CreateRevision: 5, ModRevision: 5, Version: 1},
+	}, got.Kvs)
+
+	_, closer, err := db.Get(leaseKeyIndex(7, []byte("key_2")))
+	r.NoError(err)
+	r.NoError(closer.Close())
+
+	// The history of the ingested keys is not known.
+	_, err = lookup(db, &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 2})
+	r.ErrorIs(err, serrors.ErrCompacted)
+}
+
+func TestCommandIngest_handleSequence(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() { _ = p.Close() }()
+
+	b := &IngestBuilder{}
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("ingested"), CreateRevision: 1, ModRevision: 4, Version: 2}}))
+	ingest, err := b.Build()
+	r.NoError(err)
+
+	// The ingested keys override the keys written by the preceding commands of the same sequence.
+	leaderIndex := uint64(5)
+	_, err = p.Update([]sm.Entry{
+		{Index: 1, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_SEQUENCE, Sequence: []*regattapb.Command{
+			{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("put")}},
+			ingest,
+			{Table: []byte(testTable), Type: regattapb.Command_PUT, LeaderIndex: &leaderIndex, Kv: &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("put")}},
+		}})},
+	})
+	r.NoError(err)
+
+	idx, err := p.Lookup(LocalIndexRequest{})
+	r.NoError(err)
+	r.Equal(uint64(1), idx.(*IndexResponse).Index)
+
+	got, err := lookup(p.pebble.Load(), &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte("ingested"), CreateRevision: 1, ModRevision: 4, Version: 2},
+		{Key: []byte("key_2"), Value: []byte("put"), CreateRevision: 5, ModRevision: 5, Version: 1},
+	}, got.Kvs)
+}
+
+func TestCommandIngest_handleSequenceAtomic(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	b := &IngestBuilder{}
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("ingested"), CreateRevision: 1, ModRevision: 1, Version: 1}}))
+	ingest, err := b.Build()
+	r.NoError(err)
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 1, fs: vfs.NewMem(), dirname: "/"}
+	defer func() { _ = c.Close() }()
+	_, _, err = wrapCommand(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_SEQUENCE, Sequence: []*regattapb.Command{
+		{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("put")}},
+		ingest,
+	}}).handle(c)
+	r.NoError(err)
+
+	// Nothing of the entry is committed before its index.
+	got, err := lookup(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard})
+	r.NoError(err)
+	r.Empty(got.Kvs)
+	idx, err := readLocalIndex(db, sysLocalIndex)
+	r.NoError(err)
+	r.Equal(uint64(0), idx)
+
+	r.NoError(c.Commit())
+	got, err = lookup(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte("put"), CreateRevision: 1, ModRevision: 1, Version: 1},
+		{Key: []byte("key_2"), Value: []byte("ingested"), CreateRevision: 1, ModRevision: 1, Version: 1},
+	}, got.Kvs)
+	idx, err = readLocalIndex(db, sysLocalIndex)
+	r.NoError(err)
+	r.Equal(uint64(1), idx)
+}
+
+func TestFilterIngest(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() { _ = p.Close() }()
+
+	b := &IngestBuilder{}
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 1, ModRevision: 4, Version: 2, Lease: 7}}))
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: &regattapb.KeyValue{Key: []byte("other"), Value: []byte("value"), CreateRevision: 3, ModRevision: 5, Version: 1, Lease: 8}}))
+	ingest, err := b.Build()
+	r.NoError(err)
+
+	filtered, err := FilterIngest(ingest, func(key []byte) bool { return bytes.HasPrefix(key, []byte("key")) })
+	r.NoError(err)
+	r.Equal(int64(5), filtered.Revision)
+	r.Len(filtered.Ingest, 2)
+
+	_, err = p.Update([]sm.Entry{{Index: 1, Cmd: mustMarshallProto(filtered)}})
+	r.NoError(err)
+	db := p.pebble.Load()
+	got, err := lookup(db, &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: wildcard})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 1, ModRevision: 4, Version: 2, Lease: 7},
+	}, got.Kvs)
+	_, closer, err := db.Get(leaseKeyIndex(7, []byte("key_1")))
+	r.NoError(err)
+	r.NoError(closer.Close())
+	_, _, err = db.Get(leaseKeyIndex(8, []byte("other")))
+	r.ErrorIs(err, pebble.ErrNotFound)
+
+	filtered, err = FilterIngest(ingest, func([]byte) bool { return false })
+	r.NoError(err)
+	r.Nil(filtered)
+}
@@ -0,0 +1,292 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+var (
+	// sysLeasePrefix prefix of the lease records, followed by the big-endian lease ID.
+	sysLeasePrefix = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("lease/"),
+	})
+	// sysLeaseKeyPrefix prefix of the index of the keys attached to the leases, followed by the big-endian lease ID and the user key.
+	// The index is a superset of the attached keys, entries of the keys deleted or re-attached since are dropped once the lease is revoked.
+	sysLeaseKeyPrefix = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("lease_key/"),
+	})
+)
+
+// errLeaseNotFound the command refers to a lease that does not exist.
+var errLeaseNotFound = errors.New("lease not found")
+
+type commandLeaseGrant struct {
+	*regattapb.Command
+}
+
+func (c commandLeaseGrant) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	id := c.Lease.Id
+	if id == 0 {
+		// Choose the ID deterministically so that every replica grants the same lease.
+		for id = ctx.Revision(); ; id++ {
+			l, err := readLease(ctx.batch, id)
+			if err != nil {
+				return ResultFailure, nil, err
+			}
+			if l == nil {
+				break
+			}
+		}
+	} else {
+		l, err := readLease(ctx.batch, id)
+		if err != nil {
+			return ResultFailure, nil, err
+		}
+		if l != nil {
+			return ResultLeaseExists, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+		}
+	}
+	lease := &regattapb.Lease{Id: id, Ttl: c.Lease.Ttl, Expiry: c.Lease.Expiry}
+	// Leases restored from a snapshot keep their expiry.
+	if lease.Expiry == 0 {
+		lease.Expiry = c.Timestamp + lease.Ttl*1000
+	}
+	if err := writeLease(ctx.batch, lease); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: uint64(ctx.Revision()), Lease: lease}, nil
+}
+
+type commandLeaseKeepAlive struct {
+	*regattapb.Command
+}
+
+func (c commandLeaseKeepAlive) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	lease, err := readLease(ctx.batch, c.Lease.Id)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if lease == nil {
+		return ResultLeaseNotFound, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	lease.Expiry = c.Timestamp + lease.Ttl*1000
+	if err := writeLease(ctx.batch, lease); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: uint64(ctx.Revision()), Lease: lease}, nil
+}
+
+type commandLeaseRevoke struct {
+	*regattapb.Command
+}
+
+func (c commandLeaseRevoke) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	lease, err := readLease(ctx.batch, c.Lease.Id)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if lease == nil {
+		return ResultLeaseNotFound, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	// The lease was kept alive since it was found expired.
+	if c.Lease.Expiry != 0 && c.Lease.Expiry != lease.Expiry {
+		return ResultFailure, &regattapb.CommandResult{Revision: uint64(ctx.Revision()), Lease: lease}, nil
+	}
+	if err := revokeLease(ctx, lease.Id); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: uint64(ctx.Revision()), Lease: lease}, nil
+}
+
+// revokeLease deletes the lease along with all the keys still attached to it.
+func revokeLease(ctx *updateContext, id int64) error {
+	keys, err := leaseKeys(ctx.batch, id)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if _, err := handleDelete(ctx, &regattapb.RequestOp_DeleteRange{Key: k}); err != nil {
+			return err
+		}
+	}
+	prefix := leaseKeyIndexPrefix(id)
+	if err := ctx.batch.DeleteRange(prefix, incrementRightmostByte(append([]byte(nil), prefix...)), nil); err != nil {
+		return err
+	}
+	return ctx.batch.Delete(leaseKey(id), nil)
+}
+
+// leaseKeys returns the user keys currently attached to the lease.
+func leaseKeys(reader pebble.Reader, id int64) ([][]byte, error) {
+	prefix := leaseKeyIndexPrefix(id)
+	iter := reader.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: incrementRightmostByte(append([]byte(nil), prefix...)),
+	})
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	var keys [][]byte
+	for iter.First(); iter.Valid(); iter.Next() {
+		k := iter.Key()[len(prefix):]
+		keyBuf.Reset()
+		if err := encodeUserKey(keyBuf, k); err != nil {
+			return nil, err
+		}
+		value, err := readValue(reader, keyBuf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		// Skip the stale index entries.
+		if value == nil || value.Lease != id {
+			continue
+		}
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	return keys, iter.Error()
+}
+
+// checkLeases ensures all the leases the put operations refer to exist.
+// Both branches of the nested transactions are checked as the branch taken is not known upfront.
+func checkLeases(reader pebble.Reader, ops []*regattapb.RequestOp) error {
+	for _, op := range ops {
+		if txn := op.GetRequestTxn(); txn != nil {
+			if err := checkLeases(reader, txn.Success); err != nil {
+				return err
+			}
+			if err := checkLeases(reader, txn.Failure); err != nil {
+				return err
+			}
+			continue
+		}
+		put, ok := op.Request.(*regattapb.RequestOp_RequestPut)
+		if !ok || put.RequestPut.Lease == 0 {
+			continue
+		}
+		lease, err := readLease(reader, put.RequestPut.Lease)
+		if err != nil {
+			return err
+		}
+		if lease == nil {
+			return errLeaseNotFound
+		}
+	}
+	return nil
+}
+
+// attachLease updates the index of the keys attached to the leases once the key k changes its lease from prev to curr.
+func attachLease(batch *pebble.Batch, k []byte, prev, curr int64) error {
+	if prev != 0 && prev != curr {
+		if err := batch.Delete(leaseKeyIndex(prev, k), nil); err != nil {
+			return err
+		}
+	}
+	if curr != 0 {
+		return batch.Set(leaseKeyIndex(curr, k), nil, nil)
+	}
+	return nil
+}
+
+// readLease reads the lease, nil is returned if the lease does not exist.
+func readLease(reader pebble.Reader, id int64) (*regattapb.Lease, error) {
+	raw, closer, err := reader.Get(leaseKey(id))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	lease := &regattapb.Lease{}
+	if err := lease.UnmarshalVT(raw); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func writeLease(batch *pebble.Batch, lease *regattapb.Lease) error {
+	bts, err := lease.MarshalVT()
+	if err != nil {
+		return err
+	}
+	return batch.Set(leaseKey(lease.Id), bts, nil)
+}
+
+// readValue reads the value of the encoded user key, nil is returned if the key does not exist.
+func readValue(reader pebble.Reader, k []byte) (*key.Value, error) {
+	raw, closer, err := reader.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	value, err := key.DecodeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	// The raw value is only valid until the closer is closed.
+	value.Data = bytes.Clone(value.Data)
+	return &value, nil
+}
+
+// expiredLeases returns the leases expired before now (unix milliseconds), at most limit leases are returned if limit is set.
+func expiredLeases(reader pebble.Reader, now int64, limit int) ([]*regattapb.Lease, error) {
+	iter := reader.NewIter(&pebble.IterOptions{
+		LowerBound: sysLeasePrefix,
+		UpperBound: incrementRightmostByte(append([]byte(nil), sysLeasePrefix...)),
+	})
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var leases []*regattapb.Lease
+	for iter.First(); iter.Valid() && (limit == 0 || len(leases) < limit); iter.Next() {
+		lease := &regattapb.Lease{}
+		if err := lease.UnmarshalVT(iter.Value()); err != nil {
+			return nil, err
+		}
+		if lease.Expiry < now {
+			leases = append(leases, lease)
+		}
+	}
+	return leases, iter.Error()
+}
+
+func leaseKey(id int64) []byte {
+	return binary.BigEndian.AppendUint64(bytes.Clone(sysLeasePrefix), uint64(id))
+}
+
+func leaseKeyIndexPrefix(id int64) []byte {
+	return binary.BigEndian.AppendUint64(bytes.Clone(sysLeaseKeyPrefix), uint64(id))
+}
+
+func leaseKeyIndex(id int64, k []byte) []byte {
+	return append(leaseKeyIndexPrefix(id), k...)
+}
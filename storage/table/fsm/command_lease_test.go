@@ -0,0 +1,281 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/stretchr/testify/require"
+)
+
+func leaseEntry(index uint64, typ regattapb.Command_CommandType, lease *regattapb.Lease, timestamp int64) sm.Entry {
+	return sm.Entry{
+		Index: index,
+		Cmd: mustMarshallProto(&regattapb.Command{
+			Table:     []byte(testTable),
+			Type:      typ,
+			Lease:     lease,
+			Timestamp: timestamp,
+		}),
+	}
+}
+
+func putEntry(index uint64, k string, lease int64) sm.Entry {
+	return sm.Entry{
+		Index: index,
+		Cmd: mustMarshallProto(&regattapb.Command{
+			Table: []byte(testTable),
+			Type:  regattapb.Command_PUT,
+			Kv:    &regattapb.KeyValue{Key: []byte(k), Value: []byte(testValue), Lease: lease},
+		}),
+	}
+}
+
+func updateResult(t *testing.T, p *FSM, entries ...sm.Entry) []sm.Entry {
+	t.Helper()
+	res, err := p.Update(entries)
+	require.NoError(t, err)
+	return res
+}
+
+func commandResult(t *testing.T, entry sm.Entry) *regattapb.CommandResult {
+	t.Helper()
+	res := &regattapb.CommandResult{}
+	require.NoError(t, res.UnmarshalVT(entry.Result.Data))
+	return res
+}
+
+func TestFSM_LeaseGrant(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer p.Close()
+
+	res := updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Ttl: 10}, 1000),
+		leaseEntry(2, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Ttl: 10}, 1000),
+		leaseEntry(3, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 2, Ttl: 10}, 1000),
+		leaseEntry(4, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 100, Ttl: 5}, 2000),
+	)
+	r.Equal(ResultSuccess, UpdateResult(res[0].Result.Value))
+	r.Equal(&regattapb.Lease{Id: 1, Ttl: 10, Expiry: 11000}, commandResult(t, res[0]).Lease)
+	r.Equal(ResultSuccess, UpdateResult(res[1].Result.Value))
+	r.Equal(&regattapb.Lease{Id: 2, Ttl: 10, Expiry: 11000}, commandResult(t, res[1]).Lease)
+	r.Equal(ResultLeaseExists, UpdateResult(res[2].Result.Value))
+	r.Equal(ResultSuccess, UpdateResult(res[3].Result.Value))
+	r.Equal(&regattapb.Lease{Id: 100, Ttl: 5, Expiry: 7000}, commandResult(t, res[3]).Lease)
+
+	// Auto assigned ID skips the existing leases.
+	res = updateResult(t, p, leaseEntry(100, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Ttl: 1}, 0))
+	r.Equal(int64(101), commandResult(t, res[0]).Lease.Id)
+}
+
+func TestFSM_LeaseKeepAlive(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer p.Close()
+
+	res := updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 1, Ttl: 10}, 1000),
+		leaseEntry(2, regattapb.Command_LEASE_KEEPALIVE, &regattapb.Lease{Id: 1}, 5000),
+		leaseEntry(3, regattapb.Command_LEASE_KEEPALIVE, &regattapb.Lease{Id: 2}, 5000),
+	)
+	r.Equal(ResultSuccess, UpdateResult(res[1].Result.Value))
+	r.Equal(&regattapb.Lease{Id: 1, Ttl: 10, Expiry: 15000}, commandResult(t, res[1]).Lease)
+	r.Equal(ResultLeaseNotFound, UpdateResult(res[2].Result.Value))
+
+	lr, err := p.Lookup(LeaseRequest{ID: 1})
+	r.NoError(err)
+	r.Equal(&regattapb.Lease{Id: 1, Ttl: 10, Expiry: 15000}, lr.(*LeaseResponse).Lease)
+}
+
+func TestFSM_LeasePut(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer p.Close()
+
+	res := updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 1, Ttl: 10}, 1000),
+		leaseEntry(2, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 2, Ttl: 10}, 1000),
+		putEntry(3, "key_1", 1),
+		putEntry(4, "key_2", 1),
+		putEntry(5, "key_3", 3),
+		// Re-attach to the other lease.
+		putEntry(6, "key_2", 2),
+	)
+	r.Equal(ResultSuccess, UpdateResult(res[2].Result.Value))
+	r.Equal(ResultLeaseNotFound, UpdateResult(res[4].Result.Value))
+
+	rng, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key_1"), RangeEnd: wildcard})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte(testValue), CreateRevision: 3, ModRevision: 3, Version: 1, Lease: 1},
+		{Key: []byte("key_2"), Value: []byte(testValue), CreateRevision: 4, ModRevision: 6, Version: 2, Lease: 2},
+	}, rng.(*regattapb.ResponseOp_Range).Kvs)
+
+	lr, err := p.Lookup(LeaseRequest{ID: 1, Keys: true})
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("key_1")}, lr.(*LeaseResponse).Keys)
+
+	lr, err = p.Lookup(LeaseRequest{ID: 3, Keys: true})
+	r.NoError(err)
+	r.Nil(lr.(*LeaseResponse).Lease)
+	r.Empty(lr.(*LeaseResponse).Keys)
+}
+
+func TestFSM_LeaseTxn(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer p.Close()
+
+	txn := func(index uint64, lease int64) sm.Entry {
+		return sm.Entry{
+			Index: index,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Table: []byte(testTable),
+				Type:  regattapb.Command_TXN,
+				Txn: &regattapb.Txn{
+					Success: []*regattapb.RequestOp{
+						{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key_1"), Value: []byte(testValue)}}},
+						{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key_2"), Value: []byte(testValue), Lease: lease}}},
+					},
+				},
+			}),
+		}
+	}
+
+	res := updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 1, Ttl: 10}, 1000),
+		txn(2, 2),
+	)
+	r.Equal(ResultLeaseNotFound, UpdateResult(res[1].Result.Value))
+
+	// Transaction was not applied at all.
+	rng, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key_1"), RangeEnd: wildcard})
+	r.NoError(err)
+	r.Empty(rng.(*regattapb.ResponseOp_Range).Kvs)
+
+	res = updateResult(t, p, txn(3, 1))
+	r.Equal(ResultSuccess, UpdateResult(res[0].Result.Value))
+	lr, err := p.Lookup(LeaseRequest{ID: 1, Keys: true})
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("key_2")}, lr.(*LeaseResponse).Keys)
+}
+
+func TestFSM_LeaseRevoke(t *testing.T) {
+	r := require.New(t)
+	sink := &recordingSink{}
+	p := emptySM()
+	p.events = sink
+	defer p.Close()
+
+	res := updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 1, Ttl: 10}, 1000),
+		putEntry(2, "key_1", 1),
+		putEntry(3, "key_2", 1),
+		putEntry(4, "key_3", 0),
+		// Detach the key from the lease.
+		putEntry(5, "key_2", 0),
+		leaseEntry(6, regattapb.Command_LEASE_KEEPALIVE, &regattapb.Lease{Id: 1}, 2000),
+		// Lease was kept alive since the expiry was read.
+		leaseEntry(7, regattapb.Command_LEASE_REVOKE, &regattapb.Lease{Id: 1, Expiry: 11000}, 0),
+	)
+	r.Equal(ResultFailure, UpdateResult(res[6].Result.Value))
+
+	sink.events = nil
+	res = updateResult(t, p,
+		leaseEntry(8, regattapb.Command_LEASE_REVOKE, &regattapb.Lease{Id: 1, Expiry: 12000}, 0),
+		leaseEntry(9, regattapb.Command_LEASE_REVOKE, &regattapb.Lease{Id: 1}, 0),
+	)
+	r.Equal(ResultSuccess, UpdateResult(res[0].Result.Value))
+	r.Equal(ResultLeaseNotFound, UpdateResult(res[1].Result.Value))
+	r.Equal([]*regattapb.Event{
+		{
+			Type:   regattapb.Event_DELETE,
+			Kv:     &regattapb.KeyValue{Key: []byte("key_1"), ModRevision: 8},
+			PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte(testValue), CreateRevision: 2, ModRevision: 2, Version: 1, Lease: 1},
+		},
+	}, sink.events)
+
+	rng, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key_1"), RangeEnd: wildcard, KeysOnly: true})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{
+		{Key: []byte("key_2"), CreateRevision: 3, ModRevision: 5, Version: 2},
+		{Key: []byte("key_3"), CreateRevision: 4, ModRevision: 4, Version: 1},
+	}, rng.(*regattapb.ResponseOp_Range).Kvs)
+
+	lr, err := p.Lookup(LeaseRequest{ID: 1})
+	r.NoError(err)
+	r.Nil(lr.(*LeaseResponse).Lease)
+}
+
+func TestFSM_ExpiredLeases(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer p.Close()
+
+	updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 1, Ttl: 1}, 1000),
+		leaseEntry(2, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 2, Ttl: 10}, 1000),
+		leaseEntry(3, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 3, Ttl: 2}, 1000),
+	)
+
+	res, err := p.Lookup(ExpiredLeasesRequest{Now: 5000})
+	r.NoError(err)
+	r.Equal([]*regattapb.Lease{{Id: 1, Ttl: 1, Expiry: 2000}, {Id: 3, Ttl: 2, Expiry: 3000}}, res.(*ExpiredLeasesResponse).Leases)
+
+	res, err = p.Lookup(ExpiredLeasesRequest{Now: 5000, Limit: 1})
+	r.NoError(err)
+	r.Equal([]*regattapb.Lease{{Id: 1, Ttl: 1, Expiry: 2000}}, res.(*ExpiredLeasesResponse).Leases)
+
+	res, err = p.Lookup(ExpiredLeasesRequest{Now: 1000})
+	r.NoError(err)
+	r.Empty(res.(*ExpiredLeasesResponse).Leases)
+}
+
+// commandRecorder collects the commands written by the commandSnapshot, a single command is written per Write call.
+type commandRecorder struct {
+	commands []*regattapb.Command
+}
+
+func (c *commandRecorder) Write(p []byte) (int, error) {
+	cmd := &regattapb.Command{}
+	if err := cmd.UnmarshalVT(p); err != nil {
+		return 0, err
+	}
+	c.commands = append(c.commands, cmd)
+	return len(p), nil
+}
+
+func TestFSM_LeaseSnapshot(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer p.Close()
+
+	updateResult(t, p,
+		leaseEntry(1, regattapb.Command_LEASE_GRANT, &regattapb.Lease{Id: 1, Ttl: 10}, 1000),
+		putEntry(2, "key_1", 1),
+	)
+
+	rec := &commandRecorder{}
+	_, err := p.Lookup(SnapshotRequest{Writer: rec, Stopper: make(chan struct{})})
+	r.NoError(err)
+	r.Len(rec.commands, 2)
+	r.Equal(regattapb.Command_PUT, rec.commands[0].Type)
+	r.Equal(&regattapb.KeyValue{Key: []byte("key_1"), Value: []byte(testValue), CreateRevision: 2, ModRevision: 2, Version: 1, Lease: 1}, rec.commands[0].Kv)
+	r.Equal(regattapb.Command_LEASE_GRANT, rec.commands[1].Type)
+	r.Equal(&regattapb.Lease{Id: 1, Ttl: 10, Expiry: 11000}, rec.commands[1].Lease)
+
+	// Restore the snapshot into the empty table.
+	restored := emptySM()
+	defer restored.Close()
+	updateResult(t, restored,
+		sm.Entry{Index: 1, Cmd: mustMarshallProto(&regattapb.Command{Type: regattapb.Command_PUT_BATCH, Batch: []*regattapb.KeyValue{rec.commands[0].Kv}})},
+		sm.Entry{Index: 2, Cmd: mustMarshallProto(rec.commands[1])},
+	)
+	lr, err := restored.Lookup(LeaseRequest{ID: 1, Keys: true})
+	r.NoError(err)
+	r.Equal(&regattapb.Lease{Id: 1, Ttl: 10, Expiry: 11000}, lr.(*LeaseResponse).Lease)
+	r.Equal([][]byte{[]byte("key_1")}, lr.(*LeaseResponse).Keys)
+}
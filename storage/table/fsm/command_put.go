@@ -7,6 +7,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
 )
 
 type commandPut struct {
@@ -18,12 +19,16 @@ func (c commandPut) handle(ctx *updateContext) (UpdateResult, *regattapb.Command
 		Key:    c.Kv.Key,
 		Value:  c.Kv.Value,
 		PrevKv: c.PrevKvs,
+		Lease:  c.Kv.Lease,
 	})
+	if errors.Is(err, errLeaseNotFound) {
+		return ResultLeaseNotFound, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
 	if err != nil {
 		return ResultFailure, nil, err
 	}
 	return ResultSuccess, &regattapb.CommandResult{
-		Revision:  ctx.index,
+		Revision:  uint64(ctx.Revision()),
 		Responses: []*regattapb.ResponseOp{wrapResponseOp(resp)},
 	}, nil
 }
@@ -35,58 +40,116 @@ func handlePut(ctx *updateContext, put *regattapb.RequestOp_Put) (*regattapb.Res
 	if err := encodeUserKey(keyBuf, put.Key); err != nil {
 		return nil, err
 	}
-	if put.PrevKv {
-		if err := ctx.EnsureIndexed(); err != nil {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return nil, err
+	}
+	if put.Lease != 0 {
+		lease, err := readLease(ctx.batch, put.Lease)
+		if err != nil {
 			return nil, err
 		}
-		rng, err := singleLookup(ctx.batch, &regattapb.RequestOp_Range{Key: put.Key})
-		if err != nil && !errors.Is(err, pebble.ErrNotFound) {
+		if lease == nil {
+			return nil, errLeaseNotFound
+		}
+	}
+	rev := ctx.Revision()
+	val := key.Value{CreateRevision: rev, ModRevision: rev, Version: 1, Lease: put.Lease, Data: put.Value}
+	var (
+		prevKv    *regattapb.KeyValue
+		prevLease int64
+	)
+	raw, closer, err := ctx.batch.Get(keyBuf.Bytes())
+	if err != nil && !errors.Is(err, pebble.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		prev, err := key.DecodeValue(raw)
+		if err != nil {
+			_ = closer.Close()
 			return nil, err
 		}
-		if !errors.Is(err, pebble.ErrNotFound) {
-			resp.PrevKv = rng.Kvs[0]
+		val.CreateRevision = prev.CreateRevision
+		val.Version = prev.Version + 1
+		prevLease = prev.Lease
+		if put.PrevKv || ctx.trackEvents {
+			prevKv = keyValue(put.Key, prev, false)
+		}
+		err = writeHistory(ctx.batch, put.Key, prev)
+		if cerr := closer.Close(); err == nil {
+			err = cerr
 		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.batch.Set(keyBuf.Bytes(), key.EncodeValue(nil, val), nil); err != nil {
+		return nil, err
 	}
-	if err := ctx.batch.Set(keyBuf.Bytes(), put.Value, nil); err != nil {
+	if err := attachLease(ctx.batch, put.Key, prevLease, val.Lease); err != nil {
 		return nil, err
 	}
+	if put.PrevKv {
+		resp.PrevKv = prevKv
+	}
+	if ctx.trackEvents {
+		ctx.addEvent(regattapb.Event_PUT, keyValue(put.Key, val, false), prevKv)
+	}
 	return resp, nil
 }
 
+// handleRestore stores the KeyValue together with its MVCC metadata verbatim.
+func handleRestore(ctx *updateContext, kv *regattapb.KeyValue) (*regattapb.ResponseOp_Put, error) {
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	if err := encodeUserKey(keyBuf, kv.Key); err != nil {
+		return nil, err
+	}
+	val := key.Value{CreateRevision: kv.CreateRevision, ModRevision: kv.ModRevision, Version: kv.Version, Lease: kv.Lease, Data: kv.Value}
+	if err := ctx.batch.Set(keyBuf.Bytes(), key.EncodeValue(nil, val), nil); err != nil {
+		return nil, err
+	}
+	if err := attachLease(ctx.batch, kv.Key, 0, kv.Lease); err != nil {
+		return nil, err
+	}
+	ctx.addEvent(regattapb.Event_PUT, kv, nil)
+	return &regattapb.ResponseOp_Put{}, nil
+}
+
 type commandPutBatch struct {
 	*regattapb.Command
 }
 
 func (c commandPutBatch) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
-	req := make([]*regattapb.RequestOp_Put, len(c.Batch))
-	for i, kv := range c.Batch {
-		req[i] = &regattapb.RequestOp_Put{
-			Key:   kv.Key,
-			Value: kv.Value,
-		}
-	}
-	rop, err := handlePutBatch(ctx, req)
-	if err != nil {
-		return ResultFailure, nil, err
-	}
 	res := make([]*regattapb.ResponseOp, 0, len(c.Batch))
-	for _, put := range rop {
+	var restored int64
+	for _, kv := range c.Batch {
+		var (
+			put *regattapb.ResponseOp_Put
+			err error
+		)
+		// KeyValues carrying the MVCC metadata (e.g. restored from a snapshot or a backup) are stored as they are.
+		if kv.ModRevision != 0 {
+			put, err = handleRestore(ctx, kv)
+			restored = max(restored, kv.ModRevision)
+		} else {
+			put, err = handlePut(ctx, &regattapb.RequestOp_Put{Key: kv.Key, Value: kv.Value})
+		}
+		if err != nil {
+			return ResultFailure, nil, err
+		}
 		res = append(res, wrapResponseOp(put))
 	}
+	// The history of the restored keys is not known, the table could not be read before the restored revisions.
+	if restored != 0 {
+		if err := raiseCompactRevision(ctx, restored); err != nil {
+			return ResultFailure, nil, err
+		}
+		if err := raiseRevision(ctx, restored); err != nil {
+			return ResultFailure, nil, err
+		}
+	}
 	return ResultSuccess, &regattapb.CommandResult{
-		Revision:  ctx.index,
+		Revision:  uint64(ctx.Revision()),
 		Responses: res,
 	}, nil
 }
-
-func handlePutBatch(ctx *updateContext, ops []*regattapb.RequestOp_Put) ([]*regattapb.ResponseOp_Put, error) {
-	var results []*regattapb.ResponseOp_Put
-	for _, op := range ops {
-		res, err := handlePut(ctx, op)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, res)
-	}
-	return results, nil
-}
@@ -37,6 +37,8 @@ func Test_handlePut(t *testing.T) {
 	r.NoError(c.Commit())
 
 	// Make the PUT update.
+	c.batch = db.NewBatch()
+	c.index = 2
 	req = &regattapb.RequestOp_Put{
 		Key:    []byte("key_1"),
 		Value:  []byte("value_2"),
@@ -44,7 +46,7 @@ func Test_handlePut(t *testing.T) {
 	}
 	res, err := handlePut(c, req)
 	r.NoError(err)
-	r.Equal(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1")}}, res)
+	r.Equal(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1"), CreateRevision: 1, ModRevision: 1, Version: 1}}, res)
 	r.NoError(c.Commit())
 
 	iter := db.NewIter(allUserKeysOpts())
@@ -54,7 +56,7 @@ func Test_handlePut(t *testing.T) {
 	decodeKey(t, iter, k)
 
 	r.Equal(req.Key, k.Key)
-	r.Equal(req.Value, iter.Value())
+	r.Equal(key.Value{CreateRevision: 1, ModRevision: 2, Version: 2, Data: req.Value}, decodeValue(t, iter))
 
 	// Assert that there are no more user keys.
 	iter.Next()
@@ -67,7 +69,7 @@ func Test_handlePut(t *testing.T) {
 	r.Equal(c.index, index)
 }
 
-func Test_handlePutBatch(t *testing.T) {
+func Test_commandPutBatch(t *testing.T) {
 	r := require.New(t)
 
 	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
@@ -83,13 +85,13 @@ func Test_handlePutBatch(t *testing.T) {
 	defer func() { _ = c.Close() }()
 
 	// Make the PUT_BATCH.
-	ops := []*regattapb.RequestOp_Put{
+	ops := []*regattapb.KeyValue{
 		{Key: []byte("key_1"), Value: []byte("value")},
 		{Key: []byte("key_2"), Value: []byte("value")},
 		{Key: []byte("key_3"), Value: []byte("value")},
 		{Key: []byte("key_4"), Value: []byte("value")},
 	}
-	_, err = handlePutBatch(c, ops)
+	_, _, err = commandPutBatch{&regattapb.Command{Batch: ops}}.handle(c)
 	r.NoError(err)
 	r.NoError(c.Commit())
 
@@ -103,7 +105,7 @@ func Test_handlePutBatch(t *testing.T) {
 		decodeKey(t, iter, k)
 
 		r.Equal(ops[i].Key, k.Key)
-		r.Equal(ops[i].Value, iter.Value())
+		r.Equal(key.Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: ops[i].Value}, decodeValue(t, iter))
 		r.NoError(iter.Error())
 
 		i++
@@ -116,3 +118,40 @@ func Test_handlePutBatch(t *testing.T) {
 	r.NoError(err)
 	r.Equal(c.index, index)
 }
+
+func Test_commandPutBatch_Restore(t *testing.T) {
+	r := require.New(t)
+
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	if err != nil {
+		t.Fatalf("could not open pebble db: %v", err)
+	}
+
+	c := &updateContext{
+		batch: db.NewBatch(),
+		db:    db,
+		index: 10,
+	}
+	defer func() { _ = c.Close() }()
+
+	// Make the PUT_BATCH with KVs carrying the metadata and without it.
+	_, _, err = commandPutBatch{&regattapb.Command{Batch: []*regattapb.KeyValue{
+		{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 2, ModRevision: 5, Version: 3},
+		{Key: []byte("key_2"), Value: []byte("value")},
+	}}}.handle(c)
+	r.NoError(err)
+	r.NoError(c.Commit())
+
+	want := []key.Value{
+		{CreateRevision: 2, ModRevision: 5, Version: 3, Data: []byte("value")},
+		{CreateRevision: 10, ModRevision: 10, Version: 1, Data: []byte("value")},
+	}
+	i := 0
+	iter := db.NewIter(allUserKeysOpts())
+	for iter.First(); iter.Valid(); iter.Next() {
+		r.Equal(want[i], decodeValue(t, iter))
+		i++
+	}
+	r.Equal(len(want), i)
+	r.NoError(iter.Close())
+}
@@ -11,8 +11,17 @@ type commandSequence struct {
 }
 
 func (c commandSequence) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
-	res := &regattapb.CommandResult{Revision: ctx.index}
+	res := &regattapb.CommandResult{Revision: uint64(ctx.Revision())}
 	for _, cmd := range c.Sequence {
+		if cmd.LeaderIndex != nil {
+			ctx.leaderIndex = cmd.LeaderIndex
+		}
+		// The commands restored from an incremental backup are applied with the revision of the backed up ones.
+		if cmd.Revision != 0 && restoredRevision(cmd.Type) {
+			if err := raiseRevision(ctx, cmd.Revision); err != nil {
+				return ResultFailure, nil, err
+			}
+		}
 		_, cmdRes, err := wrapCommand(cmd).handle(ctx)
 		if err != nil {
 			return ResultFailure, nil, err
@@ -21,3 +30,12 @@ func (c commandSequence) handle(ctx *updateContext) (UpdateResult, *regattapb.Co
 	}
 	return ResultSuccess, res, nil
 }
+
+// restoredRevision whether the revision of the command is the revision it was applied with in the backed up table.
+func restoredRevision(typ regattapb.Command_CommandType) bool {
+	switch typ {
+	case regattapb.Command_COMPACT, regattapb.Command_INGEST, regattapb.Command_DUMMY:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_commandSequence(t *testing.T) {
+	r := require.New(t)
+
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	if err != nil {
+		t.Fatalf("could not open pebble db: %v", err)
+	}
+
+	c := &updateContext{
+		batch: db.NewBatch(),
+		db:    db,
+		index: 1,
+	}
+	defer func() { _ = c.Close() }()
+
+	first, second := uint64(100), uint64(101)
+	c.leaderIndex = &second
+	_, res, err := commandSequence{&regattapb.Command{Sequence: []*regattapb.Command{
+		{Type: regattapb.Command_PUT, LeaderIndex: &first, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1")}},
+		{Type: regattapb.Command_PUT, LeaderIndex: &second, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_2")}, PrevKvs: true},
+	}}}.handle(c)
+	r.NoError(err)
+	r.NoError(c.Commit())
+	r.Equal(&regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1"), CreateRevision: 100, ModRevision: 100, Version: 1}, res.Responses[1].GetResponsePut().PrevKv)
+
+	// The revisions assigned by the leader cluster are kept.
+	iter := db.NewIter(allUserKeysOpts())
+	r.True(iter.First())
+	r.Equal(key.Value{CreateRevision: 100, ModRevision: 101, Version: 2, Data: []byte("value_2")}, decodeValue(t, iter))
+	r.NoError(iter.Close())
+
+	leaderIndex, err := readLocalIndex(db, sysLeaderIndex)
+	r.NoError(err)
+	r.Equal(second, leaderIndex)
+}
+
+func Test_commandSequence_restored(t *testing.T) {
+	r := require.New(t)
+
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	if err != nil {
+		t.Fatalf("could not open pebble db: %v", err)
+	}
+
+	c := &updateContext{
+		batch: db.NewBatch(),
+		db:    db,
+		index: 1,
+	}
+	defer func() { _ = c.Close() }()
+
+	// The commands restored from an incremental backup keep the revisions of the backed up ones.
+	_, res, err := commandSequence{&regattapb.Command{Sequence: []*regattapb.Command{
+		{Type: regattapb.Command_PUT, Revision: 100, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1")}},
+		{Type: regattapb.Command_PUT, Revision: 101, Kv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_2")}, PrevKvs: true},
+	}}}.handle(c)
+	r.NoError(err)
+	r.NoError(c.Commit())
+	r.Equal(&regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1"), CreateRevision: 100, ModRevision: 100, Version: 1}, res.Responses[1].GetResponsePut().PrevKv)
+
+	iter := db.NewIter(allUserKeysOpts())
+	r.True(iter.First())
+	r.Equal(key.Value{CreateRevision: 100, ModRevision: 101, Version: 2, Data: []byte("value_2")}, decodeValue(t, iter))
+	r.NoError(iter.Close())
+
+	// The leader index is not written, the restored table could be a follower.
+	leaderIndex, err := readLocalIndex(db, sysLeaderIndex)
+	r.NoError(err)
+	r.Equal(uint64(0), leaderIndex)
+	rev, err := currentRevision(db)
+	r.NoError(err)
+	r.Equal(uint64(101), rev)
+}
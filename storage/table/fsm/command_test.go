@@ -100,6 +100,16 @@ func TestUpdateContext_Commit(t *testing.T) {
 	r.Equal(uc.index, index)
 }
 
+func TestUpdateContext_Revision(t *testing.T) {
+	r := require.New(t)
+	uc := updateContext{index: 150}
+	r.Equal(int64(150), uc.Revision())
+
+	leaderIndex := uint64(300)
+	uc.leaderIndex = &leaderIndex
+	r.Equal(int64(300), uc.Revision())
+}
+
 // allKeysOpts returns *pebble.IterOptions for iterating over
 // all the user keys.
 func allUserKeysOpts() *pebble.IterOptions {
@@ -122,3 +132,11 @@ func decodeKey(t *testing.T, iter *pebble.Iterator, k *key.Key) {
 		t.Fatalf("could not decode key: %v", err)
 	}
 }
+
+func decodeValue(t *testing.T, iter *pebble.Iterator) key.Value {
+	v, err := key.DecodeValue(iter.Value())
+	if err != nil {
+		t.Fatalf("could not decode value: %v", err)
+	}
+	return v
+}
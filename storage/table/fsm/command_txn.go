@@ -8,14 +8,30 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
 )
 
+// MaxTxnDepth is the maximum nesting depth of the transactions, a transaction without any nested transactions has the depth of 1.
+const MaxTxnDepth = 8
+
+// errTxnTooDeep the transaction exceeds the MaxTxnDepth.
+var errTxnTooDeep = errors.New("txn nesting too deep")
+
 type commandTxn struct {
 	*regattapb.Command
 }
 
 func (c commandTxn) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
 	succ, rop, err := handleTxn(ctx, c.Txn.Compare, c.Txn.Success, c.Txn.Failure)
+	if errors.Is(err, errLeaseNotFound) {
+		return ResultLeaseNotFound, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	if errors.Is(err, errNotCounter) {
+		return ResultNotCounter, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
+	if errors.Is(err, errTxnTooDeep) {
+		return ResultFailure, &regattapb.CommandResult{Revision: uint64(ctx.Revision())}, nil
+	}
 	if err != nil {
 		return ResultFailure, nil, err
 	}
@@ -23,7 +39,7 @@ func (c commandTxn) handle(ctx *updateContext) (UpdateResult, *regattapb.Command
 	if !succ {
 		result = ResultFailure
 	}
-	return result, &regattapb.CommandResult{Revision: ctx.index, Responses: rop}, nil
+	return result, &regattapb.CommandResult{Revision: uint64(ctx.Revision()), Responses: rop}, nil
 }
 
 // handleTxn handle transaction operation, returns if the operation succeeded (if success, or fail was applied) list or respective results and error.
@@ -31,16 +47,28 @@ func handleTxn(ctx *updateContext, compare []*regattapb.Compare, success, fail [
 	if err := ctx.EnsureIndexed(); err != nil {
 		return false, nil, err
 	}
+	// The depth is checked upfront so that the transaction is either applied as a whole or not at all.
+	if TxnDepth(success, fail) > MaxTxnDepth {
+		return false, nil, errTxnTooDeep
+	}
 	ok, err := txnCompare(ctx.batch, compare)
 	if err != nil {
 		return false, nil, err
 	}
+	ops := fail
 	if ok {
-		res, err := handleTxnOps(ctx, success)
-		return true, res, err
+		ops = success
 	}
-	res, err := handleTxnOps(ctx, fail)
-	return false, res, err
+	// Leases are checked upfront so that the transaction is either applied as a whole or not at all.
+	if err := checkLeases(ctx.batch, ops); err != nil {
+		return false, nil, err
+	}
+	// Counters are checked upfront for the same reason.
+	if err := checkCounters(ctx.batch, ops); err != nil {
+		return false, nil, err
+	}
+	res, err := handleTxnOps(ctx, ops)
+	return ok, res, err
 }
 
 func handleTxnOps(ctx *updateContext, req []*regattapb.RequestOp) ([]*regattapb.ResponseOp, error) {
@@ -69,11 +97,48 @@ func handleTxnOps(ctx *updateContext, req []*regattapb.RequestOp) ([]*regattapb.
 			}
 
 			results = append(results, wrapResponseOp(response))
+		case *regattapb.RequestOp_RequestIncrement:
+			response, err := handleIncrement(ctx, o.RequestIncrement)
+			if errors.Is(err, errNotCounter) {
+				// The key was overwritten earlier within the transaction, the stored counters were checked upfront.
+				response, err = &regattapb.ResponseOp_Increment{}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, wrapResponseOp(response))
+		case *regattapb.RequestOp_RequestTxn:
+			ok, err := txnCompare(ctx.batch, o.RequestTxn.Compare)
+			if err != nil {
+				return nil, err
+			}
+			ops := o.RequestTxn.Failure
+			if ok {
+				ops = o.RequestTxn.Success
+			}
+			responses, err := handleTxnOps(ctx, ops)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, wrapResponseOp(&regattapb.ResponseOp_Txn{Succeeded: ok, Responses: responses}))
 		}
 	}
 	return results, nil
 }
 
+// TxnDepth returns the nesting depth of the transaction with the given branches.
+func TxnDepth(success, failure []*regattapb.RequestOp) int {
+	depth := 0
+	for _, ops := range [][]*regattapb.RequestOp{success, failure} {
+		for _, op := range ops {
+			if txn := op.GetRequestTxn(); txn != nil {
+				depth = max(depth, TxnDepth(txn.Success, txn.Failure))
+			}
+		}
+	}
+	return depth + 1
+}
+
 func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error) {
 	keyBuf := bufferPool.Get()
 	defer bufferPool.Put(keyBuf)
@@ -93,10 +158,14 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 					_ = iter.Close()
 				}()
 				if !iter.First() {
-					return false, nil
+					return txnCompareMissing(cmp), nil
 				}
 				for iter.First(); iter.Valid(); iter.Next() {
-					if !txnCompareSingle(cmp, iter.Value()) {
+					value, err := key.DecodeValue(iter.Value())
+					if err != nil {
+						return false, err
+					}
+					if !txnCompareSingle(cmp, value) {
 						return false, nil
 					}
 				}
@@ -104,10 +173,11 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 			}()
 		} else {
 			res, err = func() (bool, error) {
+				defer keyBuf.Reset()
 				if err := encodeUserKey(keyBuf, cmp.Key); err != nil {
 					return false, err
 				}
-				value, closer, err := reader.Get(keyBuf.Bytes())
+				raw, closer, err := reader.Get(keyBuf.Bytes())
 				defer func() {
 					if closer != nil {
 						_ = closer.Close()
@@ -116,17 +186,16 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 
 				if err != nil {
 					if errors.Is(err, pebble.ErrNotFound) {
-						return false, nil
+						return txnCompareMissing(cmp), nil
 					}
 					return false, err
 				}
 
-				if !txnCompareSingle(cmp, value) {
-					return false, nil
+				value, err := key.DecodeValue(raw)
+				if err != nil {
+					return false, err
 				}
-
-				keyBuf.Reset()
-				return true, nil
+				return txnCompareSingle(cmp, value), nil
 			}()
 		}
 		if err != nil {
@@ -139,20 +208,55 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 	return true, nil
 }
 
-func txnCompareSingle(cmp *regattapb.Compare, value []byte) bool {
-	cmpValue := true
-	if cmp.Target == regattapb.Compare_VALUE && cmp.TargetUnion != nil {
-		switch cmp.Result {
-		case regattapb.Compare_EQUAL:
-			cmpValue = bytes.Equal(value, cmp.GetValue())
-		case regattapb.Compare_NOT_EQUAL:
-			cmpValue = !bytes.Equal(value, cmp.GetValue())
-		case regattapb.Compare_GREATER:
-			cmpValue = bytes.Compare(value, cmp.GetValue()) == 1
-		case regattapb.Compare_LESS:
-			cmpValue = bytes.Compare(value, cmp.GetValue()) == -1
+// txnCompareMissing evaluates the comparison of a key (or a range) that does not exist. Comparing a value always fails,
+// other targets are compared against zero as a missing key has no revisions, version, nor lease.
+func txnCompareMissing(cmp *regattapb.Compare) bool {
+	if cmp.Target == regattapb.Compare_VALUE {
+		return false
+	}
+	return txnCompareSingle(cmp, key.Value{})
+}
+
+func txnCompareSingle(cmp *regattapb.Compare, value key.Value) bool {
+	switch cmp.Target {
+	case regattapb.Compare_VALUE:
+		if cmp.TargetUnion == nil {
+			return true
 		}
+		return txnCompareResult(cmp.Result, bytes.Compare(value.Data, cmp.GetValue()))
+	case regattapb.Compare_VERSION:
+		return txnCompareResult(cmp.Result, cmpInt64(value.Version, cmp.GetVersion()))
+	case regattapb.Compare_CREATE:
+		return txnCompareResult(cmp.Result, cmpInt64(value.CreateRevision, cmp.GetCreateRevision()))
+	case regattapb.Compare_MOD:
+		return txnCompareResult(cmp.Result, cmpInt64(value.ModRevision, cmp.GetModRevision()))
+	case regattapb.Compare_LEASE:
+		return txnCompareResult(cmp.Result, cmpInt64(value.Lease, cmp.GetLease()))
 	}
+	return true
+}
 
-	return cmpValue
+// txnCompareResult reports whether the result of the comparison (-1, 0, +1) satisfies the requested CompareResult.
+func txnCompareResult(result regattapb.Compare_CompareResult, c int) bool {
+	switch result {
+	case regattapb.Compare_EQUAL:
+		return c == 0
+	case regattapb.Compare_NOT_EQUAL:
+		return c != 0
+	case regattapb.Compare_GREATER:
+		return c > 0
+	case regattapb.Compare_LESS:
+		return c < 0
+	}
+	return true
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
 }
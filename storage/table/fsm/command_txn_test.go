@@ -12,6 +12,7 @@ import (
 	"github.com/cockroachdb/pebble/vfs"
 	rp "github.com/jamf/regatta/pebble"
 	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
 	"github.com/stretchr/testify/require"
 )
 
@@ -221,12 +222,12 @@ func Test_handleTxn(t *testing.T) {
 	defer func() { _ = c.Close() }()
 
 	// Make the PUT_BATCH.
-	_, err = handlePutBatch(c, []*regattapb.RequestOp_Put{
+	_, _, err = commandPutBatch{&regattapb.Command{Batch: []*regattapb.KeyValue{
 		{Key: []byte("key_1"), Value: []byte("value")},
 		{Key: []byte("key_2"), Value: []byte("value")},
 		{Key: []byte("key_3"), Value: []byte("value")},
 		{Key: []byte("key_4"), Value: []byte("value")},
-	})
+	}}}.handle(c)
 	r.NoError(err)
 	r.NoError(c.Commit())
 
@@ -250,7 +251,7 @@ func Test_handleTxn(t *testing.T) {
 	r.True(succ)
 	r.NoError(err)
 	r.Equal(1, len(res))
-	r.Equal(wrapResponseOp(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_5"), Value: nil}}), res[0])
+	r.Equal(wrapResponseOp(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_5"), Value: nil, CreateRevision: 1, ModRevision: 1, Version: 1}}), res[0])
 
 	// compare key_5 value with "value" and delete keys up to key_4 (non-inclusive)
 	succ, res, err = handleTxn(c, []*regattapb.Compare{{Key: []byte("key_5"), TargetUnion: &regattapb.Compare_Value{Value: []byte("value")}}}, []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: []byte("key_1"), RangeEnd: []byte("key_4"), PrevKv: true}}}}, nil)
@@ -260,9 +261,9 @@ func Test_handleTxn(t *testing.T) {
 	r.Equal(wrapResponseOp(&regattapb.ResponseOp_DeleteRange{
 		Deleted: 3,
 		PrevKvs: []*regattapb.KeyValue{
-			{Key: []byte("key_1"), Value: []byte("value")},
-			{Key: []byte("key_2"), Value: []byte("value")},
-			{Key: []byte("key_3"), Value: []byte("value")},
+			{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+			{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+			{Key: []byte("key_3"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
 		},
 	}), res[0])
 
@@ -272,7 +273,7 @@ func Test_handleTxn(t *testing.T) {
 	count := 0
 	for iter.First(); iter.Valid(); iter.Next() {
 		count++
-		r.Equal("value", string(iter.Value()))
+		r.Equal("value", string(decodeValue(t, iter).Data))
 	}
 	// just keys key_4 and key_5 should remain
 	r.Equal(2, count)
@@ -283,10 +284,92 @@ func Test_handleTxn(t *testing.T) {
 	r.Equal(c.index, index)
 }
 
+func Test_handleTxnNested(t *testing.T) {
+	r := require.New(t)
+
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+
+	c := &updateContext{
+		batch: db.NewBatch(),
+		db:    db,
+		index: 1,
+	}
+	defer func() { _ = c.Close() }()
+
+	put := func(k string) *regattapb.RequestOp {
+		return wrapRequestOp(&regattapb.RequestOp_Put{Key: []byte(k), Value: []byte("value")})
+	}
+	missing := []*regattapb.Compare{{Key: []byte("key_1"), Target: regattapb.Compare_CREATE, Result: regattapb.Compare_EQUAL}}
+
+	// The nested transaction sees the writes of the enclosing transaction.
+	succ, res, err := handleTxn(c, missing, []*regattapb.RequestOp{
+		put("key_1"),
+		wrapRequestOp(&regattapb.Txn{
+			Compare: missing,
+			Success: []*regattapb.RequestOp{put("key_2")},
+			Failure: []*regattapb.RequestOp{
+				put("key_3"),
+				wrapRequestOp(&regattapb.RequestOp_Range{Key: []byte("key_1"), KeysOnly: true}),
+			},
+		}),
+	}, nil)
+	r.NoError(err)
+	r.True(succ)
+	r.Equal([]*regattapb.ResponseOp{
+		wrapResponseOp(&regattapb.ResponseOp_Put{}),
+		wrapResponseOp(&regattapb.ResponseOp_Txn{
+			Succeeded: false,
+			Responses: []*regattapb.ResponseOp{
+				wrapResponseOp(&regattapb.ResponseOp_Put{}),
+				wrapResponseOp(&regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{{Key: []byte("key_1"), CreateRevision: 1, ModRevision: 1, Version: 1}}, Count: 1}),
+			},
+		}),
+	}, res)
+
+	// Missing lease in the nested transaction fails the whole transaction.
+	_, _, err = handleTxn(c, nil, []*regattapb.RequestOp{
+		put("key_4"),
+		wrapRequestOp(&regattapb.Txn{
+			Success: []*regattapb.RequestOp{wrapRequestOp(&regattapb.RequestOp_Put{Key: []byte("key_5"), Lease: 1})},
+		}),
+	}, nil)
+	r.ErrorIs(err, errLeaseNotFound)
+
+	// Transaction nested too deep is not applied.
+	deep := []*regattapb.RequestOp{put("key_6")}
+	for i := 0; i < MaxTxnDepth; i++ {
+		deep = []*regattapb.RequestOp{wrapRequestOp(&regattapb.Txn{Success: deep})}
+	}
+	_, _, err = handleTxn(c, nil, deep, nil)
+	r.ErrorIs(err, errTxnTooDeep)
+
+	r.NoError(c.Commit())
+
+	var keys []string
+	iter := db.NewIter(allUserKeysOpts())
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, err := key.DecodeBytes(iter.Key())
+		r.NoError(err)
+		keys = append(keys, string(k.Key))
+	}
+	r.NoError(iter.Close())
+	r.Equal([]string{"key_1", "key_3"}, keys)
+}
+
+func TestTxnDepth(t *testing.T) {
+	r := require.New(t)
+	r.Equal(1, TxnDepth(nil, nil))
+	r.Equal(1, TxnDepth([]*regattapb.RequestOp{wrapRequestOp(&regattapb.RequestOp_Range{})}, nil))
+
+	nested := wrapRequestOp(&regattapb.Txn{Failure: []*regattapb.RequestOp{wrapRequestOp(&regattapb.Txn{})}})
+	r.Equal(3, TxnDepth(nil, []*regattapb.RequestOp{wrapRequestOp(&regattapb.Txn{}), nested}))
+}
+
 func Test_txnCompareSingle(t *testing.T) {
 	type args struct {
 		cmp   *regattapb.Compare
-		value []byte
+		value key.Value
 	}
 	tests := []struct {
 		name string
@@ -297,7 +380,7 @@ func Test_txnCompareSingle(t *testing.T) {
 			name: "empty compare",
 			args: args{
 				cmp:   &regattapb.Compare{},
-				value: nil,
+				value: key.Value{},
 			},
 			want: true,
 		},
@@ -308,7 +391,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("test"),
+				value: key.Value{Data: []byte("test")},
 			},
 			want: true,
 		},
@@ -319,7 +402,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("testssadasd"),
+				value: key.Value{Data: []byte("testssadasd")},
 			},
 			want: false,
 		},
@@ -330,7 +413,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_NOT_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("test"),
+				value: key.Value{Data: []byte("test")},
 			},
 			want: false,
 		},
@@ -341,7 +424,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_NOT_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("testytest"),
+				value: key.Value{Data: []byte("testytest")},
 			},
 			want: true,
 		},
@@ -352,7 +435,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_GREATER,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("testa")},
 				},
-				value: []byte("testaa"),
+				value: key.Value{Data: []byte("testaa")},
 			},
 			want: true,
 		},
@@ -363,7 +446,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_GREATER,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("testa")},
 				},
-				value: []byte("test"),
+				value: key.Value{Data: []byte("test")},
 			},
 			want: false,
 		},
@@ -374,7 +457,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_LESS,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("testa"),
+				value: key.Value{Data: []byte("testa")},
 			},
 			want: false,
 		},
@@ -385,7 +468,78 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_LESS,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("testa")},
 				},
-				value: []byte("test"),
+				value: key.Value{Data: []byte("test")},
+			},
+			want: true,
+		},
+		{
+			name: "VERSION - EQUAL",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_VERSION,
+					TargetUnion: &regattapb.Compare_Version{Version: 2},
+				},
+				value: key.Value{Version: 2},
+			},
+			want: true,
+		},
+		{
+			name: "VERSION - unset target union",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result: regattapb.Compare_EQUAL,
+					Target: regattapb.Compare_VERSION,
+				},
+				value: key.Value{Version: 2},
+			},
+			want: false,
+		},
+		{
+			name: "CREATE - LESS",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_LESS,
+					Target:      regattapb.Compare_CREATE,
+					TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 10},
+				},
+				value: key.Value{CreateRevision: 5},
+			},
+			want: true,
+		},
+		{
+			name: "MOD - GREATER",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_GREATER,
+					Target:      regattapb.Compare_MOD,
+					TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 10},
+				},
+				value: key.Value{ModRevision: 10},
+			},
+			want: false,
+		},
+		{
+			name: "MOD - NOT EQUAL",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_NOT_EQUAL,
+					Target:      regattapb.Compare_MOD,
+					TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 10},
+				},
+				value: key.Value{ModRevision: 11},
+			},
+			want: true,
+		},
+		{
+			name: "LEASE - EQUAL",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_LEASE,
+					TargetUnion: &regattapb.Compare_Lease{Lease: 3},
+				},
+				value: key.Value{Lease: 3},
 			},
 			want: true,
 		},
@@ -396,3 +550,75 @@ func Test_txnCompareSingle(t *testing.T) {
 		})
 	}
 }
+
+func Test_txnCompareTargets(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 1}
+	for _, k := range []string{"key_1", "key_2"} {
+		_, err = handlePut(c, &regattapb.RequestOp_Put{Key: []byte(k), Value: []byte("value")})
+		r.NoError(err)
+	}
+	r.NoError(c.Commit())
+	c = &updateContext{batch: db.NewBatch(), db: db, index: 2}
+	_, err = handlePut(c, &regattapb.RequestOp_Put{Key: []byte("key_2"), Value: []byte("value")})
+	r.NoError(err)
+	r.NoError(c.Commit())
+
+	tests := []struct {
+		name string
+		cmp  *regattapb.Compare
+		want bool
+	}{
+		{
+			name: "MOD of the key",
+			cmp:  &regattapb.Compare{Key: []byte("key_2"), Target: regattapb.Compare_MOD, TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 2}},
+			want: true,
+		},
+		{
+			name: "MOD of the key - stale revision",
+			cmp:  &regattapb.Compare{Key: []byte("key_2"), Target: regattapb.Compare_MOD, TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 1}},
+			want: false,
+		},
+		{
+			name: "CREATE of the missing key",
+			cmp:  &regattapb.Compare{Key: []byte("key_3"), Target: regattapb.Compare_CREATE, TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0}},
+			want: true,
+		},
+		{
+			name: "VALUE of the missing key",
+			cmp:  &regattapb.Compare{Key: []byte("key_3"), TargetUnion: &regattapb.Compare_Value{Value: nil}},
+			want: false,
+		},
+		{
+			name: "VERSION of the range",
+			cmp:  &regattapb.Compare{Key: []byte("key_1"), RangeEnd: []byte("key_3"), Result: regattapb.Compare_GREATER, Target: regattapb.Compare_VERSION, TargetUnion: &regattapb.Compare_Version{Version: 0}},
+			want: true,
+		},
+		{
+			name: "MOD of the range",
+			cmp:  &regattapb.Compare{Key: []byte("key_1"), RangeEnd: []byte("key_3"), Result: regattapb.Compare_LESS, Target: regattapb.Compare_MOD, TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 2}},
+			want: false,
+		},
+		{
+			name: "VERSION of the empty range",
+			cmp:  &regattapb.Compare{Key: []byte("key_3"), RangeEnd: []byte("key_4"), Target: regattapb.Compare_VERSION, TargetUnion: &regattapb.Compare_Version{Version: 0}},
+			want: true,
+		},
+		{
+			name: "VALUE of the empty range",
+			cmp:  &regattapb.Compare{Key: []byte("key_3"), RangeEnd: []byte("key_4")},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := txnCompare(db, []*regattapb.Compare{tt.cmp})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
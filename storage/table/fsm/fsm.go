@@ -35,6 +35,14 @@ var (
 		KeyType: key.TypeSystem,
 		Key:     []byte("leader_index"),
 	})
+	sysValueVersion = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("value_version"),
+	})
+	sysValueMigration = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("value_migration"),
+	})
 	maxUserKey = mustEncodeKey(key.Key{
 		KeyType: key.TypeUser,
 		Key:     key.LatestMaxKey,
@@ -55,6 +63,18 @@ const (
 	ResultFailure UpdateResult = iota
 	// ResultSuccess applied update.
 	ResultSuccess
+	// ResultLeaseNotFound update was not applied as it refers to a lease that does not exist.
+	ResultLeaseNotFound
+	// ResultLeaseExists lease was not granted as the lease with the same ID already exists.
+	ResultLeaseExists
+	// ResultCompacted update was not applied as the requested revision has already been compacted.
+	ResultCompacted
+	// ResultFutureRevision update was not applied as the requested revision has not been reached yet.
+	ResultFutureRevision
+	// ResultCompactPending update compacted only a part of the history, the compaction continues in the next update.
+	ResultCompactPending
+	// ResultNotCounter update was not applied as it increments a key that does not hold a counter.
+	ResultNotCounter
 )
 
 type SnapshotRecoveryType uint8
@@ -86,7 +106,17 @@ func (s *snapshotHeader) snapshotType() SnapshotRecoveryType {
 	return SnapshotRecoveryType(s[6])
 }
 
-func New(tableName, stateMachineDir string, fs vfs.FS, blockCache *pebble.Cache, tableCache *pebble.TableCache, srt SnapshotRecoveryType) sm.CreateOnDiskStateMachineFunc {
+// EventSink receives the changes of the keys applied to the table, implementations must not block the caller.
+type EventSink interface {
+	// Reset signals that the table state was (re)loaded at the given revision, the events published
+	// before are not continuous with the events published afterwards.
+	Reset(table string, revision int64)
+	// Publish publishes the events of the committed updates in the order of the revisions.
+	Publish(table string, events []*regattapb.Event)
+}
+
+// New returns the constructor of the table FSM, the opts are applied on top of the default pebble options.
+func New(tableName, stateMachineDir string, fs vfs.FS, blockCache *pebble.Cache, tableCache *pebble.TableCache, srt SnapshotRecoveryType, events EventSink, opts ...rp.Option) sm.CreateOnDiskStateMachineFunc {
 	if fs == nil {
 		fs = vfs.Default
 	}
@@ -105,6 +135,8 @@ func New(tableName, stateMachineDir string, fs vfs.FS, blockCache *pebble.Cache,
 			log:          zap.S().Named("table").Named(tableName),
 			metrics:      newMetrics(tableName, clusterID),
 			recoveryType: srt,
+			events:       events,
+			dbOptions:    opts,
 		}
 	}
 }
@@ -123,6 +155,8 @@ type FSM struct {
 	tableCache   *pebble.TableCache
 	metrics      *metrics
 	recoveryType SnapshotRecoveryType
+	events       EventSink
+	dbOptions    []rp.Option
 }
 
 func (p *FSM) Open(_ <-chan struct{}) (uint64, error) {
@@ -167,6 +201,15 @@ func (p *FSM) Open(_ <-chan struct{}) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if err := migrateValues(db); err != nil {
+		return 0, err
+	}
+	if err := migrateHistory(db); err != nil {
+		return 0, err
+	}
+	if err := p.resetEvents(db); err != nil {
+		return 0, err
+	}
 	p.pebble.Store(db)
 
 	if err := prometheus.Register(p); err != nil {
@@ -181,15 +224,28 @@ func (p *FSM) Open(_ <-chan struct{}) (uint64, error) {
 	return idx, nil
 }
 
+// resetEvents signals the event sink that the table state was (re)loaded from the db.
+func (p *FSM) resetEvents(db pebble.Reader) error {
+	if p.events == nil {
+		return nil
+	}
+	rev, err := currentRevision(db)
+	if err != nil {
+		return err
+	}
+	p.events.Reset(p.tableName, int64(rev))
+	return nil
+}
+
 func (p *FSM) openDB(dbdir string) (*pebble.DB, error) {
-	return rp.OpenDB(
-		dbdir,
+	opts := append([]rp.Option{
 		rp.WithFS(p.fs),
 		rp.WithCache(p.blockCache),
 		rp.WithTableCache(p.tableCache),
 		rp.WithLogger(p.log),
 		rp.WithEventListener(makeLoggingEventListener(p.log)),
-	)
+	}, p.dbOptions...)
+	return rp.OpenDB(dbdir, opts...)
 }
 
 // Lookup locally looks up the data.
@@ -199,34 +255,32 @@ func (p *FSM) Lookup(l interface{}) (interface{}, error) {
 		snapshot := p.pebble.Load().NewSnapshot()
 		defer snapshot.Close()
 
-		ok, err := txnCompare(snapshot, req.Compare)
+		ok, responses, err := txnLookup(snapshot, req.Compare, req.Success, req.Failure)
 		if err != nil {
 			return nil, err
 		}
-
-		var ops []*regattapb.RequestOp_Range
-		if ok {
-			for _, op := range req.Success {
-				ops = append(ops, op.GetRequestRange())
-			}
-		} else {
-			for _, op := range req.Failure {
-				ops = append(ops, op.GetRequestRange())
-			}
-		}
-
-		resp := &regattapb.TxnResponse{Succeeded: ok}
-		for _, op := range ops {
-			rr, err := lookup(snapshot, op)
-			if err != nil {
-				return nil, err
-			}
-			resp.Responses = append(resp.Responses, wrapResponseOp(rr))
-		}
-		return resp, nil
+		return &regattapb.TxnResponse{Succeeded: ok, Responses: responses}, nil
 	case *regattapb.RequestOp_Range:
 		db := p.pebble.Load()
+		if req.Revision > 0 {
+			// Reads at a revision merge the latest versions with the history, read both from the same snapshot.
+			snapshot := db.NewSnapshot()
+			defer snapshot.Close()
+			return lookup(snapshot, req)
+		}
 		return lookup(db, req)
+	case CursorRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		idx, err := readLocalIndex(snapshot, sysLocalIndex)
+		if err != nil {
+			return nil, err
+		}
+		if err := cursor(snapshot, req.RangeOp, req.BatchSize, req.Consumer, req.Stopper); err != nil {
+			return nil, err
+		}
+		return &CursorResponse{Index: idx}, nil
 	case SnapshotRequest:
 		snapshot := p.pebble.Load().NewSnapshot()
 		defer snapshot.Close()
@@ -235,7 +289,45 @@ func (p *FSM) Lookup(l interface{}) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &SnapshotResponse{Index: idx}, nil
+		leaderIdx, err := readLocalIndex(snapshot, sysLeaderIndex)
+		if err != nil {
+			return nil, err
+		}
+		offset, err := readLocalIndex(snapshot, sysRevisionOffset)
+		if err != nil {
+			return nil, err
+		}
+		leaderOffset, err := readLocalIndex(snapshot, sysLeaderRevisionOffset)
+		if err != nil {
+			return nil, err
+		}
+		resp := &SnapshotResponse{Index: idx, LeaderIndex: leaderIdx, Revision: idx + offset}
+		if leaderIdx != 0 {
+			resp.LeaderRevision = leaderIdx + leaderOffset
+		}
+		return resp, nil
+	case LeaseRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		lease, err := readLease(snapshot, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp := &LeaseResponse{Lease: lease}
+		if lease != nil && req.Keys {
+			resp.Keys, err = leaseKeys(snapshot, req.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	case ExpiredLeasesRequest:
+		leases, err := expiredLeases(p.pebble.Load(), req.Now, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		return &ExpiredLeasesResponse{Leases: leases}, nil
 	case LocalIndexRequest:
 		idx, err := readLocalIndex(p.pebble.Load(), sysLocalIndex)
 		if err != nil {
@@ -248,6 +340,29 @@ func (p *FSM) Lookup(l interface{}) (interface{}, error) {
 			return nil, err
 		}
 		return &IndexResponse{Index: idx}, nil
+	case RevisionRequest:
+		rev, err := currentRevision(p.pebble.Load())
+		if err != nil {
+			return nil, err
+		}
+		return &IndexResponse{Index: rev}, nil
+	case RevisionOffsetRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		idx, err := readLocalIndex(snapshot, sysLocalIndex)
+		if err != nil {
+			return nil, err
+		}
+		offset, err := readLocalIndex(snapshot, sysRevisionOffset)
+		if err != nil {
+			return nil, err
+		}
+		leaderOffset, err := readLocalIndex(snapshot, sysLeaderRevisionOffset)
+		if err != nil {
+			return nil, err
+		}
+		return &RevisionOffsetResponse{Index: idx, RevisionOffset: offset, LeaderRevisionOffset: leaderOffset}, nil
 	case PathRequest:
 		return &PathResponse{Path: p.dirname}, nil
 	default:
@@ -262,16 +377,29 @@ func (p *FSM) Update(updates []sm.Entry) ([]sm.Entry, error) {
 	db := p.pebble.Load()
 
 	ctx := &updateContext{
-		batch: db.NewBatch(),
-		db:    db,
+		batch:       db.NewBatch(),
+		db:          db,
+		trackEvents: p.events != nil,
+		fs:          p.fs,
+		dirname:     p.dirname,
 	}
 
 	defer func() {
 		_ = ctx.Close()
 	}()
 
+	var err error
+	if ctx.revisionOffset, err = readLocalIndex(db, sysRevisionOffset); err != nil {
+		return nil, err
+	}
+	if ctx.leaderRevisionOffset, err = readLocalIndex(db, sysLeaderRevisionOffset); err != nil {
+		return nil, err
+	}
+
 	var idx uint64
 	for i := 0; i < len(updates); i++ {
+		ctx.appliedIndex, ctx.appliedLeaderIndex = idx, ctx.leaderIndex
+		ctx.entryStart = ctx.batch.Count()
 		cmd, err := parseCommand(ctx, updates[i])
 		if err != nil {
 			return nil, err
@@ -281,8 +409,14 @@ func (p *FSM) Update(updates []sm.Entry) ([]sm.Entry, error) {
 		if err != nil {
 			return nil, err
 		}
+		// The local commands following the commands applied with the leader revision continue above it.
+		if ctx.leaderIndex != nil {
+			if err := raiseRevision(ctx, ctx.Revision()); err != nil {
+				return nil, err
+			}
+		}
 
-		if len(res.Responses) > 0 {
+		if len(res.Responses) > 0 || res.Lease != nil {
 			bts, err := res.MarshalVT()
 			if err != nil {
 				return nil, err
@@ -296,6 +430,14 @@ func (p *FSM) Update(updates []sm.Entry) ([]sm.Entry, error) {
 	if err := ctx.Commit(); err != nil {
 		return nil, err
 	}
+	if len(ctx.events) > 0 {
+		p.events.Publish(p.tableName, ctx.events)
+	}
+	if ctx.reloaded {
+		if err := p.resetEvents(db); err != nil {
+			return nil, err
+		}
+	}
 
 	p.metrics.applied.Store(idx)
 	return updates, nil
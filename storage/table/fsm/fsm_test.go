@@ -137,6 +137,101 @@ func TestSMReOpen(t *testing.T) {
 	r.Equal(testIndex, index)
 }
 
+type recordingSink struct {
+	resets []int64
+	events []*regattapb.Event
+}
+
+func (s *recordingSink) Reset(_ string, revision int64) {
+	s.resets = append(s.resets, revision)
+}
+
+func (s *recordingSink) Publish(_ string, events []*regattapb.Event) {
+	s.events = append(s.events, events...)
+}
+
+func TestSM_Events(t *testing.T) {
+	r := require.New(t)
+	sink := &recordingSink{}
+	p := &FSM{
+		fs:        vfs.NewMem(),
+		clusterID: 1,
+		nodeID:    1,
+		dirname:   "/tmp/dir",
+		log:       zap.NewNop().Sugar(),
+		metrics:   newMetrics(testTable, 1),
+		events:    sink,
+	}
+	_, err := p.Open(nil)
+	r.NoError(err)
+	defer func() {
+		r.NoError(p.Close())
+	}()
+	r.Equal([]int64{0}, sink.resets)
+
+	_, err = p.Update([]sm.Entry{
+		{
+			Index: 1,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Type: regattapb.Command_PUT_BATCH,
+				Batch: []*regattapb.KeyValue{
+					{Key: []byte("key_1"), Value: []byte("value")},
+					{Key: []byte("key_2"), Value: []byte("value")},
+				},
+			}),
+		},
+		{
+			Index: 2,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Type: regattapb.Command_PUT,
+				Kv:   &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_2")},
+			}),
+		},
+		{
+			Index: 3,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Type: regattapb.Command_DELETE,
+				Kv:   &regattapb.KeyValue{Key: []byte("key_3")},
+			}),
+		},
+		{
+			Index: 4,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Type:     regattapb.Command_DELETE,
+				Kv:       &regattapb.KeyValue{Key: []byte("key_1")},
+				RangeEnd: []byte{0},
+			}),
+		},
+	})
+	r.NoError(err)
+
+	r.Equal([]*regattapb.Event{
+		{
+			Type: regattapb.Event_PUT,
+			Kv:   &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+		},
+		{
+			Type: regattapb.Event_PUT,
+			Kv:   &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+		},
+		{
+			Type:   regattapb.Event_PUT,
+			Kv:     &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_2"), CreateRevision: 1, ModRevision: 2, Version: 2},
+			PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+		},
+		{
+			Type:   regattapb.Event_DELETE,
+			Kv:     &regattapb.KeyValue{Key: []byte("key_1"), ModRevision: 4},
+			PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_2"), CreateRevision: 1, ModRevision: 2, Version: 2},
+		},
+		{
+			Type:   regattapb.Event_DELETE,
+			Kv:     &regattapb.KeyValue{Key: []byte("key_2"), ModRevision: 4},
+			PrevKv: &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+		},
+	}, sink.events)
+}
+
 func TestSM_Update(t *testing.T) {
 	type fields struct {
 		smFactory func() *FSM
@@ -433,7 +528,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 2,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: &regattapb.ResponseOp_Put{}}},
 							},
@@ -445,7 +540,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 3,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{}}},
 							},
@@ -516,7 +611,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 2,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: &regattapb.ResponseOp_Put{}}},
 							},
@@ -528,7 +623,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 3,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{}}},
 							},
@@ -587,7 +682,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 2,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{Deleted: 1}}},
 							},
@@ -659,7 +754,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 2,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: &regattapb.ResponseOp_Put{}}},
 							},
@@ -671,7 +766,7 @@ func TestSM_Update(t *testing.T) {
 					Result: sm.Result{
 						Value: 1,
 						Data: mustMarshallProto(&regattapb.CommandResult{
-							Revision: 3,
+							Revision: 1,
 							Responses: []*regattapb.ResponseOp{
 								{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{Deleted: 2}}},
 							},
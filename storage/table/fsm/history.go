@@ -0,0 +1,413 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/key"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+)
+
+// The history of the user keys is kept in the V2 keys carrying the revision, the latest versions stay in the V1 user keys.
+// Once a version of the key is superseded (or deleted) it is stored under the key at its ModRevision, the deletion of the key
+// is stored as a tombstone (empty value) under the key at the revision of the deletion.
+// The version of the key at the revision R is then either the latest version if its ModRevision <= R,
+// or the newest history record of the key at a revision <= R.
+
+// sysCompactRevision the revision the history of the keys is compacted to, the table could not be read at any older revision.
+var sysCompactRevision = mustEncodeKey(key.Key{
+	KeyType: key.TypeSystem,
+	Key:     []byte("compact_revision"),
+})
+
+// sysCompactCursor the key the pending compaction of the history continues from.
+var sysCompactCursor = mustEncodeKey(key.Key{
+	KeyType: key.TypeSystem,
+	Key:     []byte("compact_cursor"),
+})
+
+// sysRevision the revision of the last applied command.
+var sysRevision = mustEncodeKey(key.Key{
+	KeyType: key.TypeSystem,
+	Key:     []byte("revision"),
+})
+
+// sysRevisionOffset the offset of the revisions of the local commands from the local index. The tables restored from
+// a snapshot or a backup continue above the restored revisions even though the local index starts over.
+var sysRevisionOffset = mustEncodeKey(key.Key{
+	KeyType: key.TypeSystem,
+	Key:     []byte("revision_offset"),
+})
+
+// sysLeaderRevisionOffset the offset of the revisions of the replicated commands from the leader index,
+// the revision offset of the leader cluster table.
+var sysLeaderRevisionOffset = mustEncodeKey(key.Key{
+	KeyType: key.TypeSystem,
+	Key:     []byte("leader_revision_offset"),
+})
+
+// raiseRevision moves the revision offset so that the revisions of the following local commands are above rev.
+func raiseRevision(ctx *updateContext, rev int64) error {
+	if rev <= int64(ctx.index+ctx.revisionOffset) {
+		return nil
+	}
+	ctx.revisionOffset = uint64(rev) - ctx.index
+	return ctx.batch.Set(sysRevisionOffset, binary.LittleEndian.AppendUint64(nil, ctx.revisionOffset), nil)
+}
+
+// raiseLeaderRevision moves the leader revision offset so that the revision at the leader index is rev.
+func raiseLeaderRevision(ctx *updateContext, leaderIndex uint64, rev int64) error {
+	if rev <= int64(leaderIndex+ctx.leaderRevisionOffset) {
+		return nil
+	}
+	ctx.leaderRevisionOffset = uint64(rev) - leaderIndex
+	return ctx.batch.Set(sysLeaderRevisionOffset, binary.LittleEndian.AppendUint64(nil, ctx.leaderRevisionOffset), nil)
+}
+
+// raiseCompactRevision moves the compact revision to rev unless the table is already compacted to a newer revision.
+func raiseCompactRevision(ctx *updateContext, rev int64) error {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return err
+	}
+	compacted, err := readLocalIndex(ctx.batch, sysCompactRevision)
+	if err != nil {
+		return err
+	}
+	if rev <= int64(compacted) {
+		return nil
+	}
+	return writeCompactRevision(ctx.batch, rev)
+}
+
+func writeCompactRevision(batch *pebble.Batch, rev int64) error {
+	return batch.Set(sysCompactRevision, binary.LittleEndian.AppendUint64(nil, uint64(rev)), nil)
+}
+
+// writeHistory stores the superseded version of the user key k.
+func writeHistory(batch *pebble.Batch, k []byte, prev key.Value) error {
+	return batch.Set(historyKey(k, prev.ModRevision), key.EncodeValue(nil, prev), nil)
+}
+
+// writeTombstone stores the deletion of the user key k at the revision rev.
+func writeTombstone(batch *pebble.Batch, k []byte, rev int64) error {
+	return batch.Set(historyKey(k, rev), nil, nil)
+}
+
+func historyKey(k []byte, rev int64) []byte {
+	return mustEncodeKey(key.NewRevisionKey(key.TypeUser, k, rev))
+}
+
+// historyIterOptions returns the bounds of the history records of the user keys within the range [low, high).
+func historyIterOptions(low, high []byte) *pebble.IterOptions {
+	opts := &pebble.IterOptions{LowerBound: key.AppendV2Prefix(nil, key.TypeUser, low)}
+	if bytes.Equal(high, wildcard) {
+		opts.UpperBound = key.AppendV2Prefix(nil, key.TypeUser+1, nil)
+	} else {
+		opts.UpperBound = key.AppendV2Prefix(nil, key.TypeUser, high)
+	}
+	return opts
+}
+
+// currentRevision returns the revision of the last applied command.
+func currentRevision(reader pebble.Reader) (uint64, error) {
+	rev, err := readLocalIndex(reader, sysRevision)
+	if err != nil || rev != 0 {
+		return rev, err
+	}
+	// Tables last updated before the revision was stored, the tables replicated from the leader cluster keep the leader revisions.
+	rev, err = readLocalIndex(reader, sysLeaderIndex)
+	if err != nil || rev != 0 {
+		return rev, err
+	}
+	return readLocalIndex(reader, sysLocalIndex)
+}
+
+// checkRevision ensures the table could be read at the revision rev.
+func checkRevision(reader pebble.Reader, rev int64) error {
+	compacted, err := readLocalIndex(reader, sysCompactRevision)
+	if err != nil {
+		return err
+	}
+	if rev < int64(compacted) {
+		return serrors.ErrCompacted
+	}
+	current, err := currentRevision(reader)
+	if err != nil {
+		return err
+	}
+	if rev > int64(current) {
+		return serrors.ErrFutureRevision
+	}
+	return nil
+}
+
+// readUserValue reads the latest version of the user key k, nil is returned if the key does not exist.
+func readUserValue(reader pebble.Reader, k []byte) (*key.Value, error) {
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	if err := encodeUserKey(keyBuf, k); err != nil {
+		return nil, err
+	}
+	return readValue(reader, keyBuf.Bytes())
+}
+
+// historicalValue reads the version of the user key k at the revision rev, nil is returned if the key did not exist.
+func historicalValue(reader pebble.Reader, k []byte, rev int64) (*key.Value, error) {
+	latest, err := readUserValue(reader, k)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.ModRevision <= rev {
+		return latest, nil
+	}
+	iter := reader.NewIter(&pebble.IterOptions{
+		LowerBound: historyKey(k, 0),
+		UpperBound: historyKey(k, rev+1),
+	})
+	defer func() {
+		_ = iter.Close()
+	}()
+	if !iter.Last() {
+		return nil, iter.Error()
+	}
+	return decodeHistoryValue(iter.Value())
+}
+
+// historyGroupValue reads the version at the revision rev from the history records of the user key k starting
+// at the current position of the iterator, the iterator is advanced past the records of the key.
+func historyGroupValue(iter *pebble.Iterator, k []byte, rev int64) (*key.Value, error) {
+	var raw []byte
+	found := false
+	for ; iter.Valid(); iter.Next() {
+		hk, err := key.DecodeBytes(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(hk.Key, k) {
+			break
+		}
+		if hk.Revision <= rev {
+			raw = append(raw[:0], iter.Value()...)
+			found = true
+		}
+	}
+	if !found {
+		return nil, iter.Error()
+	}
+	return decodeHistoryValue(raw)
+}
+
+func decodeHistoryValue(raw []byte) (*key.Value, error) {
+	// Tombstone, the key was deleted.
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	value, err := key.DecodeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	value.Data = bytes.Clone(value.Data)
+	return &value, nil
+}
+
+// visitRevision calls fn for every user key within the range of the request as it was at the revision of the request
+// in the ascending order of the keys until fn returns false.
+func visitRevision(reader pebble.Reader, req *regattapb.RequestOp_Range, fn func(k []byte, value key.Value) bool) error {
+	if req.RangeEnd == nil {
+		value, err := historicalValue(reader, req.Key, req.Revision)
+		if err != nil || value == nil {
+			return err
+		}
+		fn(req.Key, *value)
+		return nil
+	}
+
+	opts, err := iterOptionsForBounds(req.Key, req.RangeEnd)
+	if err != nil {
+		return err
+	}
+	latest := reader.NewIter(opts)
+	defer func() {
+		_ = latest.Close()
+	}()
+	history := reader.NewIter(historyIterOptions(req.Key, req.RangeEnd))
+	defer func() {
+		_ = history.Close()
+	}()
+
+	latest.First()
+	history.First()
+	for latest.Valid() || history.Valid() {
+		var lk, hk key.Key
+		if latest.Valid() {
+			if lk, err = key.DecodeBytes(latest.Key()); err != nil {
+				return err
+			}
+		}
+		if history.Valid() {
+			if hk, err = key.DecodeBytes(history.Key()); err != nil {
+				return err
+			}
+		}
+		// Merge the latest versions with the history records by the user key.
+		var c int
+		switch {
+		case !history.Valid():
+			c = -1
+		case !latest.Valid():
+			c = 1
+		default:
+			c = bytes.Compare(lk.Key, hk.Key)
+		}
+
+		var (
+			k     []byte
+			value *key.Value
+		)
+		if c <= 0 {
+			k = lk.Key
+			v, err := key.DecodeValue(latest.Value())
+			if err != nil {
+				return err
+			}
+			if v.ModRevision <= req.Revision {
+				value = &v
+			}
+		}
+		if c >= 0 {
+			if c > 0 {
+				k = bytes.Clone(hk.Key)
+			}
+			hv, err := historyGroupValue(history, k, req.Revision)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				value = hv
+			}
+		}
+		if value != nil && !fn(k, *value) {
+			return nil
+		}
+		if c <= 0 {
+			latest.Next()
+		}
+	}
+	if err := latest.Error(); err != nil {
+		return err
+	}
+	return history.Error()
+}
+
+// historicalLookup performs the range lookup at the revision of the request.
+func historicalLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.ResponseOp_Range, error) {
+	if err := checkRevision(reader, req.Revision); err != nil {
+		return nil, err
+	}
+	fill, sf := iterFuncsFromReq(req)
+	filter := revisionFilter(req)
+	if sortInMemory(req) || isReverse(req) {
+		var entries []sortedEntry
+		err := visitRevision(reader, req, func(k []byte, value key.Value) bool {
+			if filter == nil || filter(value) {
+				value.Data = bytes.Clone(value.Data)
+				entries = append(entries, sortedEntry{key: bytes.Clone(k), value: value})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		sortEntries(entries, req)
+		return fillEntries(entries, int(req.Limit), fill, sf), nil
+	}
+
+	response := &regattapb.ResponseOp_Range{}
+	i := 0
+	err := visitRevision(reader, req, func(k []byte, value key.Value) bool {
+		if filter != nil && !filter(value) {
+			return true
+		}
+		if i == int(req.Limit) && req.Limit != 0 || (uint64(response.SizeVT())+sf(k, value)) >= maxRangeSize {
+			response.More = true
+			return false
+		}
+		i++
+		fill(k, value, response)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// historicalCursor streams the range at the revision of the request in batches to the consumer.
+func historicalCursor(reader pebble.Reader, req *regattapb.RequestOp_Range, batchSize uint64, consumer func(*regattapb.ResponseOp_Range) error, stopc <-chan struct{}) error {
+	if err := checkRevision(reader, req.Revision); err != nil {
+		return err
+	}
+	if batchSize == 0 {
+		batchSize = DefaultCursorBatchSize
+	}
+	fill, sf := iterFuncsFromReq(req)
+	filter := revisionFilter(req)
+	response := &regattapb.ResponseOp_Range{}
+	i := 0
+	var emitErr error
+	emit := func(k []byte, value key.Value) bool {
+		select {
+		case <-stopc:
+			emitErr = sm.ErrSnapshotStopped
+			return false
+		default:
+		}
+		if filter != nil && !filter(value) {
+			return true
+		}
+		if req.Limit != 0 && i == int(req.Limit) {
+			return false
+		}
+		if len(response.Kvs) > 0 && uint64(response.SizeVT())+sf(k, value)+maxKeyValueOverhead > batchSize {
+			response.More = true
+			if emitErr = consumer(response); emitErr != nil {
+				return false
+			}
+			response = &regattapb.ResponseOp_Range{}
+		}
+		i++
+		fill(k, value, response)
+		return true
+	}
+
+	if isReverse(req) {
+		// The history is merged in the ascending order of the keys only, reverse it in memory.
+		var entries []sortedEntry
+		err := visitRevision(reader, req, func(k []byte, value key.Value) bool {
+			value.Data = bytes.Clone(value.Data)
+			entries = append(entries, sortedEntry{key: bytes.Clone(k), value: value})
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		slices.Reverse(entries)
+		for _, e := range entries {
+			if !emit(e.key, e.value) {
+				break
+			}
+		}
+	} else if err := visitRevision(reader, req, emit); err != nil {
+		return err
+	}
+	if emitErr != nil {
+		return emitErr
+	}
+	return consumer(response)
+}
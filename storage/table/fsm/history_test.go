@@ -0,0 +1,269 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/stretchr/testify/require"
+)
+
+// historyDB returns the DB with the following history:
+// rev 1: PUT key_1=value_1
+// rev 2: PUT key_1=value_2, PUT key_2=value_1
+// rev 3: DELETE key_1
+// rev 4: PUT key_1=value_3, DELETE [key_2, key_3)
+// rev 5: PUT key_3=value_1.
+func historyDB(t *testing.T) *pebble.DB {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+
+	apply := func(index uint64, fn func(c *updateContext) error) {
+		c := &updateContext{batch: db.NewBatch(), db: db, index: index}
+		defer func() { _ = c.Close() }()
+		r.NoError(fn(c))
+		r.NoError(c.Commit())
+	}
+	put := func(c *updateContext, k, v string) error {
+		_, err := handlePut(c, &regattapb.RequestOp_Put{Key: []byte(k), Value: []byte(v)})
+		return err
+	}
+	apply(1, func(c *updateContext) error {
+		return put(c, "key_1", "value_1")
+	})
+	apply(2, func(c *updateContext) error {
+		if err := put(c, "key_1", "value_2"); err != nil {
+			return err
+		}
+		return put(c, "key_2", "value_1")
+	})
+	apply(3, func(c *updateContext) error {
+		_, err := handleDelete(c, &regattapb.RequestOp_DeleteRange{Key: []byte("key_1")})
+		return err
+	})
+	apply(4, func(c *updateContext) error {
+		if err := put(c, "key_1", "value_3"); err != nil {
+			return err
+		}
+		_, err := handleDelete(c, &regattapb.RequestOp_DeleteRange{Key: []byte("key_2"), RangeEnd: []byte("key_3")})
+		return err
+	})
+	apply(5, func(c *updateContext) error {
+		return put(c, "key_3", "value_1")
+	})
+	return db
+}
+
+func historyKV(k, v string, create, mod, version int64) *regattapb.KeyValue {
+	return &regattapb.KeyValue{Key: []byte(k), Value: []byte(v), CreateRevision: create, ModRevision: mod, Version: version}
+}
+
+func TestHistory_Lookup(t *testing.T) {
+	db := historyDB(t)
+	defer db.Close()
+
+	tests := []struct {
+		name string
+		req  *regattapb.RequestOp_Range
+		want *regattapb.ResponseOp_Range
+	}{
+		{
+			name: "single key at the first revision",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 1},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_1", 1, 1, 1)}, Count: 1},
+		},
+		{
+			name: "single key superseded",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 2},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_2", 1, 2, 2)}, Count: 1},
+		},
+		{
+			name: "single key deleted",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 3},
+			want: &regattapb.ResponseOp_Range{},
+		},
+		{
+			name: "single key recreated",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 5},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_3", 4, 4, 1)}, Count: 1},
+		},
+		{
+			name: "single key not yet created",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key_3"), Revision: 4},
+			want: &regattapb.ResponseOp_Range{},
+		},
+		{
+			name: "range",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_2", 1, 2, 2), historyKV("key_2", "value_1", 2, 2, 1)}, Count: 2},
+		},
+		{
+			name: "range with deleted key",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 3},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_2", "value_1", 2, 2, 1)}, Count: 1},
+		},
+		{
+			name: "range at the latest revision",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 5},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_3", 4, 4, 1), historyKV("key_3", "value_1", 5, 5, 1)}, Count: 2},
+		},
+		{
+			name: "range with limit",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2, Limit: 1},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_2", 1, 2, 2)}, Count: 1, More: true},
+		},
+		{
+			name: "range count only",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2, CountOnly: true},
+			want: &regattapb.ResponseOp_Range{Count: 2},
+		},
+		{
+			name: "range descending",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2, SortOrder: regattapb.RequestOp_Range_DESCEND},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_2", "value_1", 2, 2, 1), historyKV("key_1", "value_2", 1, 2, 2)}, Count: 2},
+		},
+		{
+			name: "range sorted by version",
+			req: &regattapb.RequestOp_Range{
+				Key:        []byte("key"),
+				RangeEnd:   wildcard,
+				Revision:   2,
+				SortOrder:  regattapb.RequestOp_Range_DESCEND,
+				SortTarget: regattapb.RequestOp_Range_VERSION,
+			},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_1", "value_2", 1, 2, 2), historyKV("key_2", "value_1", 2, 2, 1)}, Count: 2},
+		},
+		{
+			name: "range with revision filter",
+			req:  &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2, MinCreateRevision: 2},
+			want: &regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{historyKV("key_2", "value_1", 2, 2, 1)}, Count: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := lookup(db, tt.req)
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestHistory_LookupFutureRevision(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	_, err := lookup(db, &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 6})
+	r.ErrorIs(err, serrors.ErrFutureRevision)
+}
+
+func TestHistory_Cursor(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	var got []*regattapb.KeyValue
+	consumer := func(response *regattapb.ResponseOp_Range) error {
+		got = append(got, response.Kvs...)
+		return nil
+	}
+	r.NoError(cursor(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2}, 1, consumer, nil))
+	r.Equal([]*regattapb.KeyValue{historyKV("key_1", "value_2", 1, 2, 2), historyKV("key_2", "value_1", 2, 2, 1)}, got)
+
+	got = nil
+	r.NoError(cursor(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 2, SortOrder: regattapb.RequestOp_Range_DESCEND}, 0, consumer, nil))
+	r.Equal([]*regattapb.KeyValue{historyKV("key_2", "value_1", 2, 2, 1), historyKV("key_1", "value_2", 1, 2, 2)}, got)
+
+	r.ErrorIs(cursor(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: 6}, 0, consumer, nil), serrors.ErrFutureRevision)
+}
+
+func TestHistory_Migrate(t *testing.T) {
+	r := require.New(t)
+	db := historyDB(t)
+	defer db.Close()
+
+	r.NoError(migrateHistory(db))
+	compacted, err := readLocalIndex(db, sysCompactRevision)
+	r.NoError(err)
+	r.Equal(uint64(5), compacted)
+
+	_, err = lookup(db, &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 4})
+	r.ErrorIs(err, serrors.ErrCompacted)
+
+	// The migration does not move the compact revision once set.
+	r.NoError(db.Set(sysCompactRevision, binary.LittleEndian.AppendUint64(nil, 2), pebble.NoSync))
+	r.NoError(migrateHistory(db))
+	compacted, err = readLocalIndex(db, sysCompactRevision)
+	r.NoError(err)
+	r.Equal(uint64(2), compacted)
+}
+
+func TestHistory_Restore(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() { _ = p.Close() }()
+
+	put := func(k, v string, leaderIndex *uint64) *regattapb.Command {
+		return &regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_PUT, LeaderIndex: leaderIndex, Kv: &regattapb.KeyValue{Key: []byte(k), Value: []byte(v)}}
+	}
+	b := &IngestBuilder{}
+	r.NoError(b.Add(&regattapb.Command{Table: []byte(testTable), Kv: historyKV("key_1", "value_1", 90, 100, 1)}))
+	ingest, err := b.Build()
+	r.NoError(err)
+	snapshotIndex, replayedIndex := uint64(120), uint64(121)
+
+	// The snapshot taken at the leader index 120 at the revision 150 followed by the replayed log and the local writes.
+	_, err = p.Update([]sm.Entry{
+		{Index: 1, Cmd: mustMarshallProto(ingest)},
+		{Index: 2, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_DUMMY, LeaderIndex: &snapshotIndex, Revision: 150})},
+		{Index: 3, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_SEQUENCE, LeaderIndex: &replayedIndex, Sequence: []*regattapb.Command{put("key_1", "value_2", &replayedIndex)}})},
+	})
+	r.NoError(err)
+	res, err := p.Update([]sm.Entry{
+		{Index: 4, Cmd: mustMarshallProto(put("key_1", "value_3", nil))},
+		{Index: 5, Cmd: mustMarshallProto(put("key_2", "value_1", nil))},
+	})
+	r.NoError(err)
+	result := &regattapb.CommandResult{}
+	r.NoError(result.UnmarshalVT(res[1].Result.Data))
+	r.Equal(uint64(153), result.Revision)
+
+	db := p.pebble.Load()
+	rev, err := currentRevision(db)
+	r.NoError(err)
+	r.Equal(uint64(153), rev)
+
+	tests := []struct {
+		rev  int64
+		want []*regattapb.KeyValue
+	}{
+		{rev: 100, want: []*regattapb.KeyValue{historyKV("key_1", "value_1", 90, 100, 1)}},
+		{rev: 151, want: []*regattapb.KeyValue{historyKV("key_1", "value_2", 90, 151, 2)}},
+		{rev: 152, want: []*regattapb.KeyValue{historyKV("key_1", "value_3", 90, 152, 3)}},
+		{rev: 153, want: []*regattapb.KeyValue{historyKV("key_1", "value_3", 90, 152, 3), historyKV("key_2", "value_1", 153, 153, 1)}},
+	}
+	for _, tt := range tests {
+		got, err := lookup(db, &regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: wildcard, Revision: tt.rev})
+		r.NoError(err)
+		r.Equal(tt.want, got.Kvs, "revision %d", tt.rev)
+	}
+	_, err = lookup(db, &regattapb.RequestOp_Range{Key: []byte("key_1"), Revision: 99})
+	r.ErrorIs(err, serrors.ErrCompacted)
+
+	snap, err := p.Lookup(SnapshotRequest{Writer: io.Discard})
+	r.NoError(err)
+	r.Equal(uint64(5), snap.(*SnapshotResponse).Index)
+	r.Equal(uint64(153), snap.(*SnapshotResponse).Revision)
+	r.Equal(uint64(121), snap.(*SnapshotResponse).LeaderIndex)
+	r.Equal(uint64(151), snap.(*SnapshotResponse).LeaderRevision)
+}
@@ -0,0 +1,131 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+// migratedRevision the revision the migrated values are created and modified at, the revisions must be set so that
+// the values are not mistaken for missing keys.
+const migratedRevision = 1
+
+// readValueVersion reads the version of the encoding of the user values stored in the DB.
+func readValueVersion(db pebble.Reader) (uint8, error) {
+	val, closer, err := db.Get(sysValueVersion)
+	if err != nil {
+		if !errors.Is(err, pebble.ErrNotFound) {
+			return key.UnknownValueVersion, err
+		}
+		return key.UnknownValueVersion, nil
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	if len(val) != 1 {
+		return key.UnknownValueVersion, key.ErrMalformedValue
+	}
+	return val[0], nil
+}
+
+// migrateValues rewrites raw user values written prior to the value versioning into the latest value version,
+// values of an older version are left as they are.
+// Migrated values are created and modified at the migratedRevision and start at version 1, the migration runs on every
+// replica separately so the values must not depend on the state of the replica. The migration progress is committed
+// atomically with every batch so that an interrupted migration resumes without encoding any value twice.
+func migrateValues(db *pebble.DB) error {
+	ver, err := readValueVersion(db)
+	if err != nil {
+		return err
+	}
+	switch {
+	case ver == key.LatestValueVersion:
+		return nil
+	case ver > key.UnknownValueVersion && ver < key.LatestValueVersion:
+		// Versioned values are self-describing, only the marker has to be bumped.
+		if err := db.Set(sysValueVersion, []byte{key.LatestValueVersion}, pebble.NoSync); err != nil {
+			return err
+		}
+		return db.Flush()
+	case ver != key.UnknownValueVersion:
+		return key.ErrUnknownValueVersion
+	}
+
+	opts, err := iterOptionsForBounds(nil, wildcard)
+	if err != nil {
+		return err
+	}
+	progress, closer, err := db.Get(sysValueMigration)
+	switch {
+	case err == nil:
+		// Resume right after the last migrated key.
+		opts.LowerBound = append(append([]byte(nil), progress...), 0)
+		_ = closer.Close()
+	case !errors.Is(err, pebble.ErrNotFound):
+		return err
+	}
+
+	iter := db.NewIter(opts)
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	batch := db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	for iter.First(); iter.Valid(); iter.Next() {
+		if err := batch.Set(iter.Key(), key.EncodeValue(nil, key.Value{CreateRevision: migratedRevision, ModRevision: migratedRevision, Version: 1, Data: iter.Value()}), nil); err != nil {
+			return err
+		}
+		if batch.Len() >= maxBatchSize {
+			if err := batch.Set(sysValueMigration, iter.Key(), nil); err != nil {
+				return err
+			}
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return err
+			}
+			_ = batch.Close()
+			batch = db.NewBatch()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if err := batch.Delete(sysValueMigration, nil); err != nil {
+		return err
+	}
+	if err := batch.Set(sysValueVersion, []byte{key.LatestValueVersion}, nil); err != nil {
+		return err
+	}
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		return err
+	}
+	// WAL is disabled, flush to persist the migration.
+	return db.Flush()
+}
+
+// migrateHistory initializes the compact revision of the tables created prior to keeping the history of the keys.
+// The versions of the keys superseded before are unknown, so the table could not be read at any older revision.
+func migrateHistory(db *pebble.DB) error {
+	_, closer, err := db.Get(sysCompactRevision)
+	if err == nil {
+		return closer.Close()
+	}
+	if !errors.Is(err, pebble.ErrNotFound) {
+		return err
+	}
+	rev, err := currentRevision(db)
+	if err != nil {
+		return err
+	}
+	if err := db.Set(sysCompactRevision, binary.LittleEndian.AppendUint64(nil, rev), pebble.NoSync); err != nil {
+		return err
+	}
+	// WAL is disabled, flush to persist the migration.
+	return db.Flush()
+}
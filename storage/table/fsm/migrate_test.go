@@ -0,0 +1,143 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateValues(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	keys := []string{"key_1", "key_2", "key_3"}
+	for _, k := range keys {
+		r.NoError(db.Set(mustEncodeKey(key.Key{KeyType: key.TypeUser, Key: []byte(k)}), []byte("value"), pebble.NoSync))
+	}
+	r.NoError(db.Set(sysLocalIndex, binary.LittleEndian.AppendUint64(nil, 42), pebble.NoSync))
+
+	ver, err := readValueVersion(db)
+	r.NoError(err)
+	r.Equal(uint8(key.UnknownValueVersion), ver)
+
+	r.NoError(migrateValues(db))
+	// Migration must be idempotent.
+	r.NoError(migrateValues(db))
+
+	ver, err = readValueVersion(db)
+	r.NoError(err)
+	r.Equal(key.LatestValueVersion, ver)
+
+	iter := db.NewIter(allUserKeysOpts())
+	i := 0
+	k := &key.Key{}
+	for iter.First(); iter.Valid(); iter.Next() {
+		decodeKey(t, iter, k)
+		r.Equal(keys[i], string(k.Key))
+		r.Equal(key.Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: []byte("value")}, decodeValue(t, iter))
+		i++
+	}
+	r.Equal(len(keys), i)
+	r.NoError(iter.Close())
+
+	// System keys are left untouched.
+	idx, err := readLocalIndex(db, sysLocalIndex)
+	r.NoError(err)
+	r.Equal(uint64(42), idx)
+	_, _, err = db.Get(sysValueMigration)
+	r.ErrorIs(err, pebble.ErrNotFound)
+}
+
+func TestMigrateValues_Replicas(t *testing.T) {
+	r := require.New(t)
+	// The replicas are migrated at different applied indexes.
+	for _, idx := range []uint64{5, 42} {
+		db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+		r.NoError(err)
+
+		k := mustEncodeKey(key.Key{KeyType: key.TypeUser, Key: []byte("key_1")})
+		r.NoError(db.Set(k, []byte("value"), pebble.NoSync))
+		r.NoError(db.Set(sysLocalIndex, binary.LittleEndian.AppendUint64(nil, idx), pebble.NoSync))
+		r.NoError(db.Set(sysLeaderIndex, binary.LittleEndian.AppendUint64(nil, idx*2), pebble.NoSync))
+
+		r.NoError(migrateValues(db))
+
+		value, err := readUserValue(db, []byte("key_1"))
+		r.NoError(err)
+		r.Equal(&key.Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: []byte("value")}, value)
+
+		// The migrated keys are not mistaken for missing keys.
+		ok, err := txnCompare(db, []*regattapb.Compare{{Key: []byte("key_1"), Target: regattapb.Compare_CREATE, TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0}}})
+		r.NoError(err)
+		r.False(ok)
+		r.NoError(db.Close())
+	}
+}
+
+func TestMigrateValues_Resume(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	// Simulate an interrupted migration with the first key already migrated.
+	migrated := mustEncodeKey(key.Key{KeyType: key.TypeUser, Key: []byte("key_1")})
+	r.NoError(db.Set(migrated, key.EncodeValue(nil, key.Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: []byte("value")}), pebble.NoSync))
+	r.NoError(db.Set(mustEncodeKey(key.Key{KeyType: key.TypeUser, Key: []byte("key_2")}), []byte("value"), pebble.NoSync))
+	r.NoError(db.Set(sysValueMigration, migrated, pebble.NoSync))
+
+	r.NoError(migrateValues(db))
+
+	iter := db.NewIter(allUserKeysOpts())
+	i := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		r.Equal(key.Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: []byte("value")}, decodeValue(t, iter))
+		i++
+	}
+	r.Equal(2, i)
+	r.NoError(iter.Close())
+}
+
+func TestMigrateValues_UnknownVersion(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	r.NoError(db.Set(sysValueVersion, []byte{key.LatestValueVersion + 1}, pebble.NoSync))
+	r.ErrorIs(migrateValues(db), key.ErrUnknownValueVersion)
+}
+
+func TestMigrateValues_OlderVersion(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	v1 := key.EncodeValue(nil, key.Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: []byte("value")})
+	k := mustEncodeKey(key.Key{KeyType: key.TypeUser, Key: []byte("key_1")})
+	r.NoError(db.Set(k, v1, pebble.NoSync))
+	r.NoError(db.Set(sysValueVersion, []byte{key.ValueV1}, pebble.NoSync))
+
+	r.NoError(migrateValues(db))
+
+	ver, err := readValueVersion(db)
+	r.NoError(err)
+	r.Equal(key.LatestValueVersion, ver)
+
+	// Values are left intact.
+	raw, closer, err := db.Get(k)
+	r.NoError(err)
+	r.Equal(v1, raw)
+	r.NoError(closer.Close())
+}
@@ -3,9 +3,12 @@
 package fsm
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"io"
+	"slices"
+	"sort"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
@@ -13,7 +16,13 @@ import (
 	sm "github.com/lni/dragonboat/v4/statemachine"
 )
 
-const maxRangeSize uint64 = (4 * 1024 * 1024) - 1024 // 4MiB - 1KiB sentinel.
+const (
+	maxRangeSize uint64 = (4 * 1024 * 1024) - 1024 // 4MiB - 1KiB sentinel.
+	// DefaultCursorBatchSize default size of a single batch streamed by the cursor.
+	DefaultCursorBatchSize uint64 = 512 * 1024
+	// maxKeyValueOverhead upper bound of the proto encoding overhead of a single proto.KeyValue within the response.
+	maxKeyValueOverhead uint64 = 48
+)
 
 func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc <-chan struct{}) (uint64, error) {
 	iter := reader.NewIter(nil)
@@ -34,14 +43,31 @@ func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc
 			if err != nil {
 				return 0, err
 			}
-			if k.KeyType == key.TypeUser {
-				buffer, err = writeCommand(tableName, k.Key, iter.Value(), buffer)
+			switch {
+			// The history of the keys (V2 keys) is not a part of the snapshot.
+			case k.KeyType == key.TypeUser && k.Version() == key.V1:
+				val, err := key.DecodeValue(iter.Value())
 				if err != nil {
 					return 0, err
 				}
-				if _, err := w.Write(buffer); err != nil {
+				buffer, err = writeCommand(tableName, k.Key, val, buffer)
+				if err != nil {
 					return 0, err
 				}
+			case bytes.HasPrefix(iter.Key(), sysLeasePrefix):
+				lease := &regattapb.Lease{}
+				if err := lease.UnmarshalVT(iter.Value()); err != nil {
+					return 0, err
+				}
+				buffer, err = writeLeaseCommand(tableName, lease, buffer)
+				if err != nil {
+					return 0, err
+				}
+			default:
+				continue
+			}
+			if _, err := w.Write(buffer); err != nil {
+				return 0, err
 			}
 		}
 	}
@@ -49,15 +75,33 @@ func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc
 }
 
 // writeCommand writes KV pair as PUT proto.Command into (optionally provided) buffer.
-func writeCommand(tableName string, key []byte, val []byte, buffer []byte) ([]byte, error) {
+func writeCommand(tableName string, k []byte, val key.Value, buffer []byte) ([]byte, error) {
 	cmd := regattapb.CommandFromVTPool()
 	defer cmd.ReturnToVTPool()
 	cmd.Table = []byte(tableName)
 	cmd.Type = regattapb.Command_PUT
 	cmd.Kv = &regattapb.KeyValue{
-		Key:   key,
-		Value: val,
+		Key:            k,
+		Value:          val.Data,
+		CreateRevision: val.CreateRevision,
+		ModRevision:    val.ModRevision,
+		Version:        val.Version,
+		Lease:          val.Lease,
 	}
+	return marshalCommand(cmd, buffer)
+}
+
+// writeLeaseCommand writes the lease as LEASE_GRANT proto.Command into (optionally provided) buffer.
+func writeLeaseCommand(tableName string, lease *regattapb.Lease, buffer []byte) ([]byte, error) {
+	cmd := regattapb.CommandFromVTPool()
+	defer cmd.ReturnToVTPool()
+	cmd.Table = []byte(tableName)
+	cmd.Type = regattapb.Command_LEASE_GRANT
+	cmd.Lease = lease
+	return marshalCommand(cmd, buffer)
+}
+
+func marshalCommand(cmd *regattapb.Command, buffer []byte) ([]byte, error) {
 	size := cmd.SizeVT()
 	if cap(buffer) < size {
 		buffer = make([]byte, size*2)
@@ -85,7 +129,40 @@ func readLocalIndex(db pebble.Reader, indexKey []byte) (idx uint64, err error) {
 	return binary.LittleEndian.Uint64(indexVal), nil
 }
 
+// txnLookup evaluates the read-only transaction, returns if the compare succeeded and the responses of the branch taken.
+func txnLookup(reader pebble.Reader, compare []*regattapb.Compare, success, failure []*regattapb.RequestOp) (bool, []*regattapb.ResponseOp, error) {
+	ok, err := txnCompare(reader, compare)
+	if err != nil {
+		return false, nil, err
+	}
+	ops := failure
+	if ok {
+		ops = success
+	}
+	var responses []*regattapb.ResponseOp
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+			rr, err := lookup(reader, o.RequestRange)
+			if err != nil {
+				return false, nil, err
+			}
+			responses = append(responses, wrapResponseOp(rr))
+		case *regattapb.RequestOp_RequestTxn:
+			succ, rr, err := txnLookup(reader, o.RequestTxn.Compare, o.RequestTxn.Success, o.RequestTxn.Failure)
+			if err != nil {
+				return false, nil, err
+			}
+			responses = append(responses, wrapResponseOp(&regattapb.ResponseOp_Txn{Succeeded: succ, Responses: rr}))
+		}
+	}
+	return ok, responses, nil
+}
+
 func lookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.ResponseOp_Range, error) {
+	if req.Revision > 0 {
+		return historicalLookup(reader, req)
+	}
 	if req.RangeEnd != nil {
 		return rangeLookup(reader, req)
 	}
@@ -102,7 +179,98 @@ func rangeLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regatta
 		_ = iter.Close()
 	}()
 	fill, sf := iterFuncsFromReq(req)
-	return iterate(iter, int(req.Limit), fill, sf)
+	if sortInMemory(req) {
+		return sortedIterate(iter, req, revisionFilter(req), fill, sf)
+	}
+	return iterate(iter, int(req.Limit), isReverse(req), revisionFilter(req), fill, sf)
+}
+
+// isReverse reports whether the range is iterated in the descending order of the keys.
+func isReverse(req *regattapb.RequestOp_Range) bool {
+	return req.SortOrder == regattapb.RequestOp_Range_DESCEND && req.SortTarget == regattapb.RequestOp_Range_KEY
+}
+
+// sortInMemory reports whether the range must be sorted in memory as it is not sorted by the key.
+// Counting does not depend on the order, so it is never sorted.
+func sortInMemory(req *regattapb.RequestOp_Range) bool {
+	return req.SortTarget != regattapb.RequestOp_Range_KEY && !req.CountOnly
+}
+
+// sortedEntry is the copy of the key/value pair read from the iterator.
+type sortedEntry struct {
+	key   []byte
+	value key.Value
+}
+
+// sortedIterate reads the whole range accepted by the (optional) filter, sorts it by the target of the request
+// and fills the proto.RangeResponse until the limit is reached.
+func sortedIterate(iter *pebble.Iterator, req *regattapb.RequestOp_Range, filter filterFunc, f fillEntriesFunc, s sizeEntriesFunc) (*regattapb.ResponseOp_Range, error) {
+	var entries []sortedEntry
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, err := key.DecodeBytes(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := key.DecodeValue(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil && !filter(value) {
+			continue
+		}
+		value.Data = bytes.Clone(value.Data)
+		entries = append(entries, sortedEntry{key: bytes.Clone(k.Key), value: value})
+	}
+	sortEntries(entries, req)
+	return fillEntries(entries, int(req.Limit), f, s), nil
+}
+
+// sortEntries sorts the entries read in the ascending order of the keys by the target and the order of the request.
+// Entries with equal targets are ordered by the key.
+func sortEntries(entries []sortedEntry, req *regattapb.RequestOp_Range) {
+	if isReverse(req) {
+		slices.Reverse(entries)
+		return
+	}
+	if req.SortTarget == regattapb.RequestOp_Range_KEY {
+		return
+	}
+	cmp := sortCompareFunc(req.SortTarget)
+	descend := req.SortOrder == regattapb.RequestOp_Range_DESCEND
+	sort.SliceStable(entries, func(i, j int) bool {
+		if descend {
+			return cmp(entries[i].value, entries[j].value) > 0
+		}
+		return cmp(entries[i].value, entries[j].value) < 0
+	})
+}
+
+// fillEntries fills the proto.RangeResponse with the entries until the limit is reached.
+func fillEntries(entries []sortedEntry, limit int, f fillEntriesFunc, s sizeEntriesFunc) *regattapb.ResponseOp_Range {
+	response := &regattapb.ResponseOp_Range{}
+	for i, e := range entries {
+		if i == limit && limit != 0 || (uint64(response.SizeVT())+s(e.key, e.value)) >= maxRangeSize {
+			response.More = true
+			break
+		}
+		f(e.key, e.value, response)
+	}
+	return response
+}
+
+// sortCompareFunc returns the function comparing the sort target of the values.
+func sortCompareFunc(target regattapb.RequestOp_Range_SortTarget) func(a, b key.Value) int {
+	switch target {
+	case regattapb.RequestOp_Range_VERSION:
+		return func(a, b key.Value) int { return cmpInt64(a.Version, b.Version) }
+	case regattapb.RequestOp_Range_CREATE:
+		return func(a, b key.Value) int { return cmpInt64(a.CreateRevision, b.CreateRevision) }
+	case regattapb.RequestOp_Range_MOD:
+		return func(a, b key.Value) int { return cmpInt64(a.ModRevision, b.ModRevision) }
+	case regattapb.RequestOp_Range_VALUE:
+		return func(a, b key.Value) int { return bytes.Compare(a.Data, b.Data) }
+	}
+	return func(a, b key.Value) int { return 0 }
 }
 
 func iterFuncsFromReq(req *regattapb.RequestOp_Range) (fillEntriesFunc, sizeEntriesFunc) {
@@ -116,6 +284,29 @@ func iterFuncsFromReq(req *regattapb.RequestOp_Range) (fillEntriesFunc, sizeEntr
 	}
 }
 
+// filterFunc reports whether the value should be included in the proto.RangeResponse.
+type filterFunc func(value key.Value) bool
+
+// revisionFilter returns filterFunc applying the revision bounds of the request, nil is returned if no bounds are set.
+func revisionFilter(req *regattapb.RequestOp_Range) filterFunc {
+	if req.MinModRevision == 0 && req.MaxModRevision == 0 && req.MinCreateRevision == 0 && req.MaxCreateRevision == 0 {
+		return nil
+	}
+	return func(value key.Value) bool {
+		switch {
+		case req.MinModRevision != 0 && value.ModRevision < req.MinModRevision:
+			return false
+		case req.MaxModRevision != 0 && value.ModRevision > req.MaxModRevision:
+			return false
+		case req.MinCreateRevision != 0 && value.CreateRevision < req.MinCreateRevision:
+			return false
+		case req.MaxCreateRevision != 0 && value.CreateRevision > req.MaxCreateRevision:
+			return false
+		}
+		return true
+	}
+}
+
 func singleLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.ResponseOp_Range, error) {
 	keyBuf := bufferPool.Get()
 	defer bufferPool.Put(keyBuf)
@@ -134,16 +325,17 @@ func singleLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regatt
 		return &regattapb.ResponseOp_Range{}, nil
 	}
 
-	kv := &regattapb.KeyValue{Key: req.Key}
-	value := iter.Value()
-	if !(req.KeysOnly || req.CountOnly) && len(value) > 0 {
-		kv.Value = make([]byte, len(value))
-		copy(kv.Value, value)
+	value, err := key.DecodeValue(iter.Value())
+	if err != nil {
+		return nil, err
+	}
+	if filter := revisionFilter(req); filter != nil && !filter(value) {
+		return &regattapb.ResponseOp_Range{}, nil
 	}
 
 	var kvs []*regattapb.KeyValue
 	if !req.CountOnly {
-		kvs = append(kvs, kv)
+		kvs = append(kvs, keyValue(req.Key, value, req.KeysOnly))
 	}
 
 	return &regattapb.ResponseOp_Range{
@@ -152,67 +344,155 @@ func singleLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regatt
 	}, nil
 }
 
+// keyValue creates the proto.KeyValue from the decoded value, both the key and the value data are copied.
+func keyValue(k []byte, value key.Value, keyOnly bool) *regattapb.KeyValue {
+	kv := &regattapb.KeyValue{
+		Key:            make([]byte, len(k)),
+		CreateRevision: value.CreateRevision,
+		ModRevision:    value.ModRevision,
+		Version:        value.Version,
+		Lease:          value.Lease,
+	}
+	copy(kv.Key, k)
+	if !keyOnly && len(value.Data) > 0 {
+		kv.Value = make([]byte, len(value.Data))
+		copy(kv.Value, value.Data)
+	}
+	return kv
+}
+
 // fillEntriesFunc fills proto.RangeResponse response.
-type fillEntriesFunc func(key, value []byte, response *regattapb.ResponseOp_Range)
+type fillEntriesFunc func(k []byte, value key.Value, response *regattapb.ResponseOp_Range)
 
 // sizeEntriesFunc estimates entry size.
-type sizeEntriesFunc func(key, value []byte) uint64
-
-// iterate until the provided pebble.Iterator is no longer valid or the limit is reached.
-// Apply a function on the key/value pair in every iteration filling proto.RangeResponse.
-func iterate(iter *pebble.Iterator, limit int, f fillEntriesFunc, s sizeEntriesFunc) (*regattapb.ResponseOp_Range, error) {
+type sizeEntriesFunc func(k []byte, value key.Value) uint64
+
+// iterate until the provided pebble.Iterator is no longer valid or the limit is reached, the iterator is walked backwards if reverse is set.
+// Apply a function on the key/value pair accepted by the (optional) filter in every iteration filling proto.RangeResponse.
+func iterate(iter *pebble.Iterator, limit int, reverse bool, filter filterFunc, f fillEntriesFunc, s sizeEntriesFunc) (*regattapb.ResponseOp_Range, error) {
+	first, next := iter.First, iter.Next
+	if reverse {
+		first, next = iter.Last, iter.Prev
+	}
 	response := &regattapb.ResponseOp_Range{}
 	i := 0
-	for iter.First(); iter.Valid(); iter.Next() {
+	for first(); iter.Valid(); next() {
 		k, err := key.DecodeBytes(iter.Key())
 		if err != nil {
 			return nil, err
 		}
+		value, err := key.DecodeValue(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil && !filter(value) {
+			continue
+		}
 
-		if i == limit && limit != 0 || (uint64(response.SizeVT())+s(k.Key, iter.Value())) >= maxRangeSize {
-			response.More = iter.Next()
+		if i == limit && limit != 0 || (uint64(response.SizeVT())+s(k.Key, value)) >= maxRangeSize {
+			// When filtering, the current entry already matched the filter but did not fit into the response.
+			response.More = filter != nil || next()
 			break
 		}
 		i++
-		f(k.Key, iter.Value(), response)
+		f(k.Key, value, response)
 	}
 	return response, nil
 }
 
+// cursor streams the result of the Range query in batches of at most batchSize bytes to the consumer.
+// Every batch except the last one has the More flag set, the stream is interrupted once the stopc is closed.
+func cursor(reader pebble.Reader, req *regattapb.RequestOp_Range, batchSize uint64, consumer func(*regattapb.ResponseOp_Range) error, stopc <-chan struct{}) error {
+	if req.Revision > 0 {
+		return historicalCursor(reader, req, batchSize, consumer, stopc)
+	}
+	if req.RangeEnd == nil {
+		response, err := singleLookup(reader, req)
+		if err != nil {
+			return err
+		}
+		return consumer(response)
+	}
+
+	opts, err := iterOptionsForBounds(req.Key, req.RangeEnd)
+	if err != nil {
+		return err
+	}
+	iter := reader.NewIter(opts)
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	if batchSize == 0 {
+		batchSize = DefaultCursorBatchSize
+	}
+	fill, sf := iterFuncsFromReq(req)
+	filter := revisionFilter(req)
+	first, next := iter.First, iter.Next
+	if isReverse(req) {
+		first, next = iter.Last, iter.Prev
+	}
+	response := &regattapb.ResponseOp_Range{}
+	i := 0
+	for first(); iter.Valid() && (req.Limit == 0 || i < int(req.Limit)); next() {
+		select {
+		case <-stopc:
+			return sm.ErrSnapshotStopped
+		default:
+		}
+		k, err := key.DecodeBytes(iter.Key())
+		if err != nil {
+			return err
+		}
+		value, err := key.DecodeValue(iter.Value())
+		if err != nil {
+			return err
+		}
+		if filter != nil && !filter(value) {
+			continue
+		}
+		if len(response.Kvs) > 0 && uint64(response.SizeVT())+sf(k.Key, value)+maxKeyValueOverhead > batchSize {
+			response.More = true
+			if err := consumer(response); err != nil {
+				return err
+			}
+			response = &regattapb.ResponseOp_Range{}
+		}
+		i++
+		fill(k.Key, value, response)
+	}
+	return consumer(response)
+}
+
 // addKVPair adds a key/value pair from the provided iterator to the proto.RangeResponse.
-func addKVPair(key, value []byte, response *regattapb.ResponseOp_Range) {
-	kv := &regattapb.KeyValue{Key: make([]byte, len(key)), Value: make([]byte, len(value))}
-	copy(kv.Key, key)
-	copy(kv.Value, value)
-	response.Kvs = append(response.Kvs, kv)
+func addKVPair(k []byte, value key.Value, response *regattapb.ResponseOp_Range) {
+	response.Kvs = append(response.Kvs, keyValue(k, value, false))
 	response.Count++
 }
 
 // sizeKVPair takes the full pair size into consideration.
-func sizeKVPair(key, value []byte) uint64 {
-	return uint64(len(key) + len(value))
+func sizeKVPair(k []byte, value key.Value) uint64 {
+	return uint64(len(k) + len(value.Data))
 }
 
 // addKeyOnly adds a key from the provided iterator to the proto.RangeResponse.
-func addKeyOnly(key, _ []byte, response *regattapb.ResponseOp_Range) {
-	kv := &regattapb.KeyValue{Key: make([]byte, len(key))}
-	copy(kv.Key, key)
-	response.Kvs = append(response.Kvs, kv)
+func addKeyOnly(k []byte, value key.Value, response *regattapb.ResponseOp_Range) {
+	response.Kvs = append(response.Kvs, keyValue(k, value, true))
 	response.Count++
 }
 
 // sizeKeyOnly takes only the key into consideration.
-func sizeKeyOnly(key, _ []byte) uint64 {
-	return uint64(len(key))
+func sizeKeyOnly(k []byte, _ key.Value) uint64 {
+	return uint64(len(k))
 }
 
 // addCountOnly increments number of keys from the provided iterator to the proto.RangeResponse.
-func addCountOnly(_, _ []byte, response *regattapb.ResponseOp_Range) {
+func addCountOnly(_ []byte, _ key.Value, response *regattapb.ResponseOp_Range) {
 	response.Count++
 }
 
 // sizeCountOnly for count the size remains constant.
-func sizeCountOnly(_, _ []byte) uint64 {
+func sizeCountOnly(_ []byte, _ key.Value) uint64 {
 	return uint64(0)
 }
 
@@ -225,6 +505,49 @@ type SnapshotRequest struct {
 // SnapshotResponse returns local index to which the snapshot was created.
 type SnapshotResponse struct {
 	Index uint64
+	// LeaderIndex the leader index of the snapshot, set only in the tables replicated from the leader cluster.
+	LeaderIndex uint64
+	// Revision the revision the local commands following the snapshot continue from.
+	Revision uint64
+	// LeaderRevision the revision at the leader index of the snapshot, set only in the tables replicated from the leader cluster.
+	LeaderRevision uint64
+}
+
+// CursorRequest to stream the result of the Range query from a single snapshot through the Consumer.
+type CursorRequest struct {
+	RangeOp *regattapb.RequestOp_Range
+	// BatchSize maximum size of a single batch in bytes, DefaultCursorBatchSize is used if not set.
+	BatchSize uint64
+	Consumer  func(*regattapb.ResponseOp_Range) error
+	Stopper   <-chan struct{}
+}
+
+// CursorResponse returns local index of the snapshot the cursor was served from.
+type CursorResponse struct {
+	Index uint64
+}
+
+// LeaseRequest to read the lease, optionally along with the keys attached to it.
+type LeaseRequest struct {
+	ID   int64
+	Keys bool
+}
+
+// LeaseResponse returns the lease, Lease is nil if the lease does not exist.
+type LeaseResponse struct {
+	Lease *regattapb.Lease
+	Keys  [][]byte
+}
+
+// ExpiredLeasesRequest to list the leases expired before Now (unix milliseconds), at most Limit leases are listed if set.
+type ExpiredLeasesRequest struct {
+	Now   int64
+	Limit int
+}
+
+// ExpiredLeasesResponse returns the expired leases.
+type ExpiredLeasesResponse struct {
+	Leases []*regattapb.Lease
 }
 
 // LocalIndexRequest to read local index.
@@ -233,6 +556,19 @@ type LocalIndexRequest struct{}
 // LeaderIndexRequest to read leader index.
 type LeaderIndexRequest struct{}
 
+// RevisionRequest to read the revision of the last applied command.
+type RevisionRequest struct{}
+
+// RevisionOffsetRequest to read the offsets of the revisions from the local and the leader index.
+type RevisionOffsetRequest struct{}
+
+// RevisionOffsetResponse returns the offsets of the revisions as of the local index.
+type RevisionOffsetResponse struct {
+	Index                uint64
+	RevisionOffset       uint64
+	LeaderRevisionOffset uint64
+}
+
 // IndexResponse returns local index.
 type IndexResponse struct {
 	Index uint64
@@ -54,8 +54,9 @@ func TestFSM_Lookup(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 0)),
-						Value: []byte(testValue),
+						Key:     []byte(fmt.Sprintf(testKeyFormat, 0)),
+						Value:   []byte(testValue),
+						Version: 1,
 					},
 				},
 				Count: 1,
@@ -73,8 +74,9 @@ func TestFSM_Lookup(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 				},
 				Count: 1,
@@ -92,7 +94,7 @@ func TestFSM_Lookup(t *testing.T) {
 
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
 				},
 				Count: 1,
 			},
@@ -111,6 +113,34 @@ func TestFSM_Lookup(t *testing.T) {
 				Count: 1,
 			},
 		},
+		{
+			name: "Lookup filtered out by MinModRevision",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
+				MinModRevision: 2,
+			},
+			want: &regattapb.ResponseOp_Range{},
+		},
+		{
+			name: "Lookup matching MaxCreateRevision",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:               []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
+				MaxCreateRevision: 1,
+				KeysOnly:          true,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Kvs: []*regattapb.KeyValue{
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1)), CreateRevision: 1, ModRevision: 1, Version: 1},
+				},
+				Count: 1,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -191,8 +221,9 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 					wrapResponseOp(&regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
 							{
-								Key:   []byte(fmt.Sprintf(testKeyFormat, 0)),
-								Value: []byte(testValue),
+								Key:     []byte(fmt.Sprintf(testKeyFormat, 0)),
+								Value:   []byte(testValue),
+								Version: 1,
 							},
 						},
 						Count: 1,
@@ -216,8 +247,9 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 					wrapResponseOp(&regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
 							{
-								Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-								Value: []byte(largeValues[0]),
+								Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+								Value:   []byte(largeValues[0]),
+								Version: 1,
 							},
 						},
 						Count: 1,
@@ -240,7 +272,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
-							{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
+							{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
 						},
 						Count: 1,
 					}),
@@ -340,6 +372,33 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				Succeeded: false,
 			},
 		},
+		{
+			name: "Lookup with nested Txn",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.TxnRequest{
+				Success: []*regattapb.RequestOp{
+					wrapRequestOp(&regattapb.Txn{
+						Compare: []*regattapb.Compare{{Key: []byte("nonsense")}},
+						Failure: []*regattapb.RequestOp{
+							wrapRequestOp(&regattapb.RequestOp_Range{Key: []byte(fmt.Sprintf(testKeyFormat, 0)), CountOnly: true}),
+						},
+					}),
+				},
+			},
+			want: &regattapb.TxnResponse{
+				Succeeded: true,
+				Responses: []*regattapb.ResponseOp{
+					wrapResponseOp(&regattapb.ResponseOp_Txn{
+						Succeeded: false,
+						Responses: []*regattapb.ResponseOp{
+							wrapResponseOp(&regattapb.ResponseOp_Range{Count: 1}),
+						},
+					}),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -378,6 +437,22 @@ func TestFSM_Lookup_Snapshot(t *testing.T) {
 		_, err := fsm.Lookup(SnapshotRequest{io.Discard, stopper})
 		require.ErrorIs(t, err, statemachine.ErrSnapshotStopped)
 	})
+	t.Run("leader index", func(t *testing.T) {
+		r := require.New(t)
+		p := emptySM()
+		defer p.Close()
+		leaderIndex := uint64(10)
+		_, err := p.Update([]statemachine.Entry{{Index: 1, Cmd: mustMarshallProto(&regattapb.Command{
+			LeaderIndex: &leaderIndex,
+			Table:       []byte("test"),
+			Type:        regattapb.Command_PUT,
+			Kv:          &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+		})}})
+		r.NoError(err)
+		res, err := p.Lookup(SnapshotRequest{io.Discard, make(<-chan struct{})})
+		r.NoError(err)
+		r.Equal(&SnapshotResponse{Index: 1, LeaderIndex: 10, Revision: 10, LeaderRevision: 10}, res)
+	})
 }
 
 func TestFSM_Lookup_Range(t *testing.T) {
@@ -405,12 +480,16 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
-						Value: []byte(largeValues[1]),
+						Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
+						Value:          []byte(largeValues[1]),
+						CreateRevision: 1,
+						ModRevision:    1,
+						Version:        1,
 					},
 				},
 				Count: 2,
@@ -429,8 +508,9 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 				},
 				Count: 1,
@@ -450,16 +530,23 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 0)),
-						Value: []byte(testValue),
+						Key:     []byte(fmt.Sprintf(testKeyFormat, 0)),
+						Value:   []byte(testValue),
+						Version: 1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 1)),
-						Value: []byte(testValue),
+						Key:            []byte(fmt.Sprintf(testKeyFormat, 1)),
+						Value:          []byte(testValue),
+						CreateRevision: 1,
+						ModRevision:    1,
+						Version:        1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 10)),
-						Value: []byte(testValue),
+						Key:            []byte(fmt.Sprintf(testKeyFormat, 10)),
+						Value:          []byte(testValue),
+						CreateRevision: 10,
+						ModRevision:    10,
+						Version:        1,
 					},
 				},
 				Count: 3,
@@ -479,16 +566,23 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
-						Value: []byte(largeValues[1]),
+						Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
+						Value:          []byte(largeValues[1]),
+						CreateRevision: 1,
+						ModRevision:    1,
+						Version:        1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 2)),
-						Value: []byte(largeValues[2]),
+						Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 2)),
+						Value:          []byte(largeValues[2]),
+						CreateRevision: 2,
+						ModRevision:    2,
+						Version:        1,
 					},
 				},
 				Count: 3,
@@ -522,9 +616,9 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			},
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2))},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1)), CreateRevision: 1, ModRevision: 1, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2)), CreateRevision: 2, ModRevision: 2, Version: 1},
 				},
 				Count: 3,
 				More:  true,
@@ -543,9 +637,9 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			},
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2))},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1)), CreateRevision: 1, ModRevision: 1, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2)), CreateRevision: 2, ModRevision: 2, Version: 1},
 				},
 				Count: 3,
 			},
@@ -621,6 +715,63 @@ func TestFSM_Lookup_Range(t *testing.T) {
 				Count: 10000,
 			},
 		},
+		{
+			name: "Range prefix lookup with mod revision bounds",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:            []byte("testlarge"),
+				RangeEnd:       incrementRightmostByte([]byte("testlarge")),
+				KeysOnly:       true,
+				MinModRevision: 3,
+				MaxModRevision: 5,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Kvs: []*regattapb.KeyValue{
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 3)), CreateRevision: 3, ModRevision: 3, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 4)), CreateRevision: 4, ModRevision: 4, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 5)), CreateRevision: 5, ModRevision: 5, Version: 1},
+				},
+				Count: 3,
+			},
+		},
+		{
+			name: "Range prefix lookup with mod revision bounds and Limit",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:            []byte("testlarge"),
+				RangeEnd:       incrementRightmostByte([]byte("testlarge")),
+				KeysOnly:       true,
+				MinModRevision: 8,
+				Limit:          1,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Kvs: []*regattapb.KeyValue{
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 8)), CreateRevision: 8, ModRevision: 8, Version: 1},
+				},
+				Count: 1,
+				More:  true,
+			},
+		},
+		{
+			name: "Range prefix lookup with CountOnly and create revision bounds",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:               []byte("testlarge"),
+				RangeEnd:          incrementRightmostByte([]byte("testlarge")),
+				CountOnly:         true,
+				MinCreateRevision: 2,
+				MaxCreateRevision: 6,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Count: 5,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -650,3 +801,239 @@ func TestFSM_Lookup_Range(t *testing.T) {
 		})
 	}
 }
+
+func TestFSM_Lookup_RangeSort(t *testing.T) {
+	p := emptySM()
+	defer p.Close()
+
+	// Keys "a".."d" with values sorted in the opposite order, the key "b" is modified last.
+	var entries []statemachine.Entry
+	for i, kv := range [][2]string{{"a", "4"}, {"b", "3"}, {"c", "2"}, {"d", "1"}, {"b", "0"}} {
+		entries = append(entries, statemachine.Entry{
+			Index: uint64(i + 1),
+			Cmd:   mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte(kv[0]), Value: []byte(kv[1])}}),
+		})
+	}
+	_, err := p.Update(entries)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		req      *regattapb.RequestOp_Range
+		wantKeys []string
+		wantMore bool
+	}{
+		{
+			name:     "ascending by key",
+			req:      &regattapb.RequestOp_Range{SortOrder: regattapb.RequestOp_Range_ASCEND},
+			wantKeys: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "descending by key",
+			req:      &regattapb.RequestOp_Range{SortOrder: regattapb.RequestOp_Range_DESCEND},
+			wantKeys: []string{"d", "c", "b", "a"},
+		},
+		{
+			name:     "descending by key with limit",
+			req:      &regattapb.RequestOp_Range{SortOrder: regattapb.RequestOp_Range_DESCEND, Limit: 2},
+			wantKeys: []string{"d", "c"},
+			wantMore: true,
+		},
+		{
+			name:     "descending by key with filter",
+			req:      &regattapb.RequestOp_Range{SortOrder: regattapb.RequestOp_Range_DESCEND, MaxModRevision: 3},
+			wantKeys: []string{"c", "a"},
+		},
+		{
+			name:     "by value",
+			req:      &regattapb.RequestOp_Range{SortTarget: regattapb.RequestOp_Range_VALUE},
+			wantKeys: []string{"b", "d", "c", "a"},
+		},
+		{
+			name:     "descending by mod revision with limit",
+			req:      &regattapb.RequestOp_Range{SortTarget: regattapb.RequestOp_Range_MOD, SortOrder: regattapb.RequestOp_Range_DESCEND, Limit: 2},
+			wantKeys: []string{"b", "d"},
+			wantMore: true,
+		},
+		{
+			name:     "descending by version keeps the key order of ties",
+			req:      &regattapb.RequestOp_Range{SortTarget: regattapb.RequestOp_Range_VERSION, SortOrder: regattapb.RequestOp_Range_DESCEND},
+			wantKeys: []string{"b", "a", "c", "d"},
+		},
+		{
+			name:     "ascending by create revision",
+			req:      &regattapb.RequestOp_Range{SortTarget: regattapb.RequestOp_Range_CREATE, KeysOnly: true},
+			wantKeys: []string{"a", "b", "c", "d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			tt.req.Key = []byte{0}
+			tt.req.RangeEnd = []byte{0}
+			got, err := p.Lookup(tt.req)
+			r.NoError(err)
+			response := got.(*regattapb.ResponseOp_Range)
+			var keys []string
+			for _, kv := range response.Kvs {
+				keys = append(keys, string(kv.Key))
+			}
+			r.Equal(tt.wantKeys, keys)
+			r.Equal(int64(len(tt.wantKeys)), response.Count)
+			r.Equal(tt.wantMore, response.More)
+		})
+	}
+
+	t.Run("cursor descending by key", func(t *testing.T) {
+		r := require.New(t)
+		var keys []string
+		_, err := p.Lookup(CursorRequest{
+			RangeOp:   &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, SortOrder: regattapb.RequestOp_Range_DESCEND, Limit: 3},
+			BatchSize: 1,
+			Consumer: func(response *regattapb.ResponseOp_Range) error {
+				for _, kv := range response.Kvs {
+					keys = append(keys, string(kv.Key))
+				}
+				return nil
+			},
+			Stopper: make(<-chan struct{}),
+		})
+		r.NoError(err)
+		r.Equal([]string{"d", "c", "b"}, keys)
+	})
+}
+
+func TestFSM_Lookup_Cursor(t *testing.T) {
+	p := filledSM()
+	defer p.Close()
+
+	collect := func(t *testing.T, req *regattapb.RequestOp_Range, batchSize uint64) []*regattapb.ResponseOp_Range {
+		var batches []*regattapb.ResponseOp_Range
+		_, err := p.Lookup(CursorRequest{
+			RangeOp:   req,
+			BatchSize: batchSize,
+			Consumer: func(response *regattapb.ResponseOp_Range) error {
+				batches = append(batches, response)
+				return nil
+			},
+			Stopper: make(<-chan struct{}),
+		})
+		require.NoError(t, err)
+		return batches
+	}
+
+	t.Run("all keys in batches", func(t *testing.T) {
+		r := require.New(t)
+		batches := collect(t, &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}}, 64*1024)
+		r.Greater(len(batches), 1)
+		total := int64(0)
+		for i, batch := range batches {
+			r.Equal(i != len(batches)-1, batch.More)
+			r.LessOrEqual(batch.SizeVT(), 64*1024)
+			r.Equal(int64(len(batch.Kvs)), batch.Count)
+			total += batch.Count
+		}
+		r.Equal(int64(smallEntries+largeEntries), total)
+	})
+
+	t.Run("keys only with limit", func(t *testing.T) {
+		r := require.New(t)
+		batches := collect(t, &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, KeysOnly: true, Limit: 100}, 128)
+		r.Greater(len(batches), 1)
+		total := int64(0)
+		for _, batch := range batches {
+			for _, kv := range batch.Kvs {
+				r.Nil(kv.Value)
+			}
+			total += batch.Count
+		}
+		r.Equal(int64(100), total)
+		r.False(batches[len(batches)-1].More)
+	})
+
+	t.Run("count only", func(t *testing.T) {
+		r := require.New(t)
+		batches := collect(t, &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, CountOnly: true}, 128)
+		r.Equal([]*regattapb.ResponseOp_Range{{Count: smallEntries + largeEntries}}, batches)
+	})
+
+	t.Run("single key", func(t *testing.T) {
+		r := require.New(t)
+		batches := collect(t, &regattapb.RequestOp_Range{Key: []byte(fmt.Sprintf(testKeyFormat, 0))}, 0)
+		r.Len(batches, 1)
+		r.Equal(int64(1), batches[0].Count)
+		r.False(batches[0].More)
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		r := require.New(t)
+		batches := collect(t, &regattapb.RequestOp_Range{Key: []byte("nonexistent"), RangeEnd: []byte("nonexistent1")}, 0)
+		r.Equal([]*regattapb.ResponseOp_Range{{}}, batches)
+	})
+
+	t.Run("consistent view", func(t *testing.T) {
+		r := require.New(t)
+		total := int64(0)
+		_, err := p.Lookup(CursorRequest{
+			RangeOp:   &regattapb.RequestOp_Range{Key: []byte("cursor"), RangeEnd: []byte("cursos")},
+			BatchSize: 64,
+			Consumer: func(response *regattapb.ResponseOp_Range) error {
+				total += response.Count
+				return nil
+			},
+			Stopper: make(<-chan struct{}),
+		})
+		r.NoError(err)
+		r.Equal(int64(0), total)
+
+		_, err = p.Update([]statemachine.Entry{
+			{Index: smallEntries + largeEntries, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("cursor1"), Value: []byte(testValue)}})},
+		})
+		r.NoError(err)
+
+		batches := 0
+		_, err = p.Lookup(CursorRequest{
+			RangeOp:   &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, KeysOnly: true},
+			BatchSize: 1024,
+			Consumer: func(response *regattapb.ResponseOp_Range) error {
+				if batches == 0 {
+					// Keys written while the cursor is open must not be visible to it.
+					_, err := p.Update([]statemachine.Entry{
+						{Index: smallEntries + largeEntries + 1, Cmd: mustMarshallProto(&regattapb.Command{Table: []byte(testTable), Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("cursor2"), Value: []byte(testValue)}})},
+					})
+					r.NoError(err)
+				}
+				batches++
+				total += response.Count
+				return nil
+			},
+			Stopper: make(<-chan struct{}),
+		})
+		r.NoError(err)
+		r.Equal(int64(smallEntries+largeEntries+1), total)
+	})
+
+	t.Run("consumer error", func(t *testing.T) {
+		consumerErr := fmt.Errorf("consumer error")
+		_, err := p.Lookup(CursorRequest{
+			RangeOp:   &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}},
+			BatchSize: 1024,
+			Consumer: func(response *regattapb.ResponseOp_Range) error {
+				return consumerErr
+			},
+			Stopper: make(<-chan struct{}),
+		})
+		require.ErrorIs(t, err, consumerErr)
+	})
+
+	t.Run("stop by chan", func(t *testing.T) {
+		stopper := make(chan struct{})
+		close(stopper)
+		_, err := p.Lookup(CursorRequest{
+			RangeOp:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}},
+			Consumer: func(response *regattapb.ResponseOp_Range) error { return nil },
+			Stopper:  stopper,
+		})
+		require.ErrorIs(t, err, statemachine.ErrSnapshotStopped)
+	})
+}
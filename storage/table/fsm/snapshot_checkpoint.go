@@ -165,10 +165,19 @@ func (c *checkpoint) recover(r io.Reader, stopc <-chan struct{}) error {
 	if err != nil {
 		return err
 	}
+	if err := migrateValues(db); err != nil {
+		return err
+	}
+	if err := migrateHistory(db); err != nil {
+		return err
+	}
 	idx, err := readLocalIndex(db, sysLocalIndex)
 	if err != nil {
 		return err
 	}
+	if err := c.fsm.resetEvents(db); err != nil {
+		return err
+	}
 	if err := rp.SaveCurrentDBDirName(c.fsm.fs, c.fsm.dirname, randomDirName); err != nil {
 		return err
 	}
@@ -157,10 +157,19 @@ read:
 	if err := db.Ingest(files); err != nil {
 		return err
 	}
+	if err := migrateValues(db); err != nil {
+		return err
+	}
+	if err := migrateHistory(db); err != nil {
+		return err
+	}
 	idx, err := readLocalIndex(db, sysLocalIndex)
 	if err != nil {
 		return err
 	}
+	if err := s.fsm.resetEvents(db); err != nil {
+		return err
+	}
 	if err := rp.SaveCurrentDBDirName(s.fsm.fs, s.fsm.dirname, randomDirName); err != nil {
 		return err
 	}
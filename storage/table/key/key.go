@@ -53,12 +53,20 @@ type Key struct {
 	KeyType Type
 	// Key data part of a Key - supported since V1.
 	Key []byte
+	// Revision of the Key data - supported since V2.
+	Revision int64
+}
+
+// Version returns the version of the Key encoding.
+func (k *Key) Version() uint8 {
+	return k.version
 }
 
 func (k *Key) reset() {
 	k.version = UnknownVersion
 	k.KeyType = TypeUnknown
 	k.Key = k.Key[0:0]
+	k.Revision = 0
 }
 
 // Decoder the Key decoder.
@@ -97,6 +105,18 @@ func (d Decoder) Decode(key *Key) error {
 		key.Key = k.key
 		return nil
 	}
+	if header[keyVersionHeaderPos] == V2 {
+		k := keyV2{}
+		err := k.Decode(d.r)
+		if err != nil {
+			return err
+		}
+		key.version = V2
+		key.KeyType = k.keyType
+		key.Key = k.key
+		key.Revision = k.revision
+		return nil
+	}
 	return ErrUnknownKeyVersion
 }
 
@@ -131,10 +151,18 @@ func (e Encoder) Encode(key *Key) (int, error) {
 		}
 		return keyHeaderLen + n, nil
 	}
+	if key.version == V2 {
+		k := keyV2{keyType: key.KeyType, key: key.Key, revision: key.Revision}
+		n, err := k.Encode(e.w)
+		if err != nil {
+			return keyHeaderLen, err
+		}
+		return keyHeaderLen + n, nil
+	}
 	return 0, ErrUnknownKeyVersion
 }
 
-// DecodeBytes transforms raw bytes into a Key, input bytes are not copied.
+// DecodeBytes transforms raw bytes into a Key, input bytes are not copied unless the V2 key data contains escaped bytes.
 func DecodeBytes(raw []byte) (Key, error) {
 	if len(raw) < keyHeaderLen {
 		return Key{}, ErrMissingKeyHeader
@@ -147,5 +175,17 @@ func DecodeBytes(raw []byte) (Key, error) {
 			Key:     k.key,
 		}, nil
 	}
+	if raw[keyVersionHeaderPos] == V2 {
+		k, err := v2DecodeRaw(raw[keyHeaderLen:])
+		if err != nil {
+			return Key{}, err
+		}
+		return Key{
+			version:  V2,
+			KeyType:  k.keyType,
+			Key:      k.key,
+			Revision: k.revision,
+		}, nil
+	}
 	return Key{}, ErrUnknownKeyVersion
 }
@@ -0,0 +1,109 @@
+// Copyright JAMF Software, LLC
+
+package key
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrMalformedKey the key body is malformed.
+var ErrMalformedKey = errors.New("malformed key")
+
+// V2 key carries the revision suffix, the layout of the body is:
+// 0 key type
+// 1-n escaped key data, every 0x00 byte is followed by 0xFF
+// n-n+2 terminator 0x00 0x01
+// n+2-n+10 big-endian revision.
+// The escaping keeps the keys in the order of the key data first and the revision second,
+// so that all the revisions of the same key data are adjacent.
+const (
+	V2              uint8 = 2
+	v2Escape              = 0x00
+	v2Escaped             = 0xFF
+	v2Terminator          = 0x01
+	v2RevisionLen         = 8
+	keyV2BodyLen          = 1 + 2*V1KeyLen + 2 + v2RevisionLen
+	keyV2MinBodyLen       = 1 + 2 + v2RevisionLen
+)
+
+// NewRevisionKey constructs the V2 Key carrying the revision.
+func NewRevisionKey(keyType Type, key []byte, revision int64) Key {
+	return Key{version: V2, KeyType: keyType, Key: key, Revision: revision}
+}
+
+// AppendV2Prefix appends the encoded V2 key without the terminator and the revision to dst.
+// The prefix is lower or equal to the encoded V2 keys of the key data greater or equal to the key
+// and greater than the encoded V2 keys of all the lesser key data.
+func AppendV2Prefix(dst []byte, keyType Type, key []byte) []byte {
+	dst = append(dst, V2, 0x0, 0x0, 0x0, byte(keyType))
+	for _, b := range key {
+		dst = append(dst, b)
+		if b == v2Escape {
+			dst = append(dst, v2Escaped)
+		}
+	}
+	return dst
+}
+
+type keyV2 struct {
+	keyType  Type
+	key      []byte
+	revision int64
+}
+
+func (k *keyV2) Encode(writer io.Writer) (int, error) {
+	bts := AppendV2Prefix(make([]byte, 0, keyHeaderLen+keyV2MinBodyLen+len(k.key)), k.keyType, k.key)[keyHeaderLen:]
+	bts = append(bts, v2Escape, v2Terminator)
+	bts = binary.BigEndian.AppendUint64(bts, uint64(k.revision))
+	return writer.Write(bts)
+}
+
+func (k *keyV2) Decode(reader io.Reader) error {
+	bts, err := io.ReadAll(io.LimitReader(reader, int64(keyV2BodyLen)))
+	if err != nil {
+		return err
+	}
+	if len(bts) < 1 {
+		return ErrMissingKeyType
+	}
+	dk, err := v2DecodeRaw(bts)
+	if err != nil {
+		return err
+	}
+	*k = dk
+	return nil
+}
+
+// v2DecodeRaw decodes the key body, the key data is copied only if it contains escaped bytes.
+func v2DecodeRaw(raw []byte) (keyV2, error) {
+	if len(raw) < keyV2MinBodyLen {
+		return keyV2{}, ErrMalformedKey
+	}
+	body := raw[1 : len(raw)-v2RevisionLen]
+	if !bytes.HasSuffix(body, []byte{v2Escape, v2Terminator}) {
+		return keyV2{}, ErrMalformedKey
+	}
+	body = body[:len(body)-2]
+	k := keyV2{
+		keyType:  Type(raw[0]),
+		key:      body,
+		revision: int64(binary.BigEndian.Uint64(raw[len(raw)-v2RevisionLen:])),
+	}
+	if bytes.IndexByte(body, v2Escape) == -1 {
+		return k, nil
+	}
+	k.key = make([]byte, 0, len(body))
+	for i := 0; i < len(body); i++ {
+		k.key = append(k.key, body[i])
+		if body[i] == v2Escape {
+			if i+1 == len(body) || body[i+1] != v2Escaped {
+				return keyV2{}, ErrMalformedKey
+			}
+			i++
+		}
+	}
+	return k, nil
+}
@@ -0,0 +1,88 @@
+// Copyright JAMF Software, LLC
+
+package key
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeV2(t *testing.T, k Key) []byte {
+	buf := &bytes.Buffer{}
+	_, err := NewEncoder(buf).Encode(&k)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func Test_keyV2_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  Key
+	}{
+		{name: "Simple key", key: NewRevisionKey(TypeUser, []byte("test"), 10)},
+		{name: "Empty key", key: NewRevisionKey(TypeUser, []byte{}, 1)},
+		{name: "Key with zero bytes", key: NewRevisionKey(TypeUser, []byte{0x0, 't', 0x0, 0x0}, 1<<40)},
+		{name: "Key with escape bytes", key: NewRevisionKey(TypeSystem, []byte{0xFF, 0x0, 0x1, 0xFF}, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := DecodeBytes(encodeV2(t, tt.key))
+			r.NoError(err)
+			r.Equal(V2, got.Version())
+			r.Equal(tt.key.KeyType, got.KeyType)
+			r.Equal(tt.key.Key, got.Key)
+			r.Equal(tt.key.Revision, got.Revision)
+		})
+	}
+}
+
+func Test_keyV2_Order(t *testing.T) {
+	r := require.New(t)
+	// Keys ordered by the key data first and the revision second.
+	keys := []Key{
+		NewRevisionKey(TypeUser, []byte{}, 5),
+		NewRevisionKey(TypeUser, []byte{0x0}, 1),
+		NewRevisionKey(TypeUser, []byte{0x0}, 2),
+		NewRevisionKey(TypeUser, []byte{0x0, 0x0}, 1),
+		NewRevisionKey(TypeUser, []byte{0x0, 0x1}, 1),
+		NewRevisionKey(TypeUser, []byte("a"), 1),
+		NewRevisionKey(TypeUser, []byte("a"), 300),
+		NewRevisionKey(TypeUser, []byte("a\x00"), 1),
+		NewRevisionKey(TypeUser, []byte("ab"), 1),
+	}
+	encoded := make([][]byte, len(keys))
+	for i, k := range keys {
+		encoded[i] = encodeV2(t, k)
+	}
+	r.True(sort.SliceIsSorted(encoded, func(i, j int) bool {
+		return bytes.Compare(encoded[i], encoded[j]) < 0
+	}))
+
+	// The prefix bounds all the revisions of the key data and of the greater key data only.
+	prefix := AppendV2Prefix(nil, TypeUser, []byte("a"))
+	r.Less(bytes.Compare(encoded[4], prefix), 0)
+	r.GreaterOrEqual(bytes.Compare(encoded[5], prefix), 0)
+	r.Greater(bytes.Compare(encoded[8], prefix), 0)
+}
+
+func Test_keyV2_DecodeMalformed(t *testing.T) {
+	valid := encodeV2(t, NewRevisionKey(TypeUser, []byte{0x0, 't'}, 1))
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "Too short", raw: valid[:keyHeaderLen+keyV2MinBodyLen-1]},
+		{name: "Missing terminator", raw: append(append([]byte(nil), valid[:keyHeaderLen+4]...), make([]byte, v2RevisionLen)...)},
+		{name: "Unescaped zero byte", raw: append(append([]byte(nil), valid[:keyHeaderLen+1]...), append([]byte{0x0, 't', 0x0, 0x1}, make([]byte, v2RevisionLen)...)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeBytes(tt.raw)
+			require.Error(t, err)
+		})
+	}
+}
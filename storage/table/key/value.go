@@ -0,0 +1,100 @@
+// Copyright JAMF Software, LLC
+
+package key
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	valueHeaderLen        = 1
+	valueVersionHeaderPos = 0
+
+	// ValueV1 value version storing the create revision, mod revision and version of a key alongside the data.
+	ValueV1 uint8 = 1
+	// ValueV2 value version storing the lease of a key on top of the ValueV1 metadata, used only for the values with a lease attached.
+	ValueV2 uint8 = 2
+	// LatestValueVersion latest value version implemented.
+	LatestValueVersion = ValueV2
+	// UnknownValueVersion unknown value version (versions are numbered from 1, raw values written prior to versioning are version 0).
+	UnknownValueVersion = 0
+
+	valueV1MetaLen = 3 * 8
+	valueV2MetaLen = valueV1MetaLen + 8
+)
+
+var (
+	// ErrUnknownValueVersion value version is not implemented in this build.
+	ErrUnknownValueVersion = errors.New("unknown value version")
+	// ErrMalformedValue value is too short to contain the metadata of its version.
+	ErrMalformedValue = errors.New("malformed value")
+)
+
+// Value generic internal Value holding the user data along with the MVCC metadata of a key.
+type Value struct {
+	// CreateRevision revision of the last creation of the key.
+	CreateRevision int64
+	// ModRevision revision of the last modification of the key.
+	ModRevision int64
+	// Version number of modifications of the key since its creation.
+	Version int64
+	// Lease ID of the lease attached to the key, 0 if no lease is attached.
+	Lease int64
+	// Data user data part of a Value.
+	Data []byte
+}
+
+// ValueLen computes the length of an encoded Value without a lease.
+func ValueLen(dataLen int) int {
+	return valueHeaderLen + valueV1MetaLen + dataLen
+}
+
+// EncodeValue appends the encoded Value to dst and returns the extended buffer.
+// Values without a lease are encoded as ValueV1, values with a lease as ValueV2.
+func EncodeValue(dst []byte, v Value) []byte {
+	var meta [valueHeaderLen + valueV2MetaLen]byte
+	meta[valueVersionHeaderPos] = ValueV1
+	binary.LittleEndian.PutUint64(meta[1:9], uint64(v.CreateRevision))
+	binary.LittleEndian.PutUint64(meta[9:17], uint64(v.ModRevision))
+	binary.LittleEndian.PutUint64(meta[17:25], uint64(v.Version))
+	if v.Lease == 0 {
+		dst = append(dst, meta[:valueHeaderLen+valueV1MetaLen]...)
+		return append(dst, v.Data...)
+	}
+	meta[valueVersionHeaderPos] = ValueV2
+	binary.LittleEndian.PutUint64(meta[25:33], uint64(v.Lease))
+	dst = append(dst, meta[:]...)
+	return append(dst, v.Data...)
+}
+
+// DecodeValue transforms raw bytes into a Value, input bytes are not copied.
+func DecodeValue(raw []byte) (Value, error) {
+	if len(raw) < valueHeaderLen {
+		return Value{}, ErrMalformedValue
+	}
+	switch raw[valueVersionHeaderPos] {
+	case ValueV1:
+		if len(raw) < valueHeaderLen+valueV1MetaLen {
+			return Value{}, ErrMalformedValue
+		}
+		return Value{
+			CreateRevision: int64(binary.LittleEndian.Uint64(raw[1:9])),
+			ModRevision:    int64(binary.LittleEndian.Uint64(raw[9:17])),
+			Version:        int64(binary.LittleEndian.Uint64(raw[17:25])),
+			Data:           raw[valueHeaderLen+valueV1MetaLen:],
+		}, nil
+	case ValueV2:
+		if len(raw) < valueHeaderLen+valueV2MetaLen {
+			return Value{}, ErrMalformedValue
+		}
+		return Value{
+			CreateRevision: int64(binary.LittleEndian.Uint64(raw[1:9])),
+			ModRevision:    int64(binary.LittleEndian.Uint64(raw[9:17])),
+			Version:        int64(binary.LittleEndian.Uint64(raw[17:25])),
+			Lease:          int64(binary.LittleEndian.Uint64(raw[25:33])),
+			Data:           raw[valueHeaderLen+valueV2MetaLen:],
+		}, nil
+	}
+	return Value{}, ErrUnknownValueVersion
+}
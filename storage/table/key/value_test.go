@@ -0,0 +1,93 @@
+// Copyright JAMF Software, LLC
+
+package key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeValue(t *testing.T) {
+	r := require.New(t)
+	v := Value{CreateRevision: 1, ModRevision: 258, Version: 3, Data: []byte("test")}
+	encoded := EncodeValue(nil, v)
+	r.Equal(ValueLen(len(v.Data)), len(encoded))
+	r.Equal([]byte{
+		ValueV1,
+		0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		't', 'e', 's', 't',
+	}, encoded)
+
+	prefix := []byte("prefix")
+	r.Equal(append([]byte("prefix"), encoded...), EncodeValue(prefix, v))
+
+	v.Lease = 4
+	r.Equal([]byte{
+		ValueV2,
+		0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x4, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		't', 'e', 's', 't',
+	}, EncodeValue(nil, v))
+}
+
+func TestDecodeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []byte
+		want    Value
+		wantErr error
+	}{
+		{
+			name: "Decode - V1 value",
+			raw:  EncodeValue(nil, Value{CreateRevision: 1, ModRevision: 2, Version: 2, Data: []byte("test")}),
+			want: Value{CreateRevision: 1, ModRevision: 2, Version: 2, Data: []byte("test")},
+		},
+		{
+			name: "Decode - V1 empty value",
+			raw:  EncodeValue(nil, Value{CreateRevision: 1, ModRevision: 1, Version: 1}),
+			want: Value{CreateRevision: 1, ModRevision: 1, Version: 1, Data: []byte{}},
+		},
+		{
+			name: "Decode - V2 value",
+			raw:  EncodeValue(nil, Value{CreateRevision: 1, ModRevision: 2, Version: 2, Lease: 3, Data: []byte("test")}),
+			want: Value{CreateRevision: 1, ModRevision: 2, Version: 2, Lease: 3, Data: []byte("test")},
+		},
+		{
+			name:    "Decode - Truncated V2 metadata",
+			raw:     []byte{ValueV2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+			wantErr: ErrMalformedValue,
+		},
+		{
+			name:    "Decode - Missing header",
+			raw:     []byte{},
+			wantErr: ErrMalformedValue,
+		},
+		{
+			name:    "Decode - Truncated metadata",
+			raw:     []byte{ValueV1, 0x0, 0x0},
+			wantErr: ErrMalformedValue,
+		},
+		{
+			name:    "Decode - Unknown value version",
+			raw:     []byte{UnknownValueVersion, 't', 'e', 's', 't'},
+			wantErr: ErrUnknownValueVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := DecodeValue(tt.raw)
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/jamf/regatta/storage/kv"
 	"github.com/jamf/regatta/storage/table/fsm"
 	"github.com/lni/dragonboat/v4"
+	"github.com/lni/dragonboat/v4/client"
 	"github.com/lni/dragonboat/v4/config"
 	"go.uber.org/zap"
 )
@@ -38,6 +39,8 @@ const (
 	sequenceKey               = keyPrefix + "sys/idseq"
 	metaFSMClusterID          = 1000
 	tableIDsRangeStart uint64 = 10000
+	// maxIngestSize the upper bound of the size of the key values ingested by a single restored command.
+	maxIngestSize uint64 = 16 * 1024 * 1024
 )
 
 func NewManager(nh *dragonboat.NodeHost, members map[uint64]string, cfg Config) *Manager {
@@ -161,18 +164,32 @@ func (m *Manager) ReturnTable(name string) (bool, error) {
 }
 
 func (m *Manager) CreateTable(name string) error {
+	return m.CreateTableWithOverrides(name, nil)
+}
+
+// CreateTableWithOverrides creates the table with the TableConfig overrides specific to the table.
+func (m *Manager) CreateTableWithOverrides(name string, overrides *Overrides) error {
+	return m.create(Table{Name: name, Overrides: overrides})
+}
+
+// CreateLocalTable creates the table local to the follower cluster, the table is not replicated from the leader cluster.
+func (m *Manager) CreateLocalTable(name string, overrides *Overrides) error {
+	return m.create(Table{Name: name, Overrides: overrides, Local: true})
+}
+
+func (m *Manager) create(tab Table) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
-	created, err := m.createTable(name)
+	created, err := m.createTable(tab)
 	if err != nil {
 		return err
 	}
 
-	return m.startTable(created.Name, created.ClusterID)
+	return m.startTable(created, created.ClusterID)
 }
 
-func (m *Manager) createTable(name string) (Table, error) {
-	storeName := storedTableName(name)
+func (m *Manager) createTable(tab Table) (Table, error) {
+	storeName := storedTableName(tab.Name)
 	exists, err := m.store.Exists(storeName)
 	if err != nil {
 		return Table{}, err
@@ -184,10 +201,7 @@ func (m *Manager) createTable(name string) (Table, error) {
 	if err != nil {
 		return Table{}, err
 	}
-	tab := Table{
-		Name:      name,
-		ClusterID: seq,
-	}
+	tab.ClusterID = seq
 	err = m.setTableVersion(tab, 0)
 	if err != nil {
 		if errors.Is(err, kv.ErrVersionMismatch) {
@@ -201,13 +215,27 @@ func (m *Manager) createTable(name string) (Table, error) {
 func (m *Manager) DeleteTable(name string) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
-	storeName := storedTableName(name)
-	tab, err := m.store.Get(storeName)
+	_, ver, err := m.getTableVersion(name)
 	if err != nil {
 		return err
 	}
 
-	return m.store.Delete(storeName, tab.Ver)
+	return m.store.Delete(storedTableName(name), ver)
+}
+
+// MarkReplicated marks the table as replicated from the leader cluster.
+func (m *Manager) MarkReplicated(name string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	tab, ver, err := m.getTableVersion(name)
+	if err != nil {
+		return err
+	}
+	if tab.Replicated {
+		return nil
+	}
+	tab.Replicated = true
+	return m.setTableVersion(tab, ver)
 }
 
 func storedTableName(name string) string {
@@ -273,6 +301,12 @@ func (m *Manager) Start() error {
 				if ok {
 					go m.reconcileLoop()
 					go m.cleanupLoop()
+					if m.cfg.Table.LeaseExpiryInterval > 0 {
+						go m.expireLeasesLoop()
+					}
+					if m.cfg.Table.HistoryCompactionInterval > 0 {
+						go m.compactHistoryLoop()
+					}
 					close(m.readyChan)
 					return
 				}
@@ -343,7 +377,7 @@ func (m *Manager) reconcile() error {
 
 	start, stop := diffTables(tabs, nhi.ShardInfoList)
 	for id, tbl := range start {
-		err = m.startTable(tbl.Name, id)
+		err = m.startTable(tbl, id)
 		if err != nil {
 			return err
 		}
@@ -412,6 +446,93 @@ func (m *Manager) cleanup() error {
 	return nil
 }
 
+func (m *Manager) expireLeasesLoop() {
+	t := time.NewTicker(m.cfg.Table.LeaseExpiryInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.closed:
+			return
+		case <-t.C:
+			if err := m.expireLeases(); err != nil {
+				m.log.Errorf("lease expiry failed: %v", err)
+			}
+		}
+	}
+}
+
+// expireLeases revokes the expired leases of the tables this node is the Raft leader of.
+func (m *Manager) expireLeases() error {
+	tabs, err := m.GetTables()
+	if err != nil {
+		return err
+	}
+	for _, tab := range tabs {
+		leaderID, _, ok, err := m.nh.GetLeaderID(tab.ClusterID)
+		if err != nil || !ok || leaderID != m.cfg.NodeID {
+			continue
+		}
+		at := tab.AsActive(m.nh)
+		if err := func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Table.LeaseExpiryInterval)
+			defer cancel()
+			return at.ExpireLeases(ctx, time.Now())
+		}(); err != nil {
+			m.log.Warnf("[%d:%d] lease expiry failed: %v", tab.ClusterID, m.cfg.NodeID, err)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) compactHistoryLoop() {
+	t := time.NewTicker(m.cfg.Table.HistoryCompactionInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.closed:
+			return
+		case <-t.C:
+			if err := m.compactHistory(); err != nil {
+				m.log.Errorf("history compaction failed: %v", err)
+			}
+		}
+	}
+}
+
+// compactHistory compacts the history of the tables this node is the Raft leader of to their history retention.
+func (m *Manager) compactHistory() error {
+	tabs, err := m.GetTables()
+	if err != nil {
+		return err
+	}
+	for _, tab := range tabs {
+		retention := m.cfg.Table.withOverrides(tab.Overrides).HistoryRetention
+		if retention == 0 {
+			continue
+		}
+		leaderID, _, ok, err := m.nh.GetLeaderID(tab.ClusterID)
+		if err != nil || !ok || leaderID != m.cfg.NodeID {
+			continue
+		}
+		at := tab.AsActive(m.nh)
+		if err := func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Table.HistoryCompactionInterval)
+			defer cancel()
+			rev, err := at.Revision(ctx, false)
+			if err != nil || rev.Index <= retention {
+				return err
+			}
+			if err := at.Compact(ctx, int64(rev.Index-retention)); err != nil && !errors.Is(err, serrors.ErrCompacted) {
+				return err
+			}
+			return nil
+		}(); err != nil {
+			m.log.Warnf("[%d:%d] history compaction failed: %v", tab.ClusterID, m.cfg.NodeID, err)
+		}
+	}
+	return nil
+}
+
 func (m *Manager) incAndGetIDSeq() (uint64, error) {
 	seq, err := m.store.Get(sequenceKey)
 	if err != nil {
@@ -487,20 +608,22 @@ func diffTables(tables map[string]Table, raftInfo []dragonboat.ShardInfo) (toSta
 	return
 }
 
-func (m *Manager) startTable(name string, id uint64) error {
+func (m *Manager) startTable(tbl Table, id uint64) error {
+	cfg := m.cfg.Table.withOverrides(tbl.Overrides)
+	createFSM := fsm.New(tbl.Name, cfg.DataDir, cfg.FS, m.blockCache, m.tableCache, fsm.SnapshotRecoveryType(cfg.RecoveryType), m.cfg.EventSink, tbl.Overrides.dbOptions()...)
 	if m.nh.HasNodeInfo(id, m.cfg.NodeID) {
 		return m.nh.StartOnDiskReplica(
 			map[uint64]dragonboat.Target{},
 			false,
-			fsm.New(name, m.cfg.Table.DataDir, m.cfg.Table.FS, m.blockCache, m.tableCache, fsm.SnapshotRecoveryType(m.cfg.Table.RecoveryType)),
-			tableRaftConfig(m.cfg.NodeID, id, m.cfg.Table),
+			createFSM,
+			tableRaftConfig(m.cfg.NodeID, id, cfg),
 		)
 	}
 	return m.nh.StartOnDiskReplica(
 		m.members,
 		false,
-		fsm.New(name, m.cfg.Table.DataDir, m.cfg.Table.FS, m.blockCache, m.tableCache, fsm.SnapshotRecoveryType(m.cfg.Table.RecoveryType)),
-		tableRaftConfig(m.cfg.NodeID, id, m.cfg.Table),
+		createFSM,
+		tableRaftConfig(m.cfg.NodeID, id, cfg),
 	)
 }
 
@@ -580,7 +703,7 @@ func (m *Manager) Restore(name string, reader io.Reader) error {
 	tbl.Name = name
 	tbl.RecoverID = recoveryID
 
-	err = m.startTable(tbl.Name, tbl.RecoverID)
+	err = m.startTable(tbl, tbl.RecoverID)
 	if err != nil {
 		return err
 	}
@@ -595,7 +718,7 @@ func (m *Manager) Restore(name string, reader io.Reader) error {
 		return err
 	}
 
-	err = m.readIntoTable(tbl.RecoverID, reader)
+	err = m.readIntoTable(tbl.RecoverID, m.MaxInMemLogSize(tbl), reader)
 	if err != nil {
 		return err
 	}
@@ -641,9 +764,13 @@ func (m *Manager) setTableVersion(tbl Table, version uint64) error {
 	return nil
 }
 
-func (m *Manager) readIntoTable(id uint64, reader io.Reader) error {
-	backOff := backoff.NewExponentialBackOff()
-	backOff.MaxElapsedTime = 0
+// MaxInMemLogSize returns the target size of the in-memory Raft log of the table, the overrides of the table apply.
+func (m *Manager) MaxInMemLogSize(tbl Table) uint64 {
+	return m.cfg.Table.withOverrides(tbl.Overrides).MaxInMemLogSize
+}
+
+// readIntoTable proposes the restored commands, the proposals are kept within the half of the in-memory Raft log size.
+func (m *Manager) readIntoTable(id uint64, logSize uint64, reader io.Reader) error {
 	session := m.nh.GetNoOPSession(id)
 	msg := make([]byte, 1024*1024*4)
 
@@ -651,69 +778,118 @@ func (m *Manager) readIntoTable(id uint64, reader io.Reader) error {
 	batchCmd := &regattapb.Command{
 		Type: regattapb.Command_PUT_BATCH,
 	}
-	last := false
+	ingest := &fsm.IngestBuilder{}
+	batchSize := logSize / 2
+	// The size of the in-memory Raft log is not limited.
+	if logSize == 0 {
+		batchSize = maxIngestSize
+	}
+	ingestSize := int(min(batchSize, maxIngestSize))
 
 	estimatedSize := 0
 	for {
 		n, err := reader.Read(msg)
 		if err != nil {
 			if err == io.EOF {
-				last = true
-			} else {
-				return err
+				break
 			}
+			return err
 		}
 		estimatedSize += n
 
-		if !last {
-			cmd.Reset()
-			err = cmd.UnmarshalVT(msg[:n])
-			if err != nil {
+		cmd.Reset()
+		err = cmd.UnmarshalVT(msg[:n])
+		if err != nil {
+			return err
+		}
+
+		// Commands other than PUT (e.g. LEASE_GRANT) are proposed as they are, preserving the order of the stream.
+		if cmd.Type != regattapb.Command_PUT {
+			if err := m.proposeBatch(session, batchCmd); err != nil {
 				return err
 			}
-
-			batchCmd.Table = cmd.Table
-			batchCmd.LeaderIndex = cmd.LeaderIndex
-
-			if uint64(estimatedSize) < m.cfg.Table.MaxInMemLogSize/2 {
-				batchCmd.Batch = append(batchCmd.Batch, cmd.Kv)
-				continue
+			if err := m.proposeIngest(session, ingest); err != nil {
+				return err
+			}
+			if err := m.proposeRestored(session, cmd); err != nil {
+				return err
 			}
+			estimatedSize = 0
+			continue
 		}
 
-		bb, err := batchCmd.MarshalVT()
-		if err != nil {
-			return err
+		// Key values carrying the MVCC metadata are ingested into the table storage instead of being put key by key.
+		if cmd.Kv.ModRevision != 0 {
+			if err := ingest.Add(cmd); err != nil {
+				return err
+			}
+			if ingest.Size() >= ingestSize {
+				if err := m.proposeIngest(session, ingest); err != nil {
+					return err
+				}
+			}
+			continue
 		}
-		batchCmd.LeaderIndex = nil
-		batchCmd.Batch = batchCmd.Batch[:0]
 
-		err = backoff.Retry(func() error {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			_, err := m.nh.SyncPropose(ctx, session, bb)
-			if err != nil {
-				if errors.Is(err, dragonboat.ErrShardNotFound) {
-					m.log.Warn("cluster not found recovery probably started on a different node")
-					return backoff.Permanent(err)
-				}
-				m.log.Warnf("error proposing batch %v", err)
+		batchCmd.Table = cmd.Table
+		batchCmd.LeaderIndex = cmd.LeaderIndex
+		batchCmd.Batch = append(batchCmd.Batch, cmd.Kv)
+
+		if uint64(estimatedSize) >= batchSize {
+			if err := m.proposeBatch(session, batchCmd); err != nil {
 				return err
 			}
-			return nil
-		}, backOff)
-
-		if err != nil {
-			return err
+			estimatedSize = 0
 		}
+	}
+	if err := m.proposeBatch(session, batchCmd); err != nil {
+		return err
+	}
+	return m.proposeIngest(session, ingest)
+}
+
+// proposeIngest proposes the INGEST command of the restored key values, nothing is proposed if no key value was added.
+func (m *Manager) proposeIngest(session *client.Session, ingest *fsm.IngestBuilder) error {
+	cmd, err := ingest.Build()
+	if err != nil || cmd == nil {
+		return err
+	}
+	return m.proposeRestored(session, cmd)
+}
 
-		estimatedSize = 0
+// proposeBatch proposes the restored PUT_BATCH command and resets it, nothing is proposed if the batch is empty.
+func (m *Manager) proposeBatch(session *client.Session, batchCmd *regattapb.Command) error {
+	if len(batchCmd.Batch) == 0 {
+		return nil
+	}
+	err := m.proposeRestored(session, batchCmd)
+	batchCmd.LeaderIndex = nil
+	batchCmd.Batch = batchCmd.Batch[:0]
+	return err
+}
 
-		if last {
-			break
-		}
+// proposeRestored proposes the restored command retrying until the proposal succeeds.
+func (m *Manager) proposeRestored(session *client.Session, cmd *regattapb.Command) error {
+	bb, err := cmd.MarshalVT()
+	if err != nil {
+		return err
 	}
-	return nil
+	backOff := backoff.NewExponentialBackOff()
+	backOff.MaxElapsedTime = 0
+	return backoff.Retry(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_, err := m.nh.SyncPropose(ctx, session, bb)
+		if err != nil {
+			if errors.Is(err, dragonboat.ErrShardNotFound) {
+				m.log.Warn("cluster not found recovery probably started on a different node")
+				return backoff.Permanent(err)
+			}
+			m.log.Warnf("error proposing batch %v", err)
+			return err
+		}
+		return nil
+	}, backOff)
 }
 
 func (m *Manager) waitForLeader(clusterID uint64) error {
@@ -3,11 +3,16 @@
 package table
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"os"
 	"testing"
 	"time"
 
 	pvfs "github.com/cockroachdb/pebble/vfs"
+	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/replication/snapshot"
 	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/lni/dragonboat/v4"
@@ -52,6 +57,61 @@ func TestManager_CreateTable(t *testing.T) {
 	r.Equal(1, len(ts))
 }
 
+func TestManager_CreateTableWithOverrides(t *testing.T) {
+	const testTableName = "test"
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+
+	tm := NewManager(node, m, minimalTestConfig())
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+
+	snapshotEntries := uint64(0)
+	compression := CompressionZstd
+	bits := uint32(0)
+	overrides := &Overrides{SnapshotEntries: &snapshotEntries, Compression: &compression, BloomFilterBitsPerKey: &bits}
+	t.Log("create table")
+	r.NoError(tm.CreateTableWithOverrides(testTableName, overrides))
+
+	t.Log("get table")
+	tab, err := tm.GetTable(testTableName)
+	r.NoError(err)
+	r.Equal(overrides, tab.Overrides)
+
+	t.Log("table is started")
+	r.Eventually(func() bool {
+		_, _, ok, _ := node.GetLeaderID(tab.ClusterID)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_MarkReplicated(t *testing.T) {
+	const testTableName = "test"
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+
+	tm := NewManager(node, m, minimalTestConfig())
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+
+	r.ErrorIs(tm.MarkReplicated(testTableName), serrors.ErrTableNotFound)
+
+	r.NoError(tm.CreateTable(testTableName))
+	tabs, err := tm.GetTables()
+	r.NoError(err)
+	r.False(tabs[0].Replicated)
+
+	r.NoError(tm.MarkReplicated(testTableName))
+	r.NoError(tm.MarkReplicated(testTableName))
+	tabs, err = tm.GetTables()
+	r.NoError(err)
+	r.True(tabs[0].Replicated)
+}
+
 func TestManager_DeleteTable(t *testing.T) {
 	const testTableName = "test"
 	r := require.New(t)
@@ -84,6 +144,7 @@ func TestManager_DeleteTable(t *testing.T) {
 	t.Log("delete non-existent table")
 	_, err = tm.GetTable("foo")
 	r.ErrorIs(err, serrors.ErrTableNotFound)
+	r.ErrorIs(tm.DeleteTable("foo"), serrors.ErrTableNotFound)
 	r.NoError(tm.cleanup())
 
 	// LogDB cleaned
@@ -257,6 +318,206 @@ func TestManager_Restore(t *testing.T) {
 	require.Greater(t, tab2.ClusterID, tab.ClusterID, "restored table should have higher ID assigned")
 }
 
+func TestManager_RestoreLeases(t *testing.T) {
+	const (
+		sourceTable   = "source"
+		restoredTable = "restored"
+	)
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+	tm := NewManager(node, m, minimalTestConfig())
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+	r.NoError(tm.CreateTable(sourceTable))
+	r.NoError(tm.reconcile())
+
+	tab, err := tm.GetTable(sourceTable)
+	r.NoError(err)
+	r.NoError(tm.waitForLeader(tab.ClusterID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	grant, err := tab.LeaseGrant(ctx, &regattapb.LeaseGrantRequest{Table: []byte(sourceTable), Ttl: 60})
+	r.NoError(err)
+	_, err = tab.Put(ctx, &regattapb.PutRequest{Table: []byte(sourceTable), Key: []byte("key_1"), Value: []byte("value"), Lease: grant.Id})
+	r.NoError(err)
+	_, err = tab.Put(ctx, &regattapb.PutRequest{Table: []byte(sourceTable), Key: []byte("key_2"), Value: []byte("value")})
+	r.NoError(err)
+
+	sf, err := snapshot.NewTemp()
+	r.NoError(err)
+	defer func() {
+		_ = os.Remove(sf.Path())
+	}()
+	_, err = tab.Snapshot(ctx, sf)
+	r.NoError(err)
+	r.NoError(sf.Sync())
+	r.NoError(sf.Close())
+
+	sf, err = snapshot.OpenFile(sf.Path())
+	r.NoError(err)
+	defer sf.Close()
+	r.NoError(tm.Restore(restoredTable, sf))
+
+	restored, err := tm.GetTable(restoredTable)
+	r.NoError(err)
+	ttl, err := restored.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{Table: []byte(restoredTable), Id: grant.Id, Keys: true})
+	r.NoError(err)
+	r.Equal(int64(60), ttl.GrantedTtl)
+	r.Equal([][]byte{[]byte("key_1")}, ttl.Keys)
+
+	rng, err := restored.Range(ctx, &regattapb.RangeRequest{Table: []byte(restoredTable), Key: []byte("key_1"), RangeEnd: []byte{0}, Linearizable: true})
+	r.NoError(err)
+	r.Len(rng.Kvs, 2)
+}
+
+func TestManager_RestoreRevisions(t *testing.T) {
+	const (
+		sourceTable   = "source"
+		restoredTable = "restored"
+	)
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+	tm := NewManager(node, m, minimalTestConfig())
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+	r.NoError(tm.CreateTable(sourceTable))
+	r.NoError(tm.reconcile())
+
+	tab, err := tm.GetTable(sourceTable)
+	r.NoError(err)
+	r.NoError(tm.waitForLeader(tab.ClusterID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var last uint64
+	for i := 0; i < 20; i++ {
+		put, err := tab.Put(ctx, &regattapb.PutRequest{Table: []byte(sourceTable), Key: []byte("key_1"), Value: []byte(fmt.Sprintf("value_%d", i))})
+		r.NoError(err)
+		last = put.Header.Revision
+	}
+
+	sf, err := snapshot.NewTemp()
+	r.NoError(err)
+	defer func() {
+		_ = os.Remove(sf.Path())
+	}()
+	_, err = tab.Snapshot(ctx, sf)
+	r.NoError(err)
+	r.NoError(sf.Sync())
+	r.NoError(sf.Close())
+
+	sf, err = snapshot.OpenFile(sf.Path())
+	r.NoError(err)
+	defer sf.Close()
+	r.NoError(tm.Restore(restoredTable, sf))
+
+	// The writes to the restored table continue above the restored revisions.
+	restored, err := tm.GetTable(restoredTable)
+	r.NoError(err)
+	put, err := restored.Put(ctx, &regattapb.PutRequest{Table: []byte(restoredTable), Key: []byte("key_1"), Value: []byte("restored")})
+	r.NoError(err)
+	r.Greater(put.Header.Revision, last)
+
+	rng, err := restored.Range(ctx, &regattapb.RangeRequest{Table: []byte(restoredTable), Key: []byte("key_1"), Revision: int64(last), Linearizable: true})
+	r.NoError(err)
+	r.Len(rng.Kvs, 1)
+	r.Equal([]byte("value_19"), rng.Kvs[0].Value)
+	r.Equal(int64(last), rng.Kvs[0].ModRevision)
+
+	rng, err = restored.Range(ctx, &regattapb.RangeRequest{Table: []byte(restoredTable), Key: []byte("key_1"), Linearizable: true})
+	r.NoError(err)
+	r.Len(rng.Kvs, 1)
+	r.Equal([]byte("restored"), rng.Kvs[0].Value)
+	r.Equal(int64(put.Header.Revision), rng.Kvs[0].ModRevision)
+	r.Equal(int64(21), rng.Kvs[0].Version)
+}
+
+func TestManager_expireLeases(t *testing.T) {
+	const testTableName = "test"
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+	cfg := minimalTestConfig()
+	cfg.Table.LeaseExpiryInterval = 100 * time.Millisecond
+	tm := NewManager(node, m, cfg)
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+	r.NoError(tm.CreateTable(testTableName))
+	r.NoError(tm.reconcile())
+
+	tab, err := tm.GetTable(testTableName)
+	r.NoError(err)
+	r.NoError(tm.waitForLeader(tab.ClusterID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	short, err := tab.LeaseGrant(ctx, &regattapb.LeaseGrantRequest{Table: []byte(testTableName), Ttl: 1})
+	r.NoError(err)
+	long, err := tab.LeaseGrant(ctx, &regattapb.LeaseGrantRequest{Table: []byte(testTableName), Ttl: 60})
+	r.NoError(err)
+	_, err = tab.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("short"), Value: []byte("value"), Lease: short.Id})
+	r.NoError(err)
+	_, err = tab.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("long"), Value: []byte("value"), Lease: long.Id})
+	r.NoError(err)
+
+	r.Eventually(func() bool {
+		_, err := tab.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), Id: short.Id})
+		return errors.Is(err, serrors.ErrLeaseNotFound)
+	}, 3*time.Second, 100*time.Millisecond)
+
+	rng, err := tab.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte{0}, RangeEnd: []byte{0}, Linearizable: true})
+	r.NoError(err)
+	r.Len(rng.Kvs, 1)
+	r.Equal([]byte("long"), rng.Kvs[0].Key)
+
+	_, err = tab.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), Id: long.Id})
+	r.NoError(err)
+}
+
+func TestManager_compactHistory(t *testing.T) {
+	const testTableName = "test"
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+	cfg := minimalTestConfig()
+	cfg.Table.HistoryCompactionInterval = 100 * time.Millisecond
+	tm := NewManager(node, m, cfg)
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+	retention := uint64(2)
+	r.NoError(tm.CreateTableWithOverrides(testTableName, &Overrides{HistoryRetention: &retention}))
+	r.NoError(tm.reconcile())
+
+	tab, err := tm.GetTable(testTableName)
+	r.NoError(err)
+	r.NoError(tm.waitForLeader(tab.ClusterID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var revisions []int64
+	for i := 0; i < 5; i++ {
+		put, err := tab.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte(fmt.Sprintf("value_%d", i))})
+		r.NoError(err)
+		revisions = append(revisions, int64(put.Header.Revision))
+	}
+
+	// Only the history of the most recent revisions is retained.
+	r.Eventually(func() bool {
+		_, err := tab.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Revision: revisions[0], Linearizable: true})
+		return errors.Is(err, serrors.ErrCompacted)
+	}, 3*time.Second, 100*time.Millisecond)
+	rng, err := tab.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Revision: revisions[4], Linearizable: true})
+	r.NoError(err)
+	r.Equal([]byte("value_4"), rng.Kvs[0].Value)
+}
+
 func TestManager_reconcile(t *testing.T) {
 	const testTableName = "test"
 	r := require.New(t)
@@ -270,7 +531,7 @@ func TestManager_reconcile(t *testing.T) {
 	r.NoError(tm.Start())
 	defer tm.Close()
 	r.NoError(tm.WaitUntilReady())
-	_, err := tm.createTable(testTableName)
+	_, err := tm.createTable(Table{Name: testTableName})
 	r.NoError(err)
 	time.Sleep(reconcileInterval * 3)
 
@@ -4,7 +4,9 @@ package table
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	serrors "github.com/jamf/regatta/storage/errors"
@@ -21,14 +23,32 @@ type raftHandler interface {
 	GetNoOPSession(id uint64) *client.Session
 }
 
-// MaxValueLen 2MB max value.
-const MaxValueLen = 2 * 1024 * 1024
+const (
+	// MaxValueLen 2MB max value.
+	MaxValueLen = 2 * 1024 * 1024
+	// maxExpiredLeases maximum number of the leases revoked by a single ExpireLeases call.
+	maxExpiredLeases = 1000
+)
 
 // Table stored representation of a table.
 type Table struct {
 	Name      string `json:"name"`
 	ClusterID uint64 `json:"cluster_id"`
 	RecoverID uint64 `json:"recover_id"`
+	// Overrides of the TableConfig specific to this table.
+	Overrides *Overrides `json:"overrides,omitempty"`
+	// Replicated whether the table was replicated from the leader cluster.
+	Replicated bool `json:"replicated,omitempty"`
+	// Local whether the table is local to the follower cluster, local tables are not replicated and accept writes in the follower.
+	Local bool `json:"local,omitempty"`
+}
+
+// maxValueLen the maximum length of a value stored in the table.
+func (t Table) maxValueLen() int {
+	if t.Overrides != nil && t.Overrides.MaxValueLen != nil {
+		return int(*t.Overrides.MaxValueLen)
+	}
+	return MaxValueLen
 }
 
 // AsActive returns ActiveTable wrapper of this table.
@@ -68,6 +88,12 @@ func proposeTable[S any](t *ActiveTable, ctx context.Context, cmd *regattapb.Com
 	if err != nil {
 		return *new(S), 0, err
 	}
+	switch fsm.UpdateResult(res.Value) {
+	case fsm.ResultLeaseNotFound:
+		return *new(S), 0, serrors.ErrLeaseNotFound
+	case fsm.ResultNotCounter:
+		return *new(S), 0, serrors.ErrNotCounter
+	}
 	pr := &regattapb.CommandResult{}
 	if err := pr.UnmarshalVT(res.Data); err != nil {
 		return *new(S), 0, err
@@ -93,11 +119,18 @@ func (t *ActiveTable) Range(ctx context.Context, req *regattapb.RangeRequest) (*
 	}
 
 	response, err := readTable[*regattapb.ResponseOp_Range](t, ctx, req.Linearizable, &regattapb.RequestOp_Range{
-		Key:       req.Key,
-		RangeEnd:  req.RangeEnd,
-		Limit:     req.Limit,
-		KeysOnly:  req.KeysOnly,
-		CountOnly: req.CountOnly,
+		Key:               req.Key,
+		RangeEnd:          req.RangeEnd,
+		Limit:             req.Limit,
+		KeysOnly:          req.KeysOnly,
+		CountOnly:         req.CountOnly,
+		MinModRevision:    req.MinModRevision,
+		MaxModRevision:    req.MaxModRevision,
+		MinCreateRevision: req.MinCreateRevision,
+		MaxCreateRevision: req.MaxCreateRevision,
+		SortOrder:         req.SortOrder,
+		SortTarget:        req.SortTarget,
+		Revision:          req.Revision,
 	})
 	if err != nil {
 		return nil, err
@@ -109,6 +142,43 @@ func (t *ActiveTable) Range(ctx context.Context, req *regattapb.RangeRequest) (*
 	}, nil
 }
 
+// Cursor performs a Range query in the Raft data streaming the results in batches to the consumer, all the batches are read
+// from a single snapshot of the table. Supplied context must have a deadline set, the stream is interrupted once the stopper is closed.
+func (t *ActiveTable) Cursor(ctx context.Context, stopper <-chan struct{}, req *regattapb.RangeRequest, consumer func(*regattapb.RangeResponse) error) error {
+	if len(req.Key) > key.LatestVersionLen {
+		return serrors.ErrKeyLengthExceeded
+	}
+	if len(req.RangeEnd) > key.LatestVersionLen {
+		return serrors.ErrKeyLengthExceeded
+	}
+
+	_, err := readTable[*fsm.CursorResponse](t, ctx, req.Linearizable, fsm.CursorRequest{
+		RangeOp: &regattapb.RequestOp_Range{
+			Key:               req.Key,
+			RangeEnd:          req.RangeEnd,
+			Limit:             req.Limit,
+			KeysOnly:          req.KeysOnly,
+			CountOnly:         req.CountOnly,
+			MinModRevision:    req.MinModRevision,
+			MaxModRevision:    req.MaxModRevision,
+			MinCreateRevision: req.MinCreateRevision,
+			MaxCreateRevision: req.MaxCreateRevision,
+			SortOrder:         req.SortOrder,
+			SortTarget:        req.SortTarget,
+			Revision:          req.Revision,
+		},
+		Consumer: func(response *regattapb.ResponseOp_Range) error {
+			return consumer(&regattapb.RangeResponse{
+				Kvs:   response.Kvs,
+				Count: response.Count,
+				More:  response.More,
+			})
+		},
+		Stopper: stopper,
+	})
+	return err
+}
+
 // Put performs a Put proposal into the Raft, supplied context must have a deadline set.
 func (t *ActiveTable) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
 	if len(req.Key) == 0 {
@@ -117,7 +187,7 @@ func (t *ActiveTable) Put(ctx context.Context, req *regattapb.PutRequest) (*rega
 	if len(req.Key) > key.LatestVersionLen {
 		return nil, serrors.ErrKeyLengthExceeded
 	}
-	if len(req.Value) > MaxValueLen {
+	if len(req.Value) > t.maxValueLen() {
 		return nil, serrors.ErrValueLengthExceeded
 	}
 	cmd := &regattapb.Command{
@@ -126,8 +196,10 @@ func (t *ActiveTable) Put(ctx context.Context, req *regattapb.PutRequest) (*rega
 		Kv: &regattapb.KeyValue{
 			Key:   req.Key,
 			Value: req.Value,
+			Lease: req.Lease,
 		},
-		PrevKvs: req.PrevKv,
+		PrevKvs:   req.PrevKv,
+		Timestamp: time.Now().UnixMilli(),
 	}
 	r, rev, err := proposeTable[*regattapb.ResponseOp_ResponsePut](t, ctx, cmd)
 	if err != nil {
@@ -150,9 +222,10 @@ func (t *ActiveTable) Delete(ctx context.Context, req *regattapb.DeleteRangeRequ
 		Kv: &regattapb.KeyValue{
 			Key: req.Key,
 		},
-		PrevKvs:  req.PrevKv,
-		RangeEnd: req.RangeEnd,
-		Count:    req.Count,
+		PrevKvs:   req.PrevKv,
+		RangeEnd:  req.RangeEnd,
+		Count:     req.Count,
+		Timestamp: time.Now().UnixMilli(),
 	}
 	r, rev, err := proposeTable[*regattapb.ResponseOp_ResponseDeleteRange](t, ctx, cmd)
 	if err != nil {
@@ -161,7 +234,110 @@ func (t *ActiveTable) Delete(ctx context.Context, req *regattapb.DeleteRangeRequ
 	return &regattapb.DeleteRangeResponse{Deleted: r.ResponseDeleteRange.Deleted, PrevKvs: r.ResponseDeleteRange.PrevKvs, Header: &regattapb.ResponseHeader{Revision: rev}}, nil
 }
 
+// Increment performs an Increment proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	if len(req.Key) == 0 {
+		return nil, serrors.ErrEmptyKey
+	}
+	if len(req.Key) > key.LatestVersionLen {
+		return nil, serrors.ErrKeyLengthExceeded
+	}
+	cmd := &regattapb.Command{
+		Type:  regattapb.Command_INCREMENT,
+		Table: req.Table,
+		Increment: &regattapb.RequestOp_Increment{
+			Key:     req.Key,
+			Delta:   req.Delta,
+			Initial: req.Initial,
+			Min:     req.Min,
+			Max:     req.Max,
+		},
+		Timestamp: time.Now().UnixMilli(),
+	}
+	r, rev, err := proposeTable[*regattapb.ResponseOp_ResponseIncrement](t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.IncrementResponse{
+		Value:   r.ResponseIncrement.Value,
+		Applied: r.ResponseIncrement.Applied,
+		Header:  &regattapb.ResponseHeader{Revision: rev},
+	}, nil
+}
+
+// PutBatch performs a PutBatch proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) PutBatch(ctx context.Context, req *regattapb.PutBatchRequest) (*regattapb.PutBatchResponse, error) {
+	batch := make([]*regattapb.KeyValue, len(req.Kvs))
+	for i, kv := range req.Kvs {
+		if len(kv.Key) == 0 {
+			return nil, serrors.ErrEmptyKey
+		}
+		if len(kv.Key) > key.LatestVersionLen {
+			return nil, serrors.ErrKeyLengthExceeded
+		}
+		if len(kv.Value) > t.maxValueLen() {
+			return nil, serrors.ErrValueLengthExceeded
+		}
+		// Only the key and the value are taken, KeyValues carrying the MVCC metadata are restored verbatim by the FSM.
+		batch[i] = &regattapb.KeyValue{Key: kv.Key, Value: kv.Value}
+	}
+	rev, err := proposeBatch(t, ctx, &regattapb.Command{
+		Type:      regattapb.Command_PUT_BATCH,
+		Table:     req.Table,
+		Batch:     batch,
+		Timestamp: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.PutBatchResponse{Header: &regattapb.ResponseHeader{Revision: rev}}, nil
+}
+
+// DeleteBatch performs a DeleteBatch proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) DeleteBatch(ctx context.Context, req *regattapb.DeleteBatchRequest) (*regattapb.DeleteBatchResponse, error) {
+	batch := make([]*regattapb.KeyValue, len(req.Keys))
+	for i, k := range req.Keys {
+		if len(k) == 0 {
+			return nil, serrors.ErrEmptyKey
+		}
+		if len(k) > key.LatestVersionLen {
+			return nil, serrors.ErrKeyLengthExceeded
+		}
+		batch[i] = &regattapb.KeyValue{Key: k}
+	}
+	rev, err := proposeBatch(t, ctx, &regattapb.Command{
+		Type:      regattapb.Command_DELETE_BATCH,
+		Table:     req.Table,
+		Batch:     batch,
+		Timestamp: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.DeleteBatchResponse{Header: &regattapb.ResponseHeader{Revision: rev}}, nil
+}
+
+func proposeBatch(t *ActiveTable, ctx context.Context, cmd *regattapb.Command) (uint64, error) {
+	bytes, err := cmd.MarshalVT()
+	if err != nil {
+		return 0, err
+	}
+	res, err := t.nh.SyncPropose(ctx, t.session, bytes)
+	if err != nil {
+		return 0, err
+	}
+	pr := &regattapb.CommandResult{}
+	if err := pr.UnmarshalVT(res.Data); err != nil {
+		return 0, err
+	}
+	return pr.Revision, nil
+}
+
 func (t *ActiveTable) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
+	if fsm.TxnDepth(req.Success, req.Failure) > fsm.MaxTxnDepth {
+		return nil, serrors.ErrTxnTooDeep
+	}
+
 	// Do not propose read-only transactions through the log
 	if isReadonlyTransaction(req) {
 		return readTable[*regattapb.TxnResponse](t, ctx, true, req)
@@ -175,6 +351,7 @@ func (t *ActiveTable) Txn(ctx context.Context, req *regattapb.TxnRequest) (*rega
 			Success: req.Success,
 			Failure: req.Failure,
 		},
+		Timestamp: time.Now().UnixMilli(),
 	}
 
 	bytes, err := cmd.MarshalVT()
@@ -185,6 +362,12 @@ func (t *ActiveTable) Txn(ctx context.Context, req *regattapb.TxnRequest) (*rega
 	if err != nil {
 		return nil, err
 	}
+	switch fsm.UpdateResult(res.Value) {
+	case fsm.ResultLeaseNotFound:
+		return nil, serrors.ErrLeaseNotFound
+	case fsm.ResultNotCounter:
+		return nil, serrors.ErrNotCounter
+	}
 	txr := &regattapb.CommandResult{}
 	if err := txr.UnmarshalVT(res.Data); err != nil {
 		return nil, err
@@ -196,15 +379,126 @@ func (t *ActiveTable) Txn(ctx context.Context, req *regattapb.TxnRequest) (*rega
 	}, nil
 }
 
-func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
-	for _, op := range req.Success {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
-			return false
+// LeaseGrant performs a LeaseGrant proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	cmd := &regattapb.Command{
+		Type:      regattapb.Command_LEASE_GRANT,
+		Table:     req.Table,
+		Lease:     &regattapb.Lease{Id: req.Id, Ttl: req.Ttl},
+		Timestamp: time.Now().UnixMilli(),
+	}
+	lease, rev, err := proposeLease(t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.LeaseGrantResponse{Id: lease.Id, Ttl: lease.Ttl, Header: &regattapb.ResponseHeader{Revision: rev}}, nil
+}
+
+// LeaseRevoke performs a LeaseRevoke proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	cmd := &regattapb.Command{
+		Type:  regattapb.Command_LEASE_REVOKE,
+		Table: req.Table,
+		Lease: &regattapb.Lease{Id: req.Id},
+	}
+	_, rev, err := proposeLease(t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.LeaseRevokeResponse{Header: &regattapb.ResponseHeader{Revision: rev}}, nil
+}
+
+// LeaseKeepAlive performs a LeaseKeepAlive proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseKeepAlive(ctx context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error) {
+	cmd := &regattapb.Command{
+		Type:      regattapb.Command_LEASE_KEEPALIVE,
+		Table:     req.Table,
+		Lease:     &regattapb.Lease{Id: req.Id},
+		Timestamp: time.Now().UnixMilli(),
+	}
+	lease, rev, err := proposeLease(t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.LeaseKeepAliveResponse{Id: lease.Id, Ttl: lease.Ttl, Header: &regattapb.ResponseHeader{Revision: rev}}, nil
+}
+
+// LeaseTimeToLive reads the lease from the Raft data, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	response, err := readTable[*fsm.LeaseResponse](t, ctx, true, fsm.LeaseRequest{ID: req.Id, Keys: req.Keys})
+	if err != nil {
+		return nil, err
+	}
+	if response.Lease == nil {
+		return nil, serrors.ErrLeaseNotFound
+	}
+	return &regattapb.LeaseTimeToLiveResponse{
+		Id:         response.Lease.Id,
+		Ttl:        max(response.Lease.Expiry-time.Now().UnixMilli(), 0) / 1000,
+		GrantedTtl: response.Lease.Ttl,
+		Keys:       response.Keys,
+	}, nil
+}
+
+// ExpireLeases revokes the leases expired before now, leases kept alive in the meantime are left intact.
+// Supplied context must have a deadline set.
+func (t *ActiveTable) ExpireLeases(ctx context.Context, now time.Time) error {
+	response, err := readTable[*fsm.ExpiredLeasesResponse](t, ctx, false, fsm.ExpiredLeasesRequest{Now: now.UnixMilli(), Limit: maxExpiredLeases})
+	if err != nil {
+		return err
+	}
+	for _, lease := range response.Leases {
+		cmd := &regattapb.Command{
+			Type:  regattapb.Command_LEASE_REVOKE,
+			Table: []byte(t.Name),
+			Lease: &regattapb.Lease{Id: lease.Id, Expiry: lease.Expiry},
+		}
+		if _, _, err := proposeLease(t, ctx, cmd); err != nil && !errors.Is(err, serrors.ErrLeaseNotFound) {
+			return err
 		}
 	}
+	return nil
+}
 
-	for _, op := range req.Failure {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
+func proposeLease(t *ActiveTable, ctx context.Context, cmd *regattapb.Command) (*regattapb.Lease, uint64, error) {
+	bytes, err := cmd.MarshalVT()
+	if err != nil {
+		return nil, 0, err
+	}
+	res, err := t.nh.SyncPropose(ctx, t.session, bytes)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch fsm.UpdateResult(res.Value) {
+	case fsm.ResultLeaseNotFound:
+		return nil, 0, serrors.ErrLeaseNotFound
+	case fsm.ResultLeaseExists:
+		return nil, 0, serrors.ErrLeaseExists
+	}
+	pr := &regattapb.CommandResult{}
+	if err := pr.UnmarshalVT(res.Data); err != nil {
+		return nil, 0, err
+	}
+	if pr.Lease == nil {
+		return nil, 0, serrors.ErrNoResultFound
+	}
+	return pr.Lease, pr.Revision, nil
+}
+
+func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
+	return isReadonlyOps(req.Success) && isReadonlyOps(req.Failure)
+}
+
+// isReadonlyOps reports whether the ops are only ranges or read-only nested transactions.
+func isReadonlyOps(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+		case *regattapb.RequestOp_RequestTxn:
+			if !isReadonlyOps(o.RequestTxn.Success) || !isReadonlyOps(o.RequestTxn.Failure) {
+				return false
+			}
+		default:
 			return false
 		}
 	}
@@ -226,6 +520,50 @@ func (t *ActiveTable) LeaderIndex(ctx context.Context, linearizable bool) (*fsm.
 	return readTable[*fsm.IndexResponse](t, ctx, linearizable, fsm.LeaderIndexRequest{})
 }
 
+// Revision returns the revision of the last applied command.
+func (t *ActiveTable) Revision(ctx context.Context, linearizable bool) (*fsm.IndexResponse, error) {
+	return readTable[*fsm.IndexResponse](t, ctx, linearizable, fsm.RevisionRequest{})
+}
+
+// RevisionOffset returns the offsets of the revisions from the local and the leader index.
+func (t *ActiveTable) RevisionOffset(ctx context.Context, linearizable bool) (*fsm.RevisionOffsetResponse, error) {
+	return readTable[*fsm.RevisionOffsetResponse](t, ctx, linearizable, fsm.RevisionOffsetRequest{})
+}
+
+// Compact discards the history of the keys older than the revision, the table could not be read at any older revision afterwards.
+// Supplied context must have a deadline set.
+func (t *ActiveTable) Compact(ctx context.Context, revision int64) error {
+	cmd := &regattapb.Command{
+		Type:     regattapb.Command_COMPACT,
+		Table:    []byte(t.Name),
+		Revision: revision,
+	}
+	bts, err := cmd.MarshalVT()
+	if err != nil {
+		return err
+	}
+	// The history of the larger tables is compacted in multiple steps, the compaction continues until the whole history is compacted.
+	for pending := false; ; pending = true {
+		res, err := t.nh.SyncPropose(ctx, t.session, bts)
+		if err != nil {
+			return err
+		}
+		switch fsm.UpdateResult(res.Value) {
+		case fsm.ResultCompactPending:
+			continue
+		case fsm.ResultCompacted:
+			if pending {
+				// The compaction was finished by a concurrent proposal.
+				return nil
+			}
+			return serrors.ErrCompacted
+		case fsm.ResultFutureRevision:
+			return serrors.ErrFutureRevision
+		}
+		return nil
+	}
+}
+
 // Reset resets the leader index to 0.
 func (t *ActiveTable) Reset(ctx context.Context) error {
 	li := uint64(0)
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/fsm"
 	"github.com/jamf/regatta/storage/table/key"
 	"github.com/jamf/regatta/util"
 	"github.com/lni/dragonboat/v4/client"
@@ -186,6 +188,48 @@ func TestActiveTable_Range(t *testing.T) {
 	}
 }
 
+func TestActiveTable_Cursor(t *testing.T) {
+	r := require.New(t)
+
+	t.Log("Cursor with too long key")
+	at := &ActiveTable{nh: &mockRaftHandler{}, Table: Table{Name: "test", ClusterID: 1}}
+	err := at.Cursor(context.TODO(), nil, &regattapb.RangeRequest{Key: longKey}, nil)
+	r.ErrorIs(err, serrors.ErrKeyLengthExceeded)
+
+	t.Log("Cursor streams the batches to the consumer")
+	handler := &mockRaftHandler{}
+	handler.
+		On("SyncRead", mock.Anything, uint64(1), mock.Anything).
+		Run(func(args mock.Arguments) {
+			req := args.Get(2).(fsm.CursorRequest)
+			r.Equal([]byte("foo"), req.RangeOp.Key)
+			r.Equal([]byte("fop"), req.RangeOp.RangeEnd)
+			r.NoError(req.Consumer(&regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{{Key: []byte("foo")}}, Count: 1, More: true}))
+			r.NoError(req.Consumer(&regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{{Key: []byte("foo1")}}, Count: 1}))
+		}).
+		Return(&fsm.CursorResponse{}, nil)
+	at = &ActiveTable{nh: handler, Table: Table{Name: "test", ClusterID: 1}}
+
+	var got []*regattapb.RangeResponse
+	err = at.Cursor(context.TODO(), nil, &regattapb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop"), Linearizable: true}, func(response *regattapb.RangeResponse) error {
+		got = append(got, response)
+		return nil
+	})
+	r.NoError(err)
+	r.Equal([]*regattapb.RangeResponse{
+		{Kvs: []*regattapb.KeyValue{{Key: []byte("foo")}}, Count: 1, More: true},
+		{Kvs: []*regattapb.KeyValue{{Key: []byte("foo1")}}, Count: 1},
+	}, got)
+	handler.AssertExpectations(t)
+
+	t.Log("Cursor with unknown error")
+	handler = &mockRaftHandler{}
+	handler.On("StaleRead", uint64(1), mock.Anything).Return(nil, errUnknown)
+	at = &ActiveTable{nh: handler, Table: Table{Name: "test", ClusterID: 1}}
+	err = at.Cursor(context.TODO(), nil, &regattapb.RangeRequest{Key: []byte("foo")}, nil)
+	r.ErrorIs(err, errUnknown)
+}
+
 func TestActiveTable_Put(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -203,7 +247,7 @@ func TestActiveTable_Put(t *testing.T) {
 			name: "Put KV success",
 			on: func(handler *mockRaftHandler) {
 				handler.
-					On("SyncPropose", mock.Anything, mock.Anything, mustMarshallProto(&regattapb.Command{
+					On("SyncPropose", mock.Anything, mock.Anything, matchCommand(&regattapb.Command{
 						Type: regattapb.Command_PUT,
 						Kv:   &regattapb.KeyValue{Key: []byte("foo"), Value: []byte("bar")},
 					})).
@@ -224,7 +268,7 @@ func TestActiveTable_Put(t *testing.T) {
 			name: "Put KV with prev",
 			on: func(handler *mockRaftHandler) {
 				handler.
-					On("SyncPropose", mock.Anything, mock.Anything, mustMarshallProto(&regattapb.Command{
+					On("SyncPropose", mock.Anything, mock.Anything, matchCommand(&regattapb.Command{
 						Type:    regattapb.Command_PUT,
 						Kv:      &regattapb.KeyValue{Key: []byte("foo"), Value: []byte("bar")},
 						PrevKvs: true,
@@ -276,6 +320,26 @@ func TestActiveTable_Put(t *testing.T) {
 			},
 			wantErr: serrors.ErrValueLengthExceeded,
 		},
+		{
+			name: "Put KV lease not found",
+			on: func(handler *mockRaftHandler) {
+				handler.
+					On("SyncPropose", mock.Anything, mock.Anything, matchCommand(&regattapb.Command{
+						Type: regattapb.Command_PUT,
+						Kv:   &regattapb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), Lease: 1},
+					})).
+					Return(sm.Result{Value: uint64(fsm.ResultLeaseNotFound)}, nil)
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &regattapb.PutRequest{
+					Key:   []byte("foo"),
+					Value: []byte("bar"),
+					Lease: 1,
+				},
+			},
+			wantErr: serrors.ErrLeaseNotFound,
+		},
 		{
 			name: "Put KV unknown error",
 			on: func(handler *mockRaftHandler) {
@@ -318,6 +382,19 @@ func TestActiveTable_Put(t *testing.T) {
 	}
 }
 
+func TestActiveTable_PutMaxValueLenOverride(t *testing.T) {
+	r := require.New(t)
+	maxValueLen := uint64(2)
+	at := &ActiveTable{
+		Table: Table{Overrides: &Overrides{MaxValueLen: &maxValueLen}},
+		nh:    &mockRaftHandler{},
+	}
+	_, err := at.Put(context.TODO(), &regattapb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	r.ErrorIs(err, serrors.ErrValueLengthExceeded)
+	_, err = at.PutBatch(context.TODO(), &regattapb.PutBatchRequest{Kvs: []*regattapb.KeyValue{{Key: []byte("foo"), Value: []byte("bar")}}})
+	r.ErrorIs(err, serrors.ErrValueLengthExceeded)
+}
+
 func TestActiveTable_Delete(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -343,7 +420,7 @@ func TestActiveTable_Delete(t *testing.T) {
 			name: "Delete existing key",
 			on: func(handler *mockRaftHandler) {
 				handler.
-					On("SyncPropose", mock.Anything, mock.Anything, mustMarshallProto(&regattapb.Command{
+					On("SyncPropose", mock.Anything, mock.Anything, matchCommand(&regattapb.Command{
 						Type: regattapb.Command_DELETE,
 						Kv:   &regattapb.KeyValue{Key: []byte("foo")},
 					})).
@@ -361,7 +438,7 @@ func TestActiveTable_Delete(t *testing.T) {
 			name: "Delete existing key with prev",
 			on: func(handler *mockRaftHandler) {
 				handler.
-					On("SyncPropose", mock.Anything, mock.Anything, mustMarshallProto(&regattapb.Command{
+					On("SyncPropose", mock.Anything, mock.Anything, matchCommand(&regattapb.Command{
 						Type:    regattapb.Command_DELETE,
 						Kv:      &regattapb.KeyValue{Key: []byte("foo")},
 						PrevKvs: true,
@@ -383,7 +460,7 @@ func TestActiveTable_Delete(t *testing.T) {
 			name: "Delete existing range",
 			on: func(handler *mockRaftHandler) {
 				handler.
-					On("SyncPropose", mock.Anything, mock.Anything, mustMarshallProto(&regattapb.Command{
+					On("SyncPropose", mock.Anything, mock.Anything, matchCommand(&regattapb.Command{
 						Type:     regattapb.Command_DELETE,
 						Kv:       &regattapb.KeyValue{Key: []byte("foo")},
 						RangeEnd: []byte("foo1"),
@@ -463,6 +540,89 @@ func TestActiveTable_Delete(t *testing.T) {
 	}
 }
 
+func TestActiveTable_LeaseGrant(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sm.Result
+		want    *regattapb.LeaseGrantResponse
+		wantErr error
+	}{
+		{
+			name: "Grant lease",
+			result: sm.Result{
+				Value: uint64(fsm.ResultSuccess),
+				Data:  mustMarshallProto(&regattapb.CommandResult{Revision: 2, Lease: &regattapb.Lease{Id: 1, Ttl: 10, Expiry: 10000}}),
+			},
+			want: &regattapb.LeaseGrantResponse{Id: 1, Ttl: 10, Header: &regattapb.ResponseHeader{Revision: 2}},
+		},
+		{
+			name:    "Grant existing lease",
+			result:  sm.Result{Value: uint64(fsm.ResultLeaseExists), Data: mustMarshallProto(&regattapb.CommandResult{Revision: 2})},
+			wantErr: serrors.ErrLeaseExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			nh := &mockRaftHandler{}
+			nh.On("SyncPropose", mock.Anything, mock.Anything, mock.MatchedBy(func(bts []byte) bool {
+				cmd := &regattapb.Command{}
+				return cmd.UnmarshalVT(bts) == nil && cmd.Type == regattapb.Command_LEASE_GRANT && cmd.Lease.Ttl == 10 && cmd.Timestamp > 0
+			})).Return(tt.result, nil)
+			at := &ActiveTable{Table: Table{}, nh: nh}
+			got, err := at.LeaseGrant(context.TODO(), &regattapb.LeaseGrantRequest{Ttl: 10})
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestActiveTable_LeaseTimeToLive(t *testing.T) {
+	r := require.New(t)
+	nh := &mockRaftHandler{}
+	expiry := time.Now().Add(5500 * time.Millisecond).UnixMilli()
+	nh.On("SyncRead", mock.Anything, mock.Anything, fsm.LeaseRequest{ID: 1, Keys: true}).
+		Return(&fsm.LeaseResponse{Lease: &regattapb.Lease{Id: 1, Ttl: 10, Expiry: expiry}, Keys: [][]byte{[]byte("foo")}}, nil)
+	nh.On("SyncRead", mock.Anything, mock.Anything, fsm.LeaseRequest{ID: 2}).
+		Return(&fsm.LeaseResponse{}, nil)
+	at := &ActiveTable{Table: Table{}, nh: nh}
+
+	got, err := at.LeaseTimeToLive(context.TODO(), &regattapb.LeaseTimeToLiveRequest{Id: 1, Keys: true})
+	r.NoError(err)
+	r.Equal(&regattapb.LeaseTimeToLiveResponse{Id: 1, Ttl: 5, GrantedTtl: 10, Keys: [][]byte{[]byte("foo")}}, got)
+
+	_, err = at.LeaseTimeToLive(context.TODO(), &regattapb.LeaseTimeToLiveRequest{Id: 2})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+}
+
+func TestActiveTable_Txn(t *testing.T) {
+	r := require.New(t)
+	nh := &mockRaftHandler{}
+	nested := &regattapb.TxnRequest{Success: []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{}}},
+	}}
+	nh.On("SyncRead", mock.Anything, mock.Anything, nested).Return(&regattapb.TxnResponse{Succeeded: true}, nil)
+	at := &ActiveTable{Table: Table{}, nh: nh}
+
+	t.Log("Readonly nested Txn is not proposed")
+	got, err := at.Txn(context.TODO(), nested)
+	r.NoError(err)
+	r.True(got.Succeeded)
+
+	t.Log("Txn nested too deep")
+	for i := 0; i < fsm.MaxTxnDepth; i++ {
+		nested = &regattapb.TxnRequest{Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Success: nested.Success}}},
+		}}
+	}
+	_, err = at.Txn(context.TODO(), nested)
+	r.ErrorIs(err, serrors.ErrTxnTooDeep)
+}
+
 func TestTable_AsActive(t *testing.T) {
 	type fields struct {
 		Name      string
@@ -506,3 +666,15 @@ func mustMarshallProto(message pb.Message) []byte {
 	}
 	return bytes
 }
+
+// matchCommand matches the proposed command ignoring the proposal timestamp, the timestamp must be set though.
+func matchCommand(want *regattapb.Command) interface{} {
+	return mock.MatchedBy(func(bts []byte) bool {
+		cmd := &regattapb.Command{}
+		if cmd.UnmarshalVT(bts) != nil || cmd.Timestamp == 0 {
+			return false
+		}
+		cmd.Timestamp = 0
+		return pb.Equal(cmd, want)
+	})
+}
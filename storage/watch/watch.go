@@ -0,0 +1,247 @@
+// Copyright JAMF Software, LLC
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jamf/regatta/regattapb"
+)
+
+const (
+	// DefaultHistorySize default number of the most recent events retained per table.
+	DefaultHistorySize = 10_000
+	// DefaultBufferSize default number of the event batches buffered per watcher.
+	DefaultBufferSize = 128
+	// maxBacklogBatch maximum number of historical events returned at once.
+	maxBacklogBatch = 1000
+)
+
+var (
+	// ErrCompacted the requested revision is no longer retained.
+	ErrCompacted = errors.New("required revision has been compacted")
+	// ErrSlowWatcher the watcher was not able to keep up with the changes of the table.
+	ErrSlowWatcher = errors.New("watcher is too slow")
+	// ErrClosed the watcher was closed.
+	ErrClosed = errors.New("watcher closed")
+)
+
+// CompactedError is returned when the events the watcher requires are no longer retained.
+type CompactedError struct {
+	// CompactRevision minimum revision available to watch from.
+	CompactRevision int64
+	// Reason why the events are no longer available.
+	Reason error
+}
+
+func (e *CompactedError) Error() string {
+	return fmt.Sprintf("%s: minimum available revision %d", e.Reason.Error(), e.CompactRevision)
+}
+
+func (e *CompactedError) Is(target error) bool {
+	return target == ErrCompacted
+}
+
+func (e *CompactedError) Unwrap() error {
+	return e.Reason
+}
+
+// Hub tracks the changes of the tables and dispatches them to the watchers, it implements fsm.EventSink.
+// The most recent events of every table are retained so that the watchers could resume from a past revision.
+type Hub struct {
+	mu          sync.Mutex
+	tables      map[string]*tableHistory
+	historySize int
+	bufferSize  int
+}
+
+type tableHistory struct {
+	// known whether the table state was ever loaded.
+	known bool
+	// floor is the revision the retained history starts after.
+	floor    int64
+	history  []*regattapb.Event
+	watchers map[*Watcher]struct{}
+}
+
+// NewHub creates a new Hub retaining historySize events per table and buffering bufferSize event batches per watcher.
+func NewHub(historySize, bufferSize int) *Hub {
+	if bufferSize < 1 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		tables:      make(map[string]*tableHistory),
+		historySize: historySize,
+		bufferSize:  bufferSize,
+	}
+}
+
+func (h *Hub) table(name string) *tableHistory {
+	t, ok := h.tables[name]
+	if !ok {
+		t = &tableHistory{watchers: make(map[*Watcher]struct{})}
+		h.tables[name] = t
+	}
+	return t
+}
+
+// Reset drops the retained history of the table and cancels its watchers as they could have missed some events.
+func (h *Hub) Reset(table string, revision int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t := h.table(table)
+	for w := range t.watchers {
+		h.cancel(t, w, &CompactedError{CompactRevision: revision + 1, Reason: errors.New("table state reloaded")})
+	}
+	t.known = true
+	t.floor = revision
+	t.history = nil
+}
+
+// Publish dispatches the events to the watchers, watchers not able to keep up are canceled.
+func (h *Hub) Publish(table string, events []*regattapb.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t := h.table(table)
+	t.append(events, h.historySize)
+	for w := range t.watchers {
+		matched := w.filter(events)
+		if len(matched) == 0 {
+			continue
+		}
+		select {
+		case w.c <- matched:
+		default:
+			h.cancel(t, w, ErrSlowWatcher)
+		}
+	}
+}
+
+func (t *tableHistory) append(events []*regattapb.Event, size int) {
+	if size <= 0 {
+		if len(events) > 0 {
+			t.floor = events[len(events)-1].Kv.ModRevision
+		}
+		return
+	}
+	t.history = append(t.history, events...)
+	if over := len(t.history) - size; over > 0 {
+		t.floor = t.history[over-1].Kv.ModRevision
+		// Reallocate once the evicted prefix is as large as the retained history to bound the memory.
+		if over >= size {
+			t.history = append(make([]*regattapb.Event, 0, size), t.history[over:]...)
+		} else {
+			t.history = t.history[over:]
+		}
+	}
+}
+
+// Watch registers a new Watcher for the [key, rangeEnd) range of the table starting at startRevision (0 means now).
+// The returned Watcher must be closed by the caller.
+func (h *Hub) Watch(table string, key, rangeEnd []byte, startRevision int64, prevKv bool) (*Watcher, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t := h.table(table)
+	w := &Watcher{
+		hub:      h,
+		table:    table,
+		key:      key,
+		rangeEnd: rangeEnd,
+		prevKv:   prevKv,
+		c:        make(chan []*regattapb.Event, h.bufferSize),
+	}
+	if startRevision > 0 {
+		if !t.known || startRevision <= t.floor {
+			return nil, &CompactedError{CompactRevision: t.floor + 1, Reason: errors.New("revision not retained")}
+		}
+		for _, e := range t.history {
+			if e.Kv.ModRevision >= startRevision {
+				w.backlog = append(w.backlog, e)
+			}
+		}
+		w.backlog = w.filter(w.backlog)
+	}
+	t.watchers[w] = struct{}{}
+	return w, nil
+}
+
+// cancel removes the watcher and signals the reason to it, must be called with the h.mu held.
+func (h *Hub) cancel(t *tableHistory, w *Watcher, reason error) {
+	if _, ok := t.watchers[w]; !ok {
+		return
+	}
+	delete(t.watchers, w)
+	w.err = reason
+	close(w.c)
+}
+
+// Watcher receives the events of the watched range of the keys.
+type Watcher struct {
+	hub      *Hub
+	table    string
+	key      []byte
+	rangeEnd []byte
+	prevKv   bool
+	backlog  []*regattapb.Event
+	c        chan []*regattapb.Event
+	// err is set before c is closed.
+	err error
+}
+
+// Next blocks until the next batch of events is available, the watch is canceled, or the context is done.
+func (w *Watcher) Next(ctx context.Context) ([]*regattapb.Event, error) {
+	if len(w.backlog) > 0 {
+		n := min(len(w.backlog), maxBacklogBatch)
+		events := w.backlog[:n]
+		w.backlog = w.backlog[n:]
+		return events, nil
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case events, ok := <-w.c:
+		if !ok {
+			return nil, w.err
+		}
+		return events, nil
+	}
+}
+
+// Close unregisters the watcher from the Hub.
+func (w *Watcher) Close() {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+	if t, ok := w.hub.tables[w.table]; ok {
+		w.hub.cancel(t, w, ErrClosed)
+	}
+}
+
+// filter returns the events of the watched keys, the previous key-values are stripped unless requested.
+func (w *Watcher) filter(events []*regattapb.Event) []*regattapb.Event {
+	var matched []*regattapb.Event
+	for _, e := range events {
+		if !w.matches(e.Kv.Key) {
+			continue
+		}
+		if !w.prevKv && e.PrevKv != nil {
+			e = &regattapb.Event{Type: e.Type, Kv: e.Kv}
+		}
+		matched = append(matched, e)
+	}
+	return matched
+}
+
+func (w *Watcher) matches(k []byte) bool {
+	switch {
+	case len(w.rangeEnd) == 0:
+		return bytes.Equal(k, w.key)
+	case bytes.Equal(w.rangeEnd, []byte{0}):
+		return bytes.Compare(k, w.key) >= 0
+	default:
+		return bytes.Compare(k, w.key) >= 0 && bytes.Compare(k, w.rangeEnd) < 0
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright JAMF Software, LLC
+
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/stretchr/testify/require"
+)
+
+func putEvent(key string, rev int64) *regattapb.Event {
+	return &regattapb.Event{
+		Type:   regattapb.Event_PUT,
+		Kv:     &regattapb.KeyValue{Key: []byte(key), ModRevision: rev},
+		PrevKv: &regattapb.KeyValue{Key: []byte(key), ModRevision: rev - 1},
+	}
+}
+
+func next(t *testing.T, w *Watcher) ([]*regattapb.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return w.Next(ctx)
+}
+
+func TestHub_Watch(t *testing.T) {
+	r := require.New(t)
+	h := NewHub(10, 10)
+	h.Reset("table", 0)
+
+	key, err := h.Watch("table", []byte("key"), nil, 0, true)
+	r.NoError(err)
+	defer key.Close()
+	prefix, err := h.Watch("table", []byte("key"), []byte("kez"), 0, false)
+	r.NoError(err)
+	defer prefix.Close()
+	all, err := h.Watch("table", []byte{0}, []byte{0}, 0, false)
+	r.NoError(err)
+	defer all.Close()
+	other, err := h.Watch("other", []byte{0}, []byte{0}, 0, false)
+	r.NoError(err)
+	defer other.Close()
+
+	h.Publish("table", []*regattapb.Event{putEvent("key", 1), putEvent("key2", 1), putEvent("other", 2)})
+
+	evs, err := next(t, key)
+	r.NoError(err)
+	r.Equal([]*regattapb.Event{putEvent("key", 1)}, evs)
+
+	evs, err = next(t, prefix)
+	r.NoError(err)
+	r.Len(evs, 2)
+	r.Equal("key", string(evs[0].Kv.Key))
+	r.Equal("key2", string(evs[1].Kv.Key))
+	r.Nil(evs[0].PrevKv)
+
+	evs, err = next(t, all)
+	r.NoError(err)
+	r.Len(evs, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = other.Next(ctx)
+	r.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestHub_WatchStartRevision(t *testing.T) {
+	r := require.New(t)
+	h := NewHub(3, 10)
+
+	_, err := h.Watch("table", []byte("key"), nil, 1, false)
+	r.ErrorIs(err, ErrCompacted)
+
+	h.Reset("table", 10)
+	_, err = h.Watch("table", []byte("key"), nil, 10, false)
+	r.ErrorIs(err, ErrCompacted)
+
+	for i := int64(11); i <= 14; i++ {
+		h.Publish("table", []*regattapb.Event{putEvent("key", i)})
+	}
+
+	// Revision 11 was evicted from the history.
+	_, err = h.Watch("table", []byte("key"), nil, 11, false)
+	var cerr *CompactedError
+	r.ErrorAs(err, &cerr)
+	r.Equal(int64(12), cerr.CompactRevision)
+
+	w, err := h.Watch("table", []byte("key"), nil, 13, false)
+	r.NoError(err)
+	defer w.Close()
+	h.Publish("table", []*regattapb.Event{putEvent("key", 15)})
+
+	evs, err := next(t, w)
+	r.NoError(err)
+	r.Len(evs, 2)
+	r.Equal(int64(13), evs[0].Kv.ModRevision)
+	r.Equal(int64(14), evs[1].Kv.ModRevision)
+	evs, err = next(t, w)
+	r.NoError(err)
+	r.Len(evs, 1)
+	r.Equal(int64(15), evs[0].Kv.ModRevision)
+}
+
+func TestHub_SlowWatcher(t *testing.T) {
+	r := require.New(t)
+	h := NewHub(10, 1)
+	h.Reset("table", 0)
+
+	w, err := h.Watch("table", []byte("key"), nil, 0, false)
+	r.NoError(err)
+	defer w.Close()
+
+	// Publishing must not block even though nobody reads the events.
+	h.Publish("table", []*regattapb.Event{putEvent("key", 1)})
+	h.Publish("table", []*regattapb.Event{putEvent("key", 2)})
+	h.Publish("table", []*regattapb.Event{putEvent("key", 3)})
+
+	_, err = next(t, w)
+	r.NoError(err)
+	_, err = next(t, w)
+	r.ErrorIs(err, ErrSlowWatcher)
+}
+
+func TestHub_Reset(t *testing.T) {
+	r := require.New(t)
+	h := NewHub(10, 10)
+	h.Reset("table", 0)
+	h.Publish("table", []*regattapb.Event{putEvent("key", 1)})
+
+	w, err := h.Watch("table", []byte("key"), nil, 0, false)
+	r.NoError(err)
+	defer w.Close()
+
+	h.Reset("table", 100)
+	_, err = next(t, w)
+	var cerr *CompactedError
+	r.ErrorAs(err, &cerr)
+	r.Equal(int64(101), cerr.CompactRevision)
+
+	_, err = h.Watch("table", []byte("key"), nil, 1, false)
+	r.ErrorIs(err, ErrCompacted)
+}
+
+func TestWatcher_Close(t *testing.T) {
+	r := require.New(t)
+	h := NewHub(10, 10)
+	h.Reset("table", 0)
+
+	w, err := h.Watch("table", []byte("key"), nil, 0, false)
+	r.NoError(err)
+	w.Close()
+	// Closing twice is a noop.
+	w.Close()
+
+	h.Publish("table", []*regattapb.Event{putEvent("key", 1)})
+	_, err = next(t, w)
+	r.ErrorIs(err, ErrClosed)
+}